@@ -3,10 +3,13 @@ package baseapp
 import (
 	"fmt"
 	"io"
+	"os"
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/tmhash"
@@ -26,6 +29,10 @@ import (
 // and to avoid affecting the Merkle root.
 var dbHeaderKey = []byte("header")
 
+// Key to store the consensus params in the main store, for them to be known
+// again when the app restarts.
+var mainConsensusParamsKey = []byte("consensus_params")
+
 // Enum mode for app.runTx
 type runTxMode uint8
 
@@ -36,8 +43,26 @@ const (
 	runTxModeSimulate runTxMode = iota
 	// Deliver a transaction
 	runTxModeDeliver runTxMode = iota
+	// Check a transaction replacing a pending one in the mempool
+	runTxModeReplace runTxMode = iota
 )
 
+// String returns the name of the mode, as labelled in the metrics.
+func (mode runTxMode) String() string {
+	switch mode {
+	case runTxModeCheck:
+		return "check"
+	case runTxModeSimulate:
+		return "simulate"
+	case runTxModeDeliver:
+		return "deliver"
+	case runTxModeReplace:
+		return "replace"
+	default:
+		return "unknown"
+	}
+}
+
 // BaseApp reflects the ABCI application implementation.
 type BaseApp struct {
 	// initialized on creation
@@ -52,6 +77,12 @@ type BaseApp struct {
 
 	anteHandler sdk.AnteHandler // ante handler for fee and auth
 
+	// set on loading
+	baseKey sdk.StoreKey // key of the main KVStore, holding the consensus params
+
+	// consensus params from InitChain, may be nil
+	consensusParams *abci.ConsensusParams
+
 	// may be nil
 	initChainer      sdk.InitChainer  // initialize state with validators and state blob
 	beginBlocker     sdk.BeginBlocker // logic to run before any txs
@@ -68,8 +99,37 @@ type BaseApp struct {
 	deliverState *state          // for DeliverTx
 	voteInfos    []abci.VoteInfo // absent validators from begin block
 
-	// minimum fees for spam prevention
-	minimumFees sdk.Coins
+	// minimum gas prices for spam prevention
+	minGasPrices sdk.DecCoins
+
+	// weights of the fee denominations when computing gas prices
+	feeWeights sdk.FeeWeights
+
+	// local mempool ordering the checked txs by priority, may be nil
+	mempool *PriorityMempool
+
+	// state snapshots taken every snapshotInterval heights, keeping the
+	// snapshotKeepRecent most recent ones, may be nil
+	snapshots          *store.SnapshotStore
+	snapshotInterval   int64
+	snapshotKeepRecent int
+	// holds a token while a snapshot is exported, so that one runs at a time
+	snapshotLock chan struct{}
+
+	// stores added, renamed and deleted when loading the state, may be nil
+	storeUpgrades *sdk.StoreUpgrades
+
+	// the node halts after committing the block at haltHeight, or the first
+	// block whose time is at or after haltTime (in unix seconds), 0 disables
+	// them; halted is set once it's halting
+	haltHeight int64
+	haltTime   int64
+	halted     bool
+
+	// metrics of the txs and blocks, recorded in the Prometheus registry of
+	// the app if telemetry is enabled
+	metrics  *Metrics
+	registry *stdprometheus.Registry
 
 	// flag for sealing
 	sealed bool
@@ -96,6 +156,7 @@ func NewBaseApp(name string, logger log.Logger, db dbm.DB, txDecoder sdk.TxDecod
 		queryRouter: NewQueryRouter(),
 		codespacer:  sdk.NewCodespacer(),
 		txDecoder:   txDecoder,
+		metrics:     NopMetrics(),
 	}
 
 	// Register the undefined & root codespaces, which should not be used by
@@ -147,18 +208,18 @@ func (app *BaseApp) MountStore(key sdk.StoreKey, typ sdk.StoreType) {
 	app.cms.MountStoreWithDB(key, typ, nil)
 }
 
-// load latest application version
+// load latest application version, applying the store upgrades if any
 func (app *BaseApp) LoadLatestVersion(mainKey sdk.StoreKey) error {
-	err := app.cms.LoadLatestVersion()
+	err := app.cms.LoadLatestVersionAndUpgrade(app.storeUpgrades)
 	if err != nil {
 		return err
 	}
 	return app.initFromStore(mainKey)
 }
 
-// load application version
+// load application version, applying the store upgrades if any
 func (app *BaseApp) LoadVersion(version int64, mainKey sdk.StoreKey) error {
-	err := app.cms.LoadVersion(version)
+	err := app.cms.LoadVersionAndUpgrade(version, app.storeUpgrades)
 	if err != nil {
 		return err
 	}
@@ -178,11 +239,23 @@ func (app *BaseApp) LastBlockHeight() int64 {
 // initializes the remaining logic from app.cms
 func (app *BaseApp) initFromStore(mainKey sdk.StoreKey) error {
 	// main store should exist.
-	// TODO: we don't actually need the main store here
 	main := app.cms.GetKVStore(mainKey)
 	if main == nil {
 		return errors.New("baseapp expects MultiStore with 'main' KVStore")
 	}
+	app.baseKey = mainKey
+
+	// Load the consensus params set by InitChain, if any
+	app.consensusParams = nil
+	if bz := main.Get(mainConsensusParamsKey); bz != nil {
+		var params abci.ConsensusParams
+		err := params.Unmarshal(bz)
+		if err != nil {
+			return errors.Wrap(err, "failed to decode consensus params")
+		}
+		app.consensusParams = &params
+	}
+
 	// Needed for `gaiad export`, which inits from store but never calls initchain
 	app.setCheckState(abci.Header{})
 
@@ -191,13 +264,101 @@ func (app *BaseApp) initFromStore(mainKey sdk.StoreKey) error {
 	return nil
 }
 
-// SetMinimumFees sets the minimum fees.
-func (app *BaseApp) SetMinimumFees(fees sdk.Coins) { app.minimumFees = fees }
+// SetMinGasPrices sets the minimum gas prices.
+func (app *BaseApp) SetMinGasPrices(gasPrices sdk.DecCoins) { app.minGasPrices = gasPrices }
+
+// SetHaltHeight sets the height of the block after which the node halts, 0
+// to disable it.
+func (app *BaseApp) SetHaltHeight(height int64) { app.haltHeight = height }
+
+// SetHaltTime sets the time, in unix seconds, from which the node halts after
+// committing a block, 0 to disable it.
+func (app *BaseApp) SetHaltTime(haltTime int64) { app.haltTime = haltTime }
+
+// EnableTelemetry records the metrics of the app and of its multistore, if
+// it supports them, in a Prometheus registry of the app.
+func (app *BaseApp) EnableTelemetry() {
+	if app.sealed {
+		panic("EnableTelemetry() on sealed BaseApp")
+	}
+	app.registry = stdprometheus.NewRegistry()
+	app.metrics = PrometheusMetrics(MetricsNamespace, app.registry)
+	if ms, ok := app.cms.(store.InstrumentedMultiStore); ok {
+		ms.SetMetrics(store.PrometheusMetrics(MetricsNamespace, app.registry))
+	}
+}
+
+// MetricsRegistry returns the Prometheus registry the metrics of the app are
+// recorded in, for the app to register its own and the server to serve them.
+// It's nil unless telemetry is enabled.
+func (app *BaseApp) MetricsRegistry() *stdprometheus.Registry { return app.registry }
+
+// SetFeeWeights sets the weights of the fee denominations.
+func (app *BaseApp) SetFeeWeights(weights sdk.FeeWeights) { app.feeWeights = weights }
+
+// SetMempool sets the local mempool checked txs are admitted to.
+func (app *BaseApp) SetMempool(mempool *PriorityMempool) { app.mempool = mempool }
+
+// Mempool returns the local mempool, nil if none was set.
+func (app *BaseApp) Mempool() *PriorityMempool { return app.mempool }
+
+// SetStoreUpgrades sets the stores added, renamed and deleted when the state
+// is loaded, e.g. by the release adding a module at its upgrade height.
+func (app *BaseApp) SetStoreUpgrades(upgrades *sdk.StoreUpgrades) {
+	if app.sealed {
+		panic("SetStoreUpgrades() on sealed BaseApp")
+	}
+	if upgrades != nil {
+		if err := upgrades.Validate(); err != nil {
+			panic(err)
+		}
+	}
+	app.storeUpgrades = upgrades
+}
+
+// SetInterBlockCache keeps up to size of the values read from the IAVL stores
+// of the given names cached across blocks. The multistore of the app must
+// support inter-block caches.
+func (app *BaseApp) SetInterBlockCache(size int, storeNames ...string) {
+	if app.sealed {
+		panic("SetInterBlockCache() on sealed BaseApp")
+	}
+	ms, ok := app.cms.(store.InterBlockCacheMultiStore)
+	if !ok {
+		panic("multistore doesn't support inter-block caches")
+	}
+	ms.SetInterBlockCache(size, storeNames...)
+}
+
+// SetStreamingListener registers a listener notified of the changes
+// committed to the stores of the given names at every block. The multistore
+// of the app must support listeners.
+func (app *BaseApp) SetStreamingListener(listener store.WriteListener, storeNames ...string) {
+	if app.sealed {
+		panic("SetStreamingListener() on sealed BaseApp")
+	}
+	ms, ok := app.cms.(store.ListenableMultiStore)
+	if !ok {
+		panic("multistore doesn't support streaming listeners")
+	}
+	ms.AddListener(listener, storeNames...)
+}
+
+// SetSnapshotStore sets the store the state is snapshotted to every interval
+// heights, keeping the keepRecent most recent snapshots. A non-positive
+// keepRecent keeps them all.
+func (app *BaseApp) SetSnapshotStore(snapshots *store.SnapshotStore, interval int64, keepRecent int) {
+	app.snapshots = snapshots
+	app.snapshotInterval = interval
+	app.snapshotKeepRecent = keepRecent
+	app.snapshotLock = make(chan struct{}, 1)
+}
 
 // NewContext returns a new Context with the correct store, the given header, and nil txBytes.
 func (app *BaseApp) NewContext(isCheckTx bool, header abci.Header) sdk.Context {
 	if isCheckTx {
-		return sdk.NewContext(app.checkState.ms, header, true, app.Logger).WithMinimumFees(app.minimumFees)
+		return sdk.NewContext(app.checkState.ms, header, true, app.Logger).
+			WithMinGasPrices(app.minGasPrices).WithFeeWeights(app.feeWeights)
 	}
 	return sdk.NewContext(app.deliverState.ms, header, false, app.Logger)
 }
@@ -215,7 +376,7 @@ func (app *BaseApp) setCheckState(header abci.Header) {
 	ms := app.cms.CacheMultiStore()
 	app.checkState = &state{
 		ms:  ms,
-		ctx: sdk.NewContext(ms, header, true, app.Logger).WithMinimumFees(app.minimumFees),
+		ctx: sdk.NewContext(ms, header, true, app.Logger).WithMinGasPrices(app.minGasPrices).WithFeeWeights(app.feeWeights),
 	}
 }
 
@@ -255,6 +416,16 @@ func (app *BaseApp) InitChain(req abci.RequestInitChain) (res abci.ResponseInitC
 	app.setDeliverState(abci.Header{ChainID: req.ChainId})
 	app.setCheckState(abci.Header{ChainID: req.ChainId})
 
+	// Store the consensus params, committed along with the first block
+	if req.ConsensusParams != nil {
+		bz, err := req.ConsensusParams.Marshal()
+		if err != nil {
+			panic(err)
+		}
+		app.consensusParams = req.ConsensusParams
+		app.deliverState.ctx.KVStore(app.baseKey).Set(mainConsensusParamsKey, bz)
+	}
+
 	if app.initChainer == nil {
 		return
 	}
@@ -325,7 +496,8 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) (res abc
 			if err != nil {
 				result = err.Result()
 			} else {
-				result = app.Simulate(tx)
+				// pass the tx bytes, so that the tx size is charged as on delivery
+				result = app.runTx(runTxModeSimulate, txBytes, tx)
 			}
 		case "version":
 			return abci.ResponseQuery{
@@ -392,8 +564,20 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 		return sdk.ErrUnknownRequest(fmt.Sprintf("no custom querier found for route %s", path[1])).QueryResult()
 	}
 
-	ctx := sdk.NewContext(app.cms.CacheMultiStore(), app.checkState.ctx.BlockHeader(), true, app.Logger).
-		WithMinimumFees(app.minimumFees)
+	cacheMS, height, err := app.queryMultiStore(req.Height)
+	if err != nil {
+		return err.QueryResult()
+	}
+
+	// Only the header of the last block is kept: queries at past heights get a
+	// header with just the chain ID and the height, and no block time.
+	header := app.checkState.ctx.BlockHeader()
+	if height != app.LastBlockHeight() {
+		header = abci.Header{ChainID: header.ChainID, Height: height}
+	}
+
+	ctx := sdk.NewContext(cacheMS, header, true, app.Logger).
+		WithBlockHeight(height).WithMinGasPrices(app.minGasPrices).WithFeeWeights(app.feeWeights)
 	// Passes the rest of the path as an argument to the querier.
 	// For example, in the path "custom/gov/proposal/test", the gov querier gets []string{"proposal", "test"} as the path
 	resBytes, err := querier(ctx, path[2:], req)
@@ -404,13 +588,45 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 		}
 	}
 	return abci.ResponseQuery{
-		Code:  uint32(sdk.ABCICodeOK),
-		Value: resBytes,
+		Code:   uint32(sdk.ABCICodeOK),
+		Value:  resBytes,
+		Height: height,
+	}
+}
+
+// Returns a cache multistore of the state committed at the given height for
+// a custom query to run on, and the height, which is the last block height
+// if zero.
+func (app *BaseApp) queryMultiStore(height int64) (sdk.CacheMultiStore, int64, sdk.Error) {
+	lastHeight := app.LastBlockHeight()
+	if height == 0 || height == lastHeight {
+		return app.cms.CacheMultiStore(), lastHeight, nil
+	}
+	if height < 0 || height > lastHeight {
+		return nil, 0, sdk.ErrUnknownRequest(
+			fmt.Sprintf("cannot query at height %d, the last block height is %d", height, lastHeight))
 	}
+
+	ms, ok := app.cms.(store.VersionedMultiStore)
+	if !ok {
+		return nil, 0, sdk.ErrUnknownRequest("multistore doesn't support queries at past heights")
+	}
+	cacheMS, err := ms.CacheMultiStoreWithVersion(height)
+	if err != nil {
+		return nil, 0, sdk.ErrUnknownRequest(err.Error())
+	}
+	return cacheMS, height, nil
 }
 
 // BeginBlock implements the ABCI application interface.
 func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeginBlock) {
+	// Never run a block past the halt height, or after the halting block.
+	// The block can't be acknowledged without running it, so the consensus
+	// stops here until the node shuts down.
+	if app.halted || (app.haltHeight > 0 && req.Header.Height > app.haltHeight) {
+		panic(fmt.Sprintf("node halted at height %d, refusing to run block %d", app.LastBlockHeight(), req.Header.Height))
+	}
+
 	if app.cms.TracingEnabled() {
 		app.cms.ResetTraceContext()
 		app.cms.WithTracingContext(sdk.TraceContext(
@@ -429,8 +645,19 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 		app.deliverState.ctx = app.deliverState.ctx.WithBlockHeader(req.Header).WithBlockHeight(req.Header.Height)
 	}
 
+	// The txs of the block share the max block gas
+	var blockGasMeter sdk.GasMeter
+	if maxGas := app.maxBlockGas(); maxGas > 0 {
+		blockGasMeter = sdk.NewGasMeter(maxGas)
+	} else {
+		blockGasMeter = sdk.NewInfiniteGasMeter()
+	}
+	app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(blockGasMeter)
+
 	if app.beginBlocker != nil {
-		res = app.beginBlocker(app.deliverState.ctx, req)
+		ctx := app.deliverState.ctx.WithEventManager(sdk.NewEventManager())
+		res = app.beginBlocker(ctx, req)
+		res.Tags = append(res.Tags, ctx.EventManager().Events().ToTags()...)
 	}
 
 	// set the signed validators for addition to context in deliverTx
@@ -450,8 +677,14 @@ func (app *BaseApp) CheckTx(txBytes []byte) (res abci.ResponseCheckTx) {
 	var tx, err = app.txDecoder(txBytes)
 	if err != nil {
 		result = err.Result()
+		if app.mempool != nil {
+			app.mempool.Remove(txBytes)
+		}
 	} else {
 		result = app.runTx(runTxModeCheck, txBytes, tx)
+		if app.mempool != nil {
+			result = app.admitToMempool(txBytes, tx, result)
+		}
 	}
 
 	return abci.ResponseCheckTx{
@@ -475,6 +708,15 @@ func (app *BaseApp) DeliverTx(txBytes []byte) (res abci.ResponseDeliverTx) {
 		result = app.runTx(runTxModeDeliver, txBytes, tx)
 	}
 
+	// The tx is in a block now, it no longer waits in the mempool, and
+	// neither does a pending tx of the same sender and sequence.
+	if app.mempool != nil {
+		app.mempool.Remove(txBytes)
+		if tx != nil {
+			app.mempool.removeSender(mempoolSenderKey(tx))
+		}
+	}
+
 	// Even though the Result.Code is not OK, there are still effects,
 	// namely fee deductions and sequence incrementing.
 
@@ -519,14 +761,19 @@ func (app *BaseApp) getContextForAnte(mode runTxMode, txBytes []byte) (ctx sdk.C
 	return
 }
 
-// Iterates through msgs and executes them
+// Iterates through msgs and executes them. Each msg emits its events on its
+// own event manager, so that the log of the tx tells which msg emitted which
+// event.
 func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (result sdk.Result) {
 	// accumulate results
-	logs := make([]string, 0, len(msgs))
+	logs := make(sdk.ABCIMessageLogs, 0, len(msgs))
 	var data []byte   // NOTE: we just append them all (?!)
 	var tags sdk.Tags // also just append them all
+	events := sdk.EmptyEvents()
 	var code sdk.ABCICodeType
 	for msgIdx, msg := range msgs {
+		start := time.Now()
+
 		// Match route.
 		msgType := msg.Type()
 		handler := app.router.Route(msgType)
@@ -534,39 +781,50 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (re
 			return sdk.ErrUnknownRequest("Unrecognized Msg type: " + msgType).Result()
 		}
 
+		msgCtx := ctx.WithEventManager(sdk.NewEventManager())
+		msgCtx.EventManager().EmitEvent(sdk.NewEvent(sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyAction, msg.Name()),
+		))
+
 		var msgResult sdk.Result
 		// Skip actual execution for CheckTx
-		if mode != runTxModeCheck {
-			msgResult = handler(ctx, msg)
+		if mode != runTxModeCheck && mode != runTxModeReplace {
+			msgResult = handler(msgCtx, msg)
 		}
-		msgResult.Tags = append(msgResult.Tags, sdk.MakeTag("action", []byte(msg.Name())))
 
-		// NOTE: GasWanted is determined by ante handler and
-		// GasUsed by the GasMeter
-
-		// Append Data and Tags
-		data = append(data, msgResult.Data...)
-		tags = append(tags, msgResult.Tags...)
+		app.metrics.Msgs.With("mode", mode.String(), "route", msgType).Add(1)
+		app.metrics.MsgDuration.With("mode", mode.String(), "route", msgType).Observe(time.Since(start).Seconds())
 
 		// Stop execution and return on first failed message.
 		if !msgResult.IsOK() {
-			logs = append(logs, fmt.Sprintf("Msg %d failed: %s", msgIdx, msgResult.Log))
+			logs = append(logs, sdk.ABCIMessageLog{MsgIndex: msgIdx, Success: false, Log: msgResult.Log, Events: sdk.EmptyEvents()})
 			code = msgResult.Code
 			break
 		}
 
+		// NOTE: GasWanted is determined by ante handler and
+		// GasUsed by the GasMeter
+
+		// Append Data, Tags and Events
+		msgEvents := msgCtx.EventManager().Events().AppendEvents(msgResult.Events)
+		data = append(data, msgResult.Data...)
+		tags = append(tags, msgResult.Tags...)
+		tags = append(tags, msgEvents.ToTags()...)
+		events = events.AppendEvents(msgEvents)
+
 		// Construct usable logs in multi-message transactions.
-		logs = append(logs, fmt.Sprintf("Msg %d: %s", msgIdx, msgResult.Log))
+		logs = append(logs, sdk.ABCIMessageLog{MsgIndex: msgIdx, Success: true, Log: msgResult.Log, Events: msgEvents})
 	}
 
 	// Set the final gas values.
 	result = sdk.Result{
 		Code:    code,
 		Data:    data,
-		Log:     strings.Join(logs, "\n"),
+		Log:     logs.String(),
 		GasUsed: ctx.GasMeter().GasConsumed(),
 		// TODO: FeeAmount/FeeDenom
-		Tags: tags,
+		Tags:   tags,
+		Events: events,
 	}
 
 	return result
@@ -575,7 +833,7 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (re
 // Returns the applicantion's deliverState if app is in runTxModeDeliver,
 // otherwise it returns the application's checkstate.
 func getState(app *BaseApp, mode runTxMode) *state {
-	if mode == runTxModeCheck || mode == runTxModeSimulate {
+	if mode == runTxModeCheck || mode == runTxModeSimulate || mode == runTxModeReplace {
 		return app.checkState
 	}
 
@@ -586,6 +844,11 @@ func (app *BaseApp) initializeContext(ctx sdk.Context, mode runTxMode) sdk.Conte
 	if mode == runTxModeSimulate {
 		ctx = ctx.WithMultiStore(getState(app, runTxModeSimulate).CacheMultiStore())
 	}
+	// a replacement is checked against the last committed state, as the check
+	// state already includes the tx it replaces
+	if mode == runTxModeReplace {
+		ctx = ctx.WithMultiStore(app.cms.CacheMultiStore())
+	}
 	return ctx
 }
 
@@ -597,10 +860,28 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	// determined by the GasMeter. We need access to the context to get the gas
 	// meter so we initialize upfront.
 	var gasWanted int64
+	var priority int64
 	var msCache sdk.CacheMultiStore
+	var blockGasConsumed bool
+	start := time.Now()
 	ctx := app.getContextForAnte(mode, txBytes)
 	ctx = app.initializeContext(ctx, mode)
 
+	// A delivered tx can't start once the block has used up its gas
+	if mode == runTxModeDeliver && ctx.BlockGasMeter().IsOutOfGas() {
+		return sdk.ErrOutOfGas("block gas limit reached, no gas left for the tx").Result()
+	}
+
+	// The gas meter of the state is shared by all its txs, a tx only uses the
+	// gas of the block once the ante handler sets a gas meter of its own
+	stateGasMeter := ctx.GasMeter()
+	blockGasUsed := func() int64 {
+		if ctx.GasMeter() == stateGasMeter {
+			return 0
+		}
+		return ctx.GasMeter().GasConsumed()
+	}
+
 	defer func() {
 		if r := recover(); r != nil {
 			switch rType := r.(type) {
@@ -615,6 +896,17 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 
 		result.GasWanted = gasWanted
 		result.GasUsed = ctx.GasMeter().GasConsumed()
+		result.Priority = priority
+
+		// A failed tx still uses the gas of the block
+		if mode == runTxModeDeliver && !blockGasConsumed {
+			consumeBlockGas(ctx, blockGasUsed())
+		}
+
+		app.metrics.TxDuration.With("mode", mode.String()).Observe(time.Since(start).Seconds())
+		if mode == runTxModeDeliver {
+			app.metrics.TxGasUsed.Observe(float64(result.GasUsed))
+		}
 	}()
 
 	var msgs = tx.GetMsgs()
@@ -625,14 +917,16 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	// run the ante handler
 	if app.anteHandler != nil {
 		newCtx, result, abort := app.anteHandler(ctx, tx, (mode == runTxModeSimulate))
-		if abort {
-			return result
-		}
+		// keep the gas meter set for the tx even if the ante handler aborts
 		if !newCtx.IsZero() {
 			ctx = newCtx
 		}
+		if abort {
+			return result
+		}
 
 		gasWanted = result.GasWanted
+		priority = result.Priority
 	}
 
 	if mode == runTxModeSimulate {
@@ -654,7 +948,14 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	result = app.runMsgs(ctx, msgs, mode)
 	result.GasWanted = gasWanted
 
-	// only update state if all messages pass
+	if mode == runTxModeDeliver {
+		blockGasConsumed = true
+		if err := consumeBlockGas(ctx, blockGasUsed()); err != nil && result.IsOK() {
+			result = err.Result()
+		}
+	}
+
+	// only update state if all messages pass within the block gas limit
 	if result.IsOK() {
 		msCache.Write()
 	}
@@ -662,6 +963,29 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	return
 }
 
+// Returns the max gas of a block, or a value <= 0 if unlimited.
+func (app *BaseApp) maxBlockGas() int64 {
+	if app.consensusParams == nil || app.consensusParams.BlockSize == nil {
+		return 0
+	}
+	return app.consensusParams.BlockSize.MaxGas
+}
+
+// Consumes the gas used by the tx from the block gas meter, returning an
+// error if the block goes over its gas limit.
+func consumeBlockGas(ctx sdk.Context, gasUsed int64) (err sdk.Error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			err = sdk.ErrOutOfGas("block gas limit exceeded by the tx")
+		}
+	}()
+	ctx.BlockGasMeter().ConsumeGas(gasUsed, "block gas meter")
+	return nil
+}
+
 // EndBlock implements the ABCI application interface.
 func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBlock) {
 	if app.deliverState.ms.TracingEnabled() {
@@ -669,9 +993,13 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 	}
 
 	if app.endBlocker != nil {
-		res = app.endBlocker(app.deliverState.ctx, req)
+		ctx := app.deliverState.ctx.WithEventManager(sdk.NewEventManager())
+		res = app.endBlocker(ctx, req)
+		res.Tags = append(res.Tags, ctx.EventManager().Events().ToTags()...)
 	}
 
+	app.metrics.BlockGasUsed.Set(float64(app.deliverState.ctx.BlockGasMeter().GasConsumed()))
+
 	return
 }
 
@@ -695,6 +1023,10 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 		"commit", commitID,
 	)
 
+	if app.snapshots != nil && app.snapshotInterval > 0 && commitID.Version%app.snapshotInterval == 0 {
+		app.snapshot(commitID.Version)
+	}
+
 	// Reset the Check state to the latest committed
 	// NOTE: safe because Tendermint holds a lock on the mempool for Commit.
 	// Use the header from this latest block.
@@ -703,7 +1035,73 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	// Empty the Deliver state
 	app.deliverState = nil
 
+	if (app.haltHeight > 0 && header.Height >= app.haltHeight) ||
+		(app.haltTime > 0 && header.Time.Unix() >= app.haltTime) {
+		app.Logger.Info("Halting node per configuration",
+			"height", header.Height, "time", header.Time, "halt-height", app.haltHeight, "halt-time", app.haltTime)
+		app.halt()
+	}
+
 	return abci.ResponseCommit{
 		Data: commitID.Hash,
 	}
 }
+
+// halt interrupts the process of the node, for the server to stop it and
+// close its databases, or exits if the process can't be interrupted. The
+// block just committed is the last one the app runs.
+func (app *BaseApp) halt() {
+	app.halted = true
+	p, err := os.FindProcess(os.Getpid())
+	if err == nil {
+		err = p.Signal(os.Interrupt)
+	}
+	if err != nil {
+		app.Logger.Error("Failed to interrupt the node, exiting", "err", err)
+		os.Exit(0)
+	}
+}
+
+// snapshot exports the state committed at the given height in the
+// background, so as not to hold up the blocks, and prunes the older
+// snapshots. The version is pinned until exported so that pruning keeps it,
+// and the snapshot is skipped if the previous one is still being exported.
+// Errors are logged as a failed snapshot must not halt the node.
+func (app *BaseApp) snapshot(height int64) {
+	snapshotter, ok := app.cms.(store.Snapshotter)
+	if !ok {
+		app.Logger.Error("Multistore doesn't support snapshots")
+		return
+	}
+	select {
+	case app.snapshotLock <- struct{}{}:
+	default:
+		app.Logger.Error("Skipping state snapshot, the previous one is still being created", "height", height)
+		return
+	}
+	unpin := snapshotter.PinVersion(height)
+
+	go func() {
+		defer func() { <-app.snapshotLock }()
+		defer unpin()
+
+		snapshot, err := app.snapshots.Create(snapshotter, height, store.DefaultSnapshotChunkSize)
+		if err != nil {
+			app.Logger.Error("Failed to create state snapshot", "height", height, "err", err)
+			return
+		}
+		app.Logger.Info("Created state snapshot", "height", height, "chunks", len(snapshot.Chunks))
+
+		if err := app.snapshots.Prune(app.snapshotKeepRecent); err != nil {
+			app.Logger.Error("Failed to prune state snapshots", "err", err)
+		}
+	}()
+}
+
+// Waits for the snapshot being exported, if any.
+func (app *BaseApp) waitSnapshot() {
+	if app.snapshotLock != nil {
+		app.snapshotLock <- struct{}{}
+		<-app.snapshotLock
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,6 +20,7 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -139,6 +145,162 @@ func testLoadVersionHelper(t *testing.T, app *BaseApp, expectedHeight int64, exp
 	require.Equal(t, expectedID, lastID)
 }
 
+// Test that the state is snapshotted periodically and that a new node can be
+// started from a snapshot.
+func TestSnapshots(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	logger := defaultLogger()
+	capKey := sdk.NewKVStoreKey("main")
+	app := NewBaseApp(t.Name(), logger, dbm.NewMemDB(), nil, SetSnapshots(dir, 2, 1))
+	app.MountStoresIAVL(capKey)
+	err = app.LoadLatestVersion(capKey)
+	require.Nil(t, err)
+
+	var commitID sdk.CommitID
+	for height := int64(1); height <= 5; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		app.deliverState.ctx.KVStore(capKey).Set([]byte(fmt.Sprintf("key%d", height)), []byte("value"))
+		res := app.Commit()
+		app.waitSnapshot()
+		if height == 4 {
+			commitID = sdk.CommitID{Version: height, Hash: res.Data}
+		}
+	}
+
+	// only the most recent snapshot is kept
+	snapshots := store.NewSnapshotStore(dir)
+	list, err := snapshots.List()
+	require.Nil(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, commitID, list[0].CommitID())
+
+	db := dbm.NewMemDB()
+	err = snapshots.Restore(store.NewCommitMultiStore(db), 4)
+	require.Nil(t, err)
+	app = NewBaseApp(t.Name(), logger, db, nil)
+	app.MountStoresIAVL(capKey)
+	err = app.LoadLatestVersion(capKey)
+	require.Nil(t, err)
+	testLoadVersionHelper(t, app, int64(4), commitID)
+	require.Equal(t, []byte("value"), app.cms.GetCommitKVStore(capKey).Get([]byte("key4")))
+}
+
+// Test that a store renamed by a store upgrade keeps its data.
+func TestStoreUpgrades(t *testing.T) {
+	logger := defaultLogger()
+	db := dbm.NewMemDB()
+	capKey := sdk.NewKVStoreKey("main")
+	oldKey := sdk.NewKVStoreKey("old")
+	app := NewBaseApp(t.Name(), logger, db, nil)
+	app.MountStoresIAVL(capKey, oldKey)
+	err := app.LoadLatestVersion(capKey)
+	require.Nil(t, err)
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	app.deliverState.ctx.KVStore(oldKey).Set([]byte("key"), []byte("value"))
+	res := app.Commit()
+	commitID := sdk.CommitID{Version: 1, Hash: res.Data}
+
+	// the renamed store can't be loaded without the upgrade
+	newKey := sdk.NewKVStoreKey("new")
+	app = NewBaseApp(t.Name(), logger, db, nil)
+	app.MountStoresIAVL(capKey, newKey)
+	require.NotNil(t, app.LoadLatestVersion(capKey))
+
+	upgrades := sdk.StoreUpgrades{Height: 2, Renamed: []sdk.StoreRename{{OldName: "old", NewName: "new"}}}
+	app = NewBaseApp(t.Name(), logger, db, nil, SetStoreUpgrades(upgrades))
+	app.MountStoresIAVL(capKey, newKey)
+	err = app.LoadLatestVersion(capKey)
+	require.Nil(t, err)
+	testLoadVersionHelper(t, app, int64(1), commitID)
+	require.Equal(t, []byte("value"), app.cms.GetCommitKVStore(newKey).Get([]byte("key")))
+}
+
+func TestStreamingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "streaming")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "changes")
+
+	app := setupBaseApp(t, SetStreamingFile(path, []string{capKey1.Name()}))
+	for height := int64(1); height <= 2; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		key := []byte(fmt.Sprintf("key%d", height))
+		app.deliverState.ctx.KVStore(capKey1).Set(key, []byte("value"))
+		app.deliverState.ctx.KVStore(capKey2).Set(key, []byte("value"))
+		app.Commit()
+	}
+
+	// only the changes to the listened store are streamed
+	file, err := os.Open(path)
+	require.Nil(t, err)
+	defer file.Close()
+	for height := int64(1); height <= 2; height++ {
+		changeSet, err := store.ReadChangeSet(file)
+		require.Nil(t, err)
+		require.Equal(t, height, changeSet.Height)
+		require.Len(t, changeSet.Stores, 1)
+		require.Equal(t, capKey1.Name(), changeSet.Stores[0].StoreKey)
+		require.Equal(t, []store.StoreKVPair{
+			{Key: []byte(fmt.Sprintf("key%d", height)), Value: []byte("value")},
+		}, changeSet.Stores[0].Pairs)
+	}
+	_, err = store.ReadChangeSet(file)
+	require.Equal(t, io.EOF, err)
+}
+
+// Test that the node halts after committing the block at the halt height or
+// time, and runs no block past it
+func TestTelemetryRegistry(t *testing.T) {
+	// each app records its metrics in its own registry, so that several apps
+	// of a process can enable telemetry
+	for i := 0; i < 2; i++ {
+		app := newBaseApp(t.Name(), SetTelemetry(true))
+		require.NotNil(t, app.MetricsRegistry())
+	}
+	require.Nil(t, newBaseApp(t.Name()).MetricsRegistry())
+}
+
+func TestHalt(t *testing.T) {
+	// the app interrupts its own process when halting
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt)
+	defer signal.Stop(sigs)
+	halted := func() bool {
+		select {
+		case <-sigs:
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}
+	runBlock := func(app *BaseApp, header abci.Header) {
+		app.BeginBlock(abci.RequestBeginBlock{Header: header})
+		app.EndBlock(abci.RequestEndBlock{})
+		app.Commit()
+	}
+
+	app := setupBaseApp(t, SetHaltHeight(2))
+	runBlock(app, abci.Header{Height: 1})
+	require.False(t, halted())
+	runBlock(app, abci.Header{Height: 2})
+	require.True(t, halted())
+	require.Equal(t, int64(2), app.LastBlockHeight())
+	require.Panics(t, func() { runBlock(app, abci.Header{Height: 3}) })
+	require.Equal(t, int64(2), app.LastBlockHeight())
+
+	haltTime := time.Unix(1540000000, 0)
+	app = setupBaseApp(t, SetHaltTime(haltTime.Unix()))
+	runBlock(app, abci.Header{Height: 1, Time: haltTime.Add(-time.Second)})
+	require.False(t, halted())
+	runBlock(app, abci.Header{Height: 2, Time: haltTime.Add(time.Second)})
+	require.True(t, halted())
+	require.Panics(t, func() { runBlock(app, abci.Header{Height: 3, Time: haltTime.Add(2 * time.Second)}) })
+	require.Equal(t, int64(2), app.LastBlockHeight())
+}
+
 func TestOptionFunction(t *testing.T) {
 	logger := defaultLogger()
 	db := dbm.NewMemDB()
@@ -208,6 +370,89 @@ func TestInfo(t *testing.T) {
 //------------------------------------------------------------------------------------------
 // InitChain, BeginBlock, EndBlock
 
+// Test that the txs of a block fail once they exceed the max block gas
+func TestMaxBlockGasLimits(t *testing.T) {
+	gasGranted := int64(100)
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+			// abort before setting the gas meter of the tx
+			if tx.(*txTest).Counter < 0 {
+				ctx.GasMeter().ConsumeGas(7, "shared")
+				return ctx, sdk.ErrUnauthorized("negative counter").Result(), true
+			}
+			newCtx = ctx.WithGasMeter(sdk.NewGasMeter(gasGranted))
+			newCtx.GasMeter().ConsumeGas(tx.(*txTest).Counter, "counter-ante")
+			return newCtx, sdk.Result{GasWanted: gasGranted}, false
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(typeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			ctx.GasMeter().ConsumeGas(msg.(msgCounter).Counter, "counter-handler")
+			if msg.(msgCounter).Counter == 0 {
+				return sdk.ErrInternal("failing msg").Result()
+			}
+			return sdk.Result{}
+		})
+	}
+	var endBlockGas int64
+	endBlockerOpt := func(bapp *BaseApp) {
+		bapp.SetEndBlocker(func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+			endBlockGas = ctx.BlockGasMeter().GasConsumed()
+			return abci.ResponseEndBlock{}
+		})
+	}
+
+	db := dbm.NewMemDB()
+	app := NewBaseApp(t.Name(), defaultLogger(), db, nil, anteOpt, routerOpt, endBlockerOpt)
+	app.MountStoresIAVL(capKey1)
+	require.Nil(t, app.LoadLatestVersion(capKey1))
+	app.InitChain(abci.RequestInitChain{
+		ConsensusParams: &abci.ConsensusParams{
+			BlockSize: &abci.BlockSize{MaxGas: 100},
+		},
+	})
+
+	testCases := []struct {
+		tx       *txTest
+		blockGas int64
+		fail     bool
+	}{
+		{newTxCounter(10, 20), 30, false},
+		// a failing tx uses the gas of the block too
+		{newTxCounter(5, 0), 35, true},
+		// but not the gas of the shared meter if it has no meter of its own
+		{newTxCounter(-1, 1), 35, true},
+		{newTxCounter(10, 50), 95, false},
+		// the tx exceeding the block gas fails
+		{newTxCounter(0, 10), 105, true},
+		// no tx runs once the block is out of gas
+		{newTxCounter(0, 1), 105, true},
+	}
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	for i, tc := range testCases {
+		res := app.Deliver(tc.tx)
+		require.Equal(t, !tc.fail, res.IsOK(), fmt.Sprintf("%d: %v", i, res))
+		require.Equal(t, tc.blockGas, app.deliverState.ctx.BlockGasMeter().GasConsumed(), fmt.Sprintf("%d", i))
+	}
+	app.EndBlock(abci.RequestEndBlock{})
+	require.Equal(t, int64(105), endBlockGas)
+	app.Commit()
+
+	// the next block starts with the whole max gas
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	require.True(t, app.Deliver(newTxCounter(0, 100)).IsOK())
+	res := app.Deliver(newTxCounter(0, 1))
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfGas), res.Code)
+	app.Commit()
+
+	// the max block gas is known again after a restart
+	app = NewBaseApp(t.Name(), defaultLogger(), db, nil, anteOpt, routerOpt)
+	app.MountStoresIAVL(capKey1)
+	require.Nil(t, app.LoadLatestVersion(capKey1))
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	require.Equal(t, int64(100), app.deliverState.ctx.BlockGasMeter().Limit())
+}
+
 func TestInitChainer(t *testing.T) {
 	name := t.Name()
 	// keep the db and logger ourselves so
@@ -583,6 +828,47 @@ func TestMultiMsgDeliverTx(t *testing.T) {
 	}
 }
 
+// The events of a tx are logged per msg and indexed as tags.
+func TestMultiMsgEvents(t *testing.T) {
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(typeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			counter := fmt.Sprintf("%d", msg.(msgCounter).Counter)
+			ctx.EventManager().EmitEvent(sdk.NewEvent("counter", sdk.NewAttribute("value", counter)))
+			return sdk.Result{}
+		})
+	}
+
+	app := setupBaseApp(t, routerOpt)
+	app.BeginBlock(abci.RequestBeginBlock{})
+
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	txBytes, err := codec.MarshalBinary(newTxCounter(0, 0, 1))
+	require.NoError(t, err)
+	res := app.DeliverTx(txBytes)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+
+	logs, err := sdk.ParseABCIMessageLogs(res.Log)
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	for i, log := range logs {
+		require.Equal(t, i, log.MsgIndex)
+		require.True(t, log.Success)
+		require.Equal(t, sdk.Events{
+			sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyAction, "counter1")),
+			sdk.NewEvent("counter", sdk.NewAttribute("value", fmt.Sprintf("%d", i))),
+		}, log.Events)
+	}
+
+	require.Equal(t, sdk.Tags{
+		sdk.MakeTag("message.action", []byte("counter1")),
+		sdk.MakeTag("counter.value", []byte("0")),
+		sdk.MakeTag("message.action", []byte("counter1")),
+		sdk.MakeTag("counter.value", []byte("1")),
+	}, sdk.Tags(res.Tags))
+}
+
 // Interleave calls to Check and Deliver and ensure
 // that there is no cross-talk. Check sees results of the previous Check calls
 // and Deliver sees that of the previous Deliver calls, but they don't see eachother.
@@ -598,6 +884,8 @@ func TestSimulateTx(t *testing.T) {
 
 	anteOpt := func(bapp *BaseApp) {
 		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+			// the tx bytes are simulated too
+			require.NotEmpty(t, ctx.TxBytes())
 			newCtx = ctx.WithGasMeter(sdk.NewGasMeter(gasConsumed))
 			return
 		})
@@ -624,20 +912,20 @@ func TestSimulateTx(t *testing.T) {
 		app.BeginBlock(abci.RequestBeginBlock{})
 
 		tx := newTxCounter(count, count)
+		txBytes, err := cdc.MarshalBinary(tx)
+		require.Nil(t, err)
 
 		// simulate a message, check gas reported
-		result := app.Simulate(tx)
+		result := app.Simulate(txBytes, tx)
 		require.True(t, result.IsOK(), result.Log)
 		require.Equal(t, gasConsumed, result.GasUsed)
 
 		// simulate again, same result
-		result = app.Simulate(tx)
+		result = app.Simulate(txBytes, tx)
 		require.True(t, result.IsOK(), result.Log)
 		require.Equal(t, gasConsumed, result.GasUsed)
 
 		// simulate by calling Query with encoded tx
-		txBytes, err := cdc.MarshalBinary(tx)
-		require.Nil(t, err)
 		query := abci.RequestQuery{
 			Path: "/app/simulate",
 			Data: txBytes,
@@ -821,3 +1109,58 @@ func TestTxGasLimits(t *testing.T) {
 		}
 	}
 }
+
+//-------------------------------------------------------------------------------------------
+// Queries
+
+// Custom queries run on the state committed at the height they ask for, with
+// the header of the last block only at the last height.
+func TestQueryCustomHeight(t *testing.T) {
+	heightKey := []byte("height")
+	blockerOpt := func(bapp *BaseApp) {
+		bapp.SetBeginBlocker(func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+			setIntOnStore(ctx.KVStore(capKey1), heightKey, req.Header.Height)
+			return abci.ResponseBeginBlock{}
+		})
+	}
+	querierOpt := func(bapp *BaseApp) {
+		bapp.QueryRouter().AddRoute("height", func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+			return i2b(getIntFromStore(ctx.KVStore(capKey1), heightKey)), nil
+		})
+		bapp.QueryRouter().AddRoute("time", func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+			return i2b(ctx.BlockHeader().Time.Unix()), nil
+		})
+	}
+
+	app := setupBaseApp(t, blockerOpt, querierOpt)
+	for height := int64(1); height <= 3; height++ {
+		header := abci.Header{Height: height, Time: time.Unix(height*10, 0)}
+		app.BeginBlock(abci.RequestBeginBlock{Header: header})
+		app.EndBlock(abci.RequestEndBlock{})
+		app.Commit()
+	}
+
+	// the latest height by default
+	res := app.Query(abci.RequestQuery{Path: "/custom/height"})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, int64(3), res.Height)
+	require.Equal(t, i2b(3), res.Value)
+
+	for height := int64(1); height <= 3; height++ {
+		res = app.Query(abci.RequestQuery{Path: "/custom/height", Height: height})
+		require.True(t, res.IsOK(), res.Log)
+		require.Equal(t, height, res.Height)
+		require.Equal(t, i2b(height), res.Value)
+	}
+
+	res = app.Query(abci.RequestQuery{Path: "/custom/height", Height: 4})
+	require.False(t, res.IsOK())
+
+	// the block time is only known at the last height
+	res = app.Query(abci.RequestQuery{Path: "/custom/time", Height: 3})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, i2b(30), res.Value)
+	res = app.Query(abci.RequestQuery{Path: "/custom/time", Height: 2})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, i2b(time.Time{}.Unix()), res.Value)
+}
@@ -12,9 +12,10 @@ func (app *BaseApp) Check(tx sdk.Tx) (result sdk.Result) {
 	return app.runTx(runTxModeCheck, nil, tx)
 }
 
-// nolint - full tx execution
-func (app *BaseApp) Simulate(tx sdk.Tx) (result sdk.Result) {
-	return app.runTx(runTxModeSimulate, nil, tx)
+// nolint - full tx execution, txBytes are the encoded tx whose size the ante
+// handler charges gas for
+func (app *BaseApp) Simulate(txBytes []byte, tx sdk.Tx) (result sdk.Result) {
+	return app.runTx(runTxModeSimulate, txBytes, tx)
 }
 
 // nolint
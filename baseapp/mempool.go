@@ -0,0 +1,205 @@
+package baseapp
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// SequencedTx is implemented by txs exposing the account paying their fee and
+// the sequence it signed them with. The mempool lets such a tx be replaced by
+// one from the same account and sequence which pays a higher gas price, as
+// long as it is the first pending tx of the account.
+type SequencedTx interface {
+	sdk.Tx
+	GetFeePayerSequence() (sdk.AccAddress, int64)
+}
+
+// PriorityMempool is a local stand-in for a fee-prioritized mempool. It keeps
+// the txs accepted by CheckTx ordered by the priority the AnteHandler computed
+// for them and, once full, only admits a tx by evicting a lower priority one.
+type PriorityMempool struct {
+	mtx      sync.Mutex
+	maxTxs   int
+	txs      []*mempoolTx          // sorted by decreasing priority, then by arrival
+	byHash   map[string]*mempoolTx // indexed by tx hash
+	bySender map[string]*mempoolTx // indexed by fee payer and sequence
+}
+
+type mempoolTx struct {
+	txBytes   []byte
+	hash      string
+	senderKey string
+	priority  int64
+}
+
+// NewPriorityMempool returns a mempool holding at most maxTxs txs.
+func NewPriorityMempool(maxTxs int) *PriorityMempool {
+	return &PriorityMempool{
+		maxTxs:   maxTxs,
+		byHash:   make(map[string]*mempoolTx),
+		bySender: make(map[string]*mempoolTx),
+	}
+}
+
+// Size returns the number of txs in the mempool.
+func (mp *PriorityMempool) Size() int {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+	return len(mp.txs)
+}
+
+// Txs returns up to max txs of the mempool, highest priority first. A
+// negative max returns all of them.
+func (mp *PriorityMempool) Txs(max int) [][]byte {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	if max < 0 || max > len(mp.txs) {
+		max = len(mp.txs)
+	}
+	txs := make([][]byte, max)
+	for i := 0; i < max; i++ {
+		txs[i] = mp.txs[i].txBytes
+	}
+	return txs
+}
+
+// Insert adds a tx to the mempool. A tx with the same sender key as a pending
+// one replaces it only if it has a higher priority. When the mempool is full,
+// the lowest priority tx is evicted to make room for a higher priority one.
+// An empty sender key never replaces any tx.
+func (mp *PriorityMempool) Insert(txBytes []byte, priority int64, senderKey string) sdk.Error {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	hash := string(tmhash.Sum(txBytes))
+	if _, ok := mp.byHash[hash]; ok {
+		return nil
+	}
+
+	if senderKey != "" {
+		if pending, ok := mp.bySender[senderKey]; ok {
+			if priority <= pending.priority {
+				return sdk.ErrInsufficientFee(fmt.Sprintf(
+					"replacement tx must have a higher priority than the pending one, got: %d pending: %d",
+					priority, pending.priority))
+			}
+			mp.remove(pending)
+		}
+	}
+
+	if mp.maxTxs > 0 && len(mp.txs) >= mp.maxTxs {
+		lowest := mp.txs[len(mp.txs)-1]
+		if priority <= lowest.priority {
+			return sdk.ErrInsufficientFee(fmt.Sprintf(
+				"mempool is full, tx priority must be higher than %d, got: %d", lowest.priority, priority))
+		}
+		mp.remove(lowest)
+	}
+
+	tx := &mempoolTx{
+		txBytes:   txBytes,
+		hash:      hash,
+		senderKey: senderKey,
+		priority:  priority,
+	}
+	i := sort.Search(len(mp.txs), func(i int) bool { return mp.txs[i].priority < priority })
+	mp.txs = append(mp.txs, nil)
+	copy(mp.txs[i+1:], mp.txs[i:])
+	mp.txs[i] = tx
+
+	mp.byHash[hash] = tx
+	if senderKey != "" {
+		mp.bySender[senderKey] = tx
+	}
+	return nil
+}
+
+// Remove removes a tx from the mempool, if present.
+func (mp *PriorityMempool) Remove(txBytes []byte) {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	if tx, ok := mp.byHash[string(tmhash.Sum(txBytes))]; ok {
+		mp.remove(tx)
+	}
+}
+
+// Removes the pending tx with the given sender key, if any.
+func (mp *PriorityMempool) removeSender(senderKey string) {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	if tx, ok := mp.bySender[senderKey]; ok && senderKey != "" {
+		mp.remove(tx)
+	}
+}
+
+// Returns whether a tx with the given sender key is pending.
+func (mp *PriorityMempool) hasSender(senderKey string) bool {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	_, ok := mp.bySender[senderKey]
+	return senderKey != "" && ok
+}
+
+func (mp *PriorityMempool) remove(tx *mempoolTx) {
+	for i, pending := range mp.txs {
+		if pending == tx {
+			mp.txs = append(mp.txs[:i], mp.txs[i+1:]...)
+			break
+		}
+	}
+	delete(mp.byHash, tx.hash)
+	if tx.senderKey != "" && mp.bySender[tx.senderKey] == tx {
+		delete(mp.bySender, tx.senderKey)
+	}
+}
+
+// Returns the key identifying the fee payer and sequence of a tx, or an
+// empty key if the tx does not expose them.
+func mempoolSenderKey(tx sdk.Tx) string {
+	seqTx, ok := tx.(SequencedTx)
+	if !ok {
+		return ""
+	}
+	payer, sequence := seqTx.GetFeePayerSequence()
+	if payer.Empty() {
+		return ""
+	}
+	return fmt.Sprintf("%s/%d", payer, sequence)
+}
+
+// Adds a tx which went through CheckTx to the mempool, or removes it from the
+// mempool if it failed, as when a pending tx fails the recheck after a block.
+//
+// A tx reusing the sequence of a pending tx fails the sequence check against
+// the check state, which already includes the pending tx, so it is checked
+// again against the last committed state before replacing the pending tx.
+// Only the first pending sequence of a sender is valid against that state,
+// so the txs of its later pending sequences can't be replaced: they fail
+// with an invalid sequence.
+func (app *BaseApp) admitToMempool(txBytes []byte, tx sdk.Tx, result sdk.Result) sdk.Result {
+	senderKey := mempoolSenderKey(tx)
+	invalidSequence := sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidSequence)
+	if result.Code == invalidSequence && app.mempool.hasSender(senderKey) {
+		result = app.runTx(runTxModeReplace, txBytes, tx)
+	}
+	if !result.IsOK() {
+		app.mempool.Remove(txBytes)
+		return result
+	}
+
+	if err := app.mempool.Insert(txBytes, result.Priority, senderKey); err != nil {
+		res := err.Result()
+		res.GasWanted = result.GasWanted
+		res.GasUsed = result.GasUsed
+		return res
+	}
+	return result
+}
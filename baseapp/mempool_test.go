@@ -0,0 +1,166 @@
+package baseapp
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestPriorityMempoolOrdering(t *testing.T) {
+	mp := NewPriorityMempool(10)
+
+	require.Nil(t, mp.Insert([]byte("low"), 1, ""))
+	require.Nil(t, mp.Insert([]byte("high"), 10, ""))
+	require.Nil(t, mp.Insert([]byte("mid"), 5, ""))
+	require.Nil(t, mp.Insert([]byte("mid2"), 5, ""))
+
+	// equal priorities keep their arrival order
+	require.Equal(t, [][]byte{[]byte("high"), []byte("mid"), []byte("mid2"), []byte("low")}, mp.Txs(-1))
+	require.Equal(t, [][]byte{[]byte("high"), []byte("mid")}, mp.Txs(2))
+
+	// inserting the same tx twice is a no-op
+	require.Nil(t, mp.Insert([]byte("mid"), 5, ""))
+	require.Equal(t, 4, mp.Size())
+
+	mp.Remove([]byte("mid"))
+	require.Equal(t, [][]byte{[]byte("high"), []byte("mid2"), []byte("low")}, mp.Txs(-1))
+}
+
+func TestPriorityMempoolEviction(t *testing.T) {
+	mp := NewPriorityMempool(2)
+
+	require.Nil(t, mp.Insert([]byte("a"), 2, ""))
+	require.Nil(t, mp.Insert([]byte("b"), 3, ""))
+
+	// a tx paying no more than the lowest one is rejected
+	require.NotNil(t, mp.Insert([]byte("c"), 2, ""))
+	require.Equal(t, 2, mp.Size())
+
+	// a higher paying tx evicts the lowest one
+	require.Nil(t, mp.Insert([]byte("d"), 4, ""))
+	require.Equal(t, [][]byte{[]byte("d"), []byte("b")}, mp.Txs(-1))
+}
+
+func TestPriorityMempoolReplaceByFee(t *testing.T) {
+	mp := NewPriorityMempool(10)
+
+	require.Nil(t, mp.Insert([]byte("a"), 2, "addr/0"))
+	require.True(t, mp.hasSender("addr/0"))
+	require.False(t, mp.hasSender("addr/1"))
+
+	// the replacement must pay more than the pending tx
+	require.NotNil(t, mp.Insert([]byte("b"), 2, "addr/0"))
+	require.Equal(t, [][]byte{[]byte("a")}, mp.Txs(-1))
+
+	require.Nil(t, mp.Insert([]byte("c"), 3, "addr/0"))
+	require.Equal(t, [][]byte{[]byte("c")}, mp.Txs(-1))
+
+	// another sequence of the same sender does not replace it
+	require.Nil(t, mp.Insert([]byte("d"), 1, "addr/1"))
+	require.Equal(t, [][]byte{[]byte("c"), []byte("d")}, mp.Txs(-1))
+
+	mp.Remove([]byte("c"))
+	require.False(t, mp.hasSender("addr/0"))
+}
+
+// Tx of a single sender signed with a sequence, paying the given priority.
+type txSequenced struct {
+	Sequence int64
+	Priority int64
+}
+
+// Implements SequencedTx
+func (tx txSequenced) GetMsgs() []sdk.Msg { return []sdk.Msg{msgCounter{}} }
+func (tx txSequenced) GetFeePayerSequence() (sdk.AccAddress, int64) {
+	return sdk.AccAddress("sender"), tx.Sequence
+}
+
+func sequencedTxDecoder(cdc *codec.Codec) sdk.TxDecoder {
+	return func(txBytes []byte) (sdk.Tx, sdk.Error) {
+		var tx txSequenced
+		err := cdc.UnmarshalBinary(txBytes, &tx)
+		if err != nil {
+			return nil, sdk.ErrTxDecode("").TraceSDK(err.Error())
+		}
+		return tx, nil
+	}
+}
+
+// checks the sequence of the tx against the one of the sender, and
+// increments it
+func anteHandlerSequenced(capKey *sdk.KVStoreKey, sequenceKey []byte) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		store := ctx.KVStore(capKey)
+		seqTx := tx.(txSequenced)
+		if seq := getIntFromStore(store, sequenceKey); seq != seqTx.Sequence {
+			return ctx, sdk.ErrInvalidSequence(fmt.Sprintf("expected %d, got %d", seq, seqTx.Sequence)).Result(), true
+		}
+		setIntOnStore(store, sequenceKey, seqTx.Sequence+1)
+		return ctx, sdk.Result{Priority: seqTx.Priority}, false
+	}
+}
+
+func setupMempoolApp(t *testing.T) (*BaseApp, func(seq, priority int64) []byte) {
+	cdc := codec.New()
+	cdc.RegisterConcrete(txSequenced{}, "cosmos-sdk/baseapp/txSequenced", nil)
+
+	app := NewBaseApp(t.Name(), defaultLogger(), dbm.NewMemDB(), sequencedTxDecoder(cdc))
+	app.SetAnteHandler(anteHandlerSequenced(capKey1, []byte("sequence")))
+	app.Router().AddRoute(typeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} })
+	app.SetMempool(NewPriorityMempool(10))
+	app.MountStoresIAVL(capKey1)
+	require.Nil(t, app.LoadLatestVersion(capKey1))
+	app.InitChain(abci.RequestInitChain{})
+
+	newTx := func(seq, priority int64) []byte {
+		txBytes, err := cdc.MarshalBinary(txSequenced{seq, priority})
+		require.Nil(t, err)
+		return txBytes
+	}
+	return app, newTx
+}
+
+func TestMempoolRemovesFailedTxs(t *testing.T) {
+	app, newTx := setupMempoolApp(t)
+
+	require.True(t, app.CheckTx(newTx(0, 1)).IsOK())
+	require.True(t, app.CheckTx(newTx(1, 1)).IsOK())
+	require.Equal(t, 2, app.Mempool().Size())
+
+	// a pending tx failing the recheck is removed
+	require.Nil(t, app.Mempool().Insert(newTx(5, 1), 1, mempoolSenderKey(txSequenced{Sequence: 5})))
+	require.Equal(t, 3, app.Mempool().Size())
+	require.False(t, app.CheckTx(newTx(5, 1)).IsOK())
+	require.Equal(t, [][]byte{newTx(0, 1), newTx(1, 1)}, app.Mempool().Txs(-1))
+
+	// delivering a tx prunes the pending tx of the same sender and sequence
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	require.True(t, app.DeliverTx(newTx(0, 2)).IsOK())
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+	require.Equal(t, [][]byte{newTx(1, 1)}, app.Mempool().Txs(-1))
+	require.False(t, app.Mempool().hasSender(mempoolSenderKey(txSequenced{Sequence: 0})))
+}
+
+func TestMempoolReplacement(t *testing.T) {
+	app, newTx := setupMempoolApp(t)
+
+	// the first pending tx of the sender is replaced by a higher paying one
+	require.True(t, app.CheckTx(newTx(0, 1)).IsOK())
+	require.True(t, app.CheckTx(newTx(0, 2)).IsOK())
+	require.Equal(t, [][]byte{newTx(0, 2)}, app.Mempool().Txs(-1))
+
+	// the replacement of a later pending sequence is checked against the
+	// last committed state, where its sequence is invalid
+	require.True(t, app.CheckTx(newTx(1, 1)).IsOK())
+	res := app.CheckTx(newTx(1, 5))
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidSequence), sdk.ABCICodeType(res.Code))
+	require.Equal(t, [][]byte{newTx(0, 2), newTx(1, 1)}, app.Mempool().Txs(-1))
+}
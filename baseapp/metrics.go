@@ -0,0 +1,106 @@
+package baseapp
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsNamespace is the namespace of the application metrics.
+	MetricsNamespace = "cosmos"
+
+	// MetricsSubsystem is the subsystem of the BaseApp metrics.
+	MetricsSubsystem = "baseapp"
+)
+
+// Metrics contains the metrics of the BaseApp.
+type Metrics struct {
+	// Number of msgs run, by mode (check, simulate, deliver or replace) and
+	// route
+	Msgs metrics.Counter
+	// Duration of the routing and handling of a msg, in seconds, by mode and
+	// route
+	MsgDuration metrics.Histogram
+
+	// Duration of a tx, including its ante handler, in seconds, by mode
+	TxDuration metrics.Histogram
+	// Gas used by a delivered tx
+	TxGasUsed metrics.Histogram
+	// Gas used by the txs of the last block
+	BlockGasUsed metrics.Gauge
+}
+
+// PrometheusMetrics returns the BaseApp metrics registered to the Prometheus
+// registerer under the namespace.
+func PrometheusMetrics(namespace string, registerer stdprometheus.Registerer) *Metrics {
+	return &Metrics{
+		Msgs: prometheus.NewCounter(registerCounterVec(registerer, stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "msgs",
+			Help:      "Number of msgs run.",
+		}, []string{"mode", "route"})),
+		MsgDuration: prometheus.NewHistogram(registerHistogramVec(registerer, stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "msg_duration_seconds",
+			Help:      "Duration of the routing and handling of a msg.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.0001, 2, 14),
+		}, []string{"mode", "route"})),
+		TxDuration: prometheus.NewHistogram(registerHistogramVec(registerer, stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "tx_duration_seconds",
+			Help:      "Duration of a tx, including its ante handler.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.0001, 2, 14),
+		}, []string{"mode"})),
+		TxGasUsed: prometheus.NewHistogram(registerHistogramVec(registerer, stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "tx_gas_used",
+			Help:      "Gas used by a delivered tx.",
+			Buckets:   stdprometheus.ExponentialBuckets(1000, 2, 14),
+		}, []string{})),
+		BlockGasUsed: prometheus.NewGauge(registerGaugeVec(registerer, stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_gas_used",
+			Help:      "Gas used by the txs of the last block.",
+		}, []string{})),
+	}
+}
+
+// registerCounterVec returns a new counter vector registered to the registerer.
+func registerCounterVec(registerer stdprometheus.Registerer, opts stdprometheus.CounterOpts, labels []string) *stdprometheus.CounterVec {
+	vec := stdprometheus.NewCounterVec(opts, labels)
+	registerer.MustRegister(vec)
+	return vec
+}
+
+// registerHistogramVec returns a new histogram vector registered to the
+// registerer.
+func registerHistogramVec(registerer stdprometheus.Registerer, opts stdprometheus.HistogramOpts, labels []string) *stdprometheus.HistogramVec {
+	vec := stdprometheus.NewHistogramVec(opts, labels)
+	registerer.MustRegister(vec)
+	return vec
+}
+
+// registerGaugeVec returns a new gauge vector registered to the registerer.
+func registerGaugeVec(registerer stdprometheus.Registerer, opts stdprometheus.GaugeOpts, labels []string) *stdprometheus.GaugeVec {
+	vec := stdprometheus.NewGaugeVec(opts, labels)
+	registerer.MustRegister(vec)
+	return vec
+}
+
+// NopMetrics returns BaseApp metrics which record nothing.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Msgs:         discard.NewCounter(),
+		MsgDuration:  discard.NewHistogram(),
+		TxDuration:   discard.NewHistogram(),
+		TxGasUsed:    discard.NewHistogram(),
+		BlockGasUsed: discard.NewGauge(),
+	}
+}
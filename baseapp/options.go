@@ -3,6 +3,7 @@ package baseapp
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -13,30 +14,112 @@ import (
 // for options that need access to non-exported fields of the BaseApp
 
 // SetPruning sets a pruning option on the multistore associated with the app
-func SetPruning(pruning string) func(*BaseApp) {
-	var pruningEnum sdk.PruningStrategy
-	switch pruning {
-	case "nothing":
-		pruningEnum = sdk.PruneNothing
-	case "everything":
-		pruningEnum = sdk.PruneEverything
-	case "syncable":
-		pruningEnum = sdk.PruneSyncable
-	default:
-		panic(fmt.Sprintf("invalid pruning strategy: %s", pruning))
+func SetPruning(pruning sdk.PruningStrategy) func(*BaseApp) {
+	if err := pruning.Validate(); err != nil {
+		panic(err)
 	}
 	return func(bap *BaseApp) {
-		bap.cms.SetPruning(pruningEnum)
+		bap.cms.SetPruning(pruning)
 	}
 }
 
-// SetMinimumFees returns an option that sets the minimum fees on the app.
-func SetMinimumFees(minFees string) func(*BaseApp) {
-	fees, err := sdk.ParseCoins(minFees)
+// SetMinGasPrices returns an option that sets the minimum gas prices on the app.
+func SetMinGasPrices(gasPricesStr string) func(*BaseApp) {
+	gasPrices, err := sdk.ParseDecCoins(gasPricesStr)
 	if err != nil {
-		panic(fmt.Sprintf("invalid minimum fees: %v", err))
+		panic(fmt.Sprintf("invalid minimum gas prices: %v", err))
+	}
+	return func(bap *BaseApp) { bap.SetMinGasPrices(gasPrices) }
+}
+
+// SetHaltHeight returns an option that halts the node once the block at the
+// given height is committed. 0 disables it.
+func SetHaltHeight(height int64) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.SetHaltHeight(height) }
+}
+
+// SetHaltTime returns an option that halts the node once a block whose time
+// is at or after haltTime, in unix seconds, is committed. 0 disables it.
+func SetHaltTime(haltTime int64) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.SetHaltTime(haltTime) }
+}
+
+// SetTelemetry returns an option that records the metrics of the app in a
+// Prometheus registry of the app if enabled.
+func SetTelemetry(enabled bool) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		if enabled {
+			bap.EnableTelemetry()
+		}
+	}
+}
+
+// SetFeeWeights returns an option that sets the weights of the fee
+// denominations on the app.
+func SetFeeWeights(feeWeights string) func(*BaseApp) {
+	weights, err := sdk.ParseFeeWeights(feeWeights)
+	if err != nil {
+		panic(fmt.Sprintf("invalid fee weights: %v", err))
+	}
+	return func(bap *BaseApp) { bap.SetFeeWeights(weights) }
+}
+
+// SetPriorityMempool returns an option that admits the checked txs to a
+// priority mempool holding at most maxTxs txs. A non-positive maxTxs
+// disables it.
+func SetPriorityMempool(maxTxs int) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		if maxTxs > 0 {
+			bap.SetMempool(NewPriorityMempool(maxTxs))
+		}
+	}
+}
+
+// SetSnapshots returns an option that snapshots the state to dir every
+// interval heights, keeping the keepRecent most recent snapshots. A
+// non-positive interval disables the snapshots.
+func SetSnapshots(dir string, interval int64, keepRecent int) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		if interval > 0 {
+			bap.SetSnapshotStore(store.NewSnapshotStore(dir), interval, keepRecent)
+		}
+	}
+}
+
+// SetStoreUpgrades returns an option that applies the store upgrades when the
+// state is loaded.
+func SetStoreUpgrades(upgrades sdk.StoreUpgrades) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.SetStoreUpgrades(&upgrades) }
+}
+
+// SetInterBlockCache returns an option that keeps up to size of the values
+// read from the IAVL stores of the given names cached across blocks. A
+// non-positive size disables the caches.
+func SetInterBlockCache(size int, storeNames []string) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.SetInterBlockCache(size, storeNames...) }
+}
+
+// SetStreamingListener returns an option that notifies the listener of the
+// changes committed to the stores of the given names at every block.
+func SetStreamingListener(listener store.WriteListener, storeNames ...string) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.SetStreamingListener(listener, storeNames...) }
+}
+
+// SetStreamingFile returns an option that appends the changes committed to
+// the stores of the given names at every block to the file at path, as
+// length-prefixed amino-encoded store.ChangeSets. An empty path or no store
+// names disables the streaming.
+func SetStreamingFile(path string, storeNames []string) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		if path == "" || len(storeNames) == 0 {
+			return
+		}
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			panic(err)
+		}
+		bap.SetStreamingListener(store.NewFileListener(file), storeNames...)
 	}
-	return func(bap *BaseApp) { bap.SetMinimumFees(fees) }
 }
 
 func (app *BaseApp) SetName(name string) {
@@ -20,6 +20,8 @@ type cliConfig struct {
 	Output    string `toml:"output"`
 	Node      string `toml:"node"`
 	Trace     bool   `toml:"trace"`
+
+	SigningDevice string `toml:"signing_device"`
 }
 
 // ConfigCmd returns a CLI command to interactively create a
@@ -70,6 +72,8 @@ func runConfigCmd(cmd *cobra.Command, args []string) error {
 		Output:    output,
 		Node:      node,
 		Trace:     false,
+
+		SigningDevice: SigningDeviceLedger,
 	}
 
 	return createGaiaCLIConfig(cfg)
@@ -6,6 +6,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // nolint
@@ -15,7 +17,9 @@ const (
 	// occur between the tx simulation and the actual run.
 	DefaultGasAdjustment = 1.0
 	DefaultGasLimit      = 200000
-	GasFlagSimulate      = "simulate"
+	GasFlagAuto          = "auto"
+	// DEPRECATED: alias of GasFlagAuto
+	GasFlagSimulate = "simulate"
 
 	FlagUseLedger      = "ledger"
 	FlagChainID        = "chain-id"
@@ -30,12 +34,15 @@ const (
 	FlagSequence       = "sequence"
 	FlagMemo           = "memo"
 	FlagFee            = "fee"
+	FlagGasPrices      = "gas-prices"
 	FlagAsync          = "async"
 	FlagJson           = "json"
 	FlagPrintResponse  = "print-response"
 	FlagDryRun         = "dry-run"
 	FlagGenerateOnly   = "generate-only"
 	FlagIndentResponse = "indent"
+	FlagPage           = "page"
+	FlagLimit          = "limit"
 )
 
 // LineBreak can be included in a command list to provide a blank line
@@ -62,6 +69,20 @@ func GetCommands(cmds ...*cobra.Command) []*cobra.Command {
 	return cmds
 }
 
+// PageCommands adds the pagination flags to query commands returning lists
+func PageCommands(cmds ...*cobra.Command) []*cobra.Command {
+	for _, c := range cmds {
+		c.Flags().Int(FlagPage, 1, "page of the results to query, counting from 1")
+		c.Flags().Int(FlagLimit, sdk.DefaultPageLimit, "number of results per page")
+	}
+	return cmds
+}
+
+// ReadPageRequest returns the page request of the pagination flags.
+func ReadPageRequest() sdk.PageRequest {
+	return sdk.NewPageRequest(viper.GetInt(FlagPage), viper.GetInt(FlagLimit))
+}
+
 // PostCommands adds common flags for commands to post tx
 func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 	for _, c := range cmds {
@@ -71,19 +92,20 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().Int64(FlagSequence, 0, "Sequence number to sign the tx")
 		c.Flags().String(FlagMemo, "", "Memo to send along with transaction")
 		c.Flags().String(FlagFee, "", "Fee to pay along with transaction")
+		c.Flags().String(FlagGasPrices, "", "Gas prices to determine the transaction fee (e.g. 0.00001steak)")
 		c.Flags().String(FlagChainID, "", "Chain ID of tendermint node")
 		c.Flags().String(FlagNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for this chain")
 		c.Flags().Bool(FlagUseLedger, false, "Use a connected Ledger device")
-		c.Flags().Float64(FlagGasAdjustment, DefaultGasAdjustment, "adjustment factor to be multiplied against the estimate returned by the tx simulation; if the gas limit is set manually this flag is ignored ")
+		c.Flags().Float64(FlagGasAdjustment, DefaultGasAdjustment, fmt.Sprintf("adjustment factor to be multiplied against the estimate returned by the tx simulation when --gas=%s; if the gas limit is set manually this flag is ignored", GasFlagAuto))
 		c.Flags().Bool(FlagAsync, false, "broadcast transactions asynchronously")
 		c.Flags().Bool(FlagJson, false, "return output in json format")
 		c.Flags().Bool(FlagPrintResponse, true, "return tx response (only works with async = false)")
 		c.Flags().Bool(FlagTrustNode, true, "Trust connected full node (don't verify proofs for responses)")
 		c.Flags().Bool(FlagDryRun, false, "ignore the --gas flag and perform a simulation of a transaction, but don't broadcast it")
 		c.Flags().Bool(FlagGenerateOnly, false, "build an unsigned transaction and write it to STDOUT")
-		// --gas can accept integers and "simulate"
-		c.Flags().Var(&GasFlagVar, "gas", fmt.Sprintf(
-			"gas limit to set per-transaction; set to %q to calculate required gas automatically (default %d)", GasFlagSimulate, DefaultGasLimit))
+		// --gas can accept integers and "auto"
+		c.Flags().Var(&GasFlagVar, FlagGas, fmt.Sprintf(
+			"gas limit to set per-transaction; set to %q to calculate required gas automatically (default %d)", GasFlagAuto, DefaultGasLimit))
 		viper.BindPFlag(FlagTrustNode, c.Flags().Lookup(FlagTrustNode))
 		viper.BindPFlag(FlagUseLedger, c.Flags().Lookup(FlagUseLedger))
 		viper.BindPFlag(FlagChainID, c.Flags().Lookup(FlagChainID))
@@ -111,7 +133,7 @@ func (v *GasSetting) Set(s string) (err error) {
 
 func (v *GasSetting) String() string {
 	if v.Simulate {
-		return GasFlagSimulate
+		return GasFlagAuto
 	}
 	return strconv.FormatInt(v.Gas, 10)
 }
@@ -121,12 +143,12 @@ func ReadGasFlag(s string) (simulate bool, gas int64, err error) {
 	switch s {
 	case "":
 		gas = DefaultGasLimit
-	case GasFlagSimulate:
+	case GasFlagAuto, GasFlagSimulate:
 		simulate = true
 	default:
 		gas, err = strconv.ParseInt(s, 10, 64)
 		if err != nil {
-			err = fmt.Errorf("gas must be either integer or %q", GasFlagSimulate)
+			err = fmt.Errorf("gas must be either integer or %q", GasFlagAuto)
 			return
 		}
 	}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadGasFlag(t *testing.T) {
+	tests := []struct {
+		input        string
+		wantSimulate bool
+		wantGas      int64
+		wantErr      bool
+	}{
+		{"", false, DefaultGasLimit, false},
+		{"auto", true, 0, false},
+		{"simulate", true, 0, false},
+		{"100000", false, 100000, false},
+		{"1.5", false, 0, true},
+		{"all", false, 0, true},
+	}
+	for i, tc := range tests {
+		simulate, gas, err := ReadGasFlag(tc.input)
+		require.Equal(t, tc.wantErr, err != nil, "tc #%d", i)
+		if tc.wantErr {
+			continue
+		}
+		require.Equal(t, tc.wantSimulate, simulate, "tc #%d", i)
+		require.Equal(t, tc.wantGas, gas, "tc #%d", i)
+	}
+}
@@ -1,15 +1,34 @@
 package client
 
 import (
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/crypto"
 	"github.com/cosmos/cosmos-sdk/crypto/keys"
 	dbm "github.com/tendermint/tendermint/libs/db"
 )
 
+// nolint
+const (
+	// ConfigSigningDevice selects the backend of the device holding Ledger
+	// keys, either SigningDeviceLedger or SigningDeviceEmulator.
+	ConfigSigningDevice = "signing_device"
+	// ConfigLedgerEmulatorMnemonic is the mnemonic the emulator derives its
+	// keys from. It is meant for tests only.
+	ConfigLedgerEmulatorMnemonic = "ledger_emulator_mnemonic"
+
+	SigningDeviceLedger   = "ledger"
+	SigningDeviceEmulator = "emulator"
+)
+
 // GetKeyBase initializes a keybase based on the given db.
 // The KeyBase manages all activity requiring access to a key.
 func GetKeyBase(db dbm.DB) keys.Keybase {
-	keybase := keys.New(
+	keybase := keys.NewWithSigningDevice(
 		db,
+		discoverSigningDevice(viper.GetString(ConfigSigningDevice)),
 	)
 	return keybase
 }
@@ -20,3 +39,18 @@ func GetKeyBase(db dbm.DB) keys.Keybase {
 func MockKeyBase() keys.Keybase {
 	return GetKeyBase(dbm.NewMemDB())
 }
+
+// Returns the discovery function of the configured signing device backend.
+func discoverSigningDevice(backend string) crypto.DiscoverSigningDeviceFn {
+	switch backend {
+	case "", SigningDeviceLedger:
+		return crypto.DiscoverLedger
+	case SigningDeviceEmulator:
+		return crypto.DiscoverLedgerEmulator(viper.GetString(ConfigLedgerEmulatorMnemonic))
+	default:
+		return func() (crypto.SigningDevice, error) {
+			return nil, fmt.Errorf("unknown signing device %q, expected %q or %q",
+				backend, SigningDeviceLedger, SigningDeviceEmulator)
+		}
+	}
+}
@@ -1,10 +1,12 @@
 package keys
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sort"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/gorilla/mux"
@@ -14,6 +16,8 @@ import (
 	ccrypto "github.com/cosmos/cosmos-sdk/crypto"
 	"github.com/cosmos/cosmos-sdk/crypto/keys"
 
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
 	"github.com/tendermint/tendermint/libs/cli"
 )
 
@@ -24,6 +28,10 @@ const (
 	flagDryRun   = "dry-run"
 	flagAccount  = "account"
 	flagIndex    = "index"
+	flagMultisig = "multisig"
+	flagNoSort   = "nosort"
+
+	flagMultisigThreshold = "multisig-threshold"
 )
 
 func addKeyCommand() *cobra.Command {
@@ -32,7 +40,15 @@ func addKeyCommand() *cobra.Command {
 		Short: "Create a new key, or import from seed",
 		Long: `Add a public/private key pair to the key store.
 If you select --seed/-s you can recover a key from the seed
-phrase, otherwise, a new key will be generated.`,
+phrase, otherwise, a new key will be generated.
+
+Use the --multisig flag to store a threshold multisig public key built
+from keys that are already in the key store, e.g.:
+
+    gaiacli keys add treasury --multisig=alice,bob,carol --multisig-threshold=2
+
+By default the public keys are sorted by address, pass --nosort to
+preserve the order in which they were given on the command line.`,
 		RunE: runAddCmd,
 	}
 	cmd.Flags().StringP(flagType, "t", "secp256k1", "Type of private key (secp256k1|ed25519)")
@@ -42,6 +58,9 @@ phrase, otherwise, a new key will be generated.`,
 	cmd.Flags().Bool(flagDryRun, false, "Perform action, but don't add key to local keystore")
 	cmd.Flags().Uint32(flagAccount, 0, "Account number for HD derivation")
 	cmd.Flags().Uint32(flagIndex, 0, "Index number for HD derivation")
+	cmd.Flags().StringSlice(flagMultisig, nil, "Construct and store a multisig public key (implies --pubkey)")
+	cmd.Flags().Uint(flagMultisigThreshold, 1, "K out of N required signatures. For use in conjunction with --multisig")
+	cmd.Flags().Bool(flagNoSort, false, "Keys passed to --multisig are taken in the order they're supplied")
 	return cmd
 }
 
@@ -77,6 +96,11 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		multisigKeys := viper.GetStringSlice(flagMultisig)
+		if len(multisigKeys) != 0 {
+			return addMultisigKey(kb, name, multisigKeys)
+		}
+
 		// ask for a password when generating a local key
 		if !viper.GetBool(client.FlagUseLedger) {
 			pass, err = client.GetCheckPassword(
@@ -122,6 +146,51 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// addMultisigKey stores an offline reference to a threshold multisig public
+// key composed of the public keys of the given locally known keys.
+func addMultisigKey(kb keys.Keybase, name string, keyNames []string) error {
+	threshold := viper.GetInt(flagMultisigThreshold)
+	if err := validateMultisigThreshold(threshold, len(keyNames)); err != nil {
+		return err
+	}
+
+	pks := make([]crypto.PubKey, len(keyNames))
+	for i, keyName := range keyNames {
+		info, err := kb.Get(keyName)
+		if err != nil {
+			return err
+		}
+		pks[i] = info.GetPubKey()
+	}
+
+	if !viper.GetBool(flagNoSort) {
+		sort.Slice(pks, func(i, j int) bool {
+			return bytes.Compare(pks[i].Address(), pks[j].Address()) < 0
+		})
+	}
+
+	pk := multisig.NewPubKeyMultisigThreshold(threshold, pks)
+	info, err := kb.CreateOffline(name, pk)
+	if err != nil {
+		return err
+	}
+
+	viper.Set(flagNoBackup, true)
+	printCreate(info, "")
+	return nil
+}
+
+func validateMultisigThreshold(k, nKeys int) error {
+	if k <= 0 {
+		return fmt.Errorf("threshold must be a positive integer")
+	}
+	if nKeys < k {
+		return fmt.Errorf(
+			"threshold k of n multisignature: %d < %d", nKeys, k)
+	}
+	return nil
+}
+
 func printCreate(info keys.Info, seed string) {
 	output := viper.Get(cli.OutputFlag)
 	switch output {
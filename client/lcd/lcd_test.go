@@ -289,7 +289,7 @@ func TestCoinSend(t *testing.T) {
 	require.Equal(t, http.StatusInternalServerError, res.StatusCode, body)
 
 	// test failure with wrong adjustment
-	res, body, _ = doSendWithGas(t, port, seed, name, password, addr, "simulate", 0.1, "")
+	res, body, _ = doSendWithGas(t, port, seed, name, password, addr, "auto", 0.1, "")
 	require.Equal(t, http.StatusInternalServerError, res.StatusCode, body)
 
 	// run simulation and test success with estimated gas
@@ -340,7 +340,7 @@ func TestCoinSendGenerateSignAndBroadcast(t *testing.T) {
 	acc := getAccount(t, port, addr)
 
 	// generate TX
-	res, body, _ := doSendWithGas(t, port, seed, name, password, addr, "simulate", 0, "?generate_only=true")
+	res, body, _ := doSendWithGas(t, port, seed, name, password, addr, "auto", 0, "?generate_only=true")
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
 	var msg auth.StdTx
 	require.Nil(t, cdc.UnmarshalJSON([]byte(body), &msg))
@@ -350,6 +350,23 @@ func TestCoinSendGenerateSignAndBroadcast(t *testing.T) {
 	require.Equal(t, 0, len(msg.Signatures))
 	gasEstimate := msg.Fee.Gas
 
+	// estimate the gas of the unsigned tx and the resulting fee
+	estimatePayload := authrest.EstimateGasBody{
+		Tx:            msg,
+		GasAdjustment: "1.5",
+		GasPrices:     "0.0001steak",
+	}
+	json, err := cdc.MarshalJSON(estimatePayload)
+	require.Nil(t, err)
+	res, body = Request(t, port, "POST", "/tx/estimate_gas", json)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var estimate authrest.EstimateGasResponse
+	require.Nil(t, cdc.UnmarshalJSON([]byte(body), &estimate))
+	require.Equal(t, gasEstimate, estimate.GasEstimate)
+	require.Equal(t, int64(1.5*float64(gasEstimate)), estimate.GasAdjusted)
+	require.Equal(t, estimate.GasAdjusted, estimate.Fee.Gas)
+	require.Equal(t, "steak", estimate.Fee.Amount[0].Denom)
+
 	// sign tx
 	var signedMsg auth.StdTx
 	accnum := acc.GetAccountNumber()
@@ -363,7 +380,7 @@ func TestCoinSendGenerateSignAndBroadcast(t *testing.T) {
 		AccountNumber:    accnum,
 		Sequence:         sequence,
 	}
-	json, err := cdc.MarshalJSON(payload)
+	json, err = cdc.MarshalJSON(payload)
 	require.Nil(t, err)
 	res, body = Request(t, port, "POST", "/tx/sign", json)
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
@@ -403,7 +420,7 @@ func TestTxs(t *testing.T) {
 	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
 
 	// query empty
-	res, body = Request(t, port, "GET", fmt.Sprintf("/txs?tag=sender_bech32='%s'", "cosmos1jawd35d9aq4u76sr3fjalmcqc8hqygs90d0g0v"), nil)
+	res, body = Request(t, port, "GET", fmt.Sprintf("/txs?tag=transfer.sender_bech32='%s'", "cosmos1jawd35d9aq4u76sr3fjalmcqc8hqygs90d0g0v"), nil)
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
 	require.Equal(t, "[]", body)
 
@@ -433,7 +450,7 @@ func TestTxs(t *testing.T) {
 
 	// query sender
 	// also tests url decoding
-	res, body = Request(t, port, "GET", fmt.Sprintf("/txs?tag=sender_bech32=%%27%s%%27", addr), nil)
+	res, body = Request(t, port, "GET", fmt.Sprintf("/txs?tag=transfer.sender_bech32=%%27%s%%27", addr), nil)
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
 
 	err = cdc.UnmarshalJSON([]byte(body), &indexedTxs)
@@ -442,7 +459,7 @@ func TestTxs(t *testing.T) {
 	require.Equal(t, resultTx.Height, indexedTxs[0].Height)
 
 	// query recipient
-	res, body = Request(t, port, "GET", fmt.Sprintf("/txs?tag=recipient_bech32='%s'", receiveAddr), nil)
+	res, body = Request(t, port, "GET", fmt.Sprintf("/txs?tag=transfer.recipient_bech32='%s'", receiveAddr), nil)
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
 
 	err = cdc.UnmarshalJSON([]byte(body), &indexedTxs)
@@ -41,6 +41,11 @@ will match any transaction tagged with both test1,test2. To match a transaction
 test1 or test2, use:
 
 $ gaiacli tendermint txs --tag test1,test2 --any
+
+The events emitted by the msgs of a transaction are indexed as tags keyed by the event type and
+the attribute key joined by a dot, e.g. to search for the transfers to an address:
+
+$ gaiacli tendermint txs --tag "transfer.recipient='cosmos1...'"
 `),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			tags := viper.GetStringSlice(flagTags)
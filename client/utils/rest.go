@@ -19,6 +19,8 @@ import (
 const (
 	queryArgDryRun       = "simulate"
 	queryArgGenerateOnly = "generate_only"
+	queryArgPage         = "page"
+	queryArgLimit        = "limit"
 )
 
 //----------------------------------------
@@ -64,6 +66,31 @@ func ParseInt64OrReturnBadRequest(w http.ResponseWriter, s string) (n int64, ok
 	return n, true
 }
 
+// ParsePageRequestOrReturnBadRequest reads the page request of the "page"
+// and "limit" arguments of a URL's query, which default to the first page
+// of sdk.DefaultPageLimit results.
+func ParsePageRequestOrReturnBadRequest(w http.ResponseWriter, r *http.Request) (page sdk.PageRequest, ok bool) {
+	page = sdk.NewPageRequest(1, sdk.DefaultPageLimit)
+	for arg, n := range map[string]*int{queryArgPage: &page.Page, queryArgLimit: &page.Limit} {
+		s := r.URL.Query().Get(arg)
+		if len(s) == 0 {
+			continue
+		}
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("'%s' is not a valid %s", s, arg))
+			return page, false
+		}
+		*n = i
+	}
+
+	if err := page.ValidateBasic(); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return page, false
+	}
+	return page, true
+}
+
 // ParseFloat64OrReturnBadRequest converts s to a float64 value. It returns a
 // default value, defaultIfEmpty, if the string is empty.
 func ParseFloat64OrReturnBadRequest(w http.ResponseWriter, s string, defaultIfEmpty float64) (n float64, ok bool) {
@@ -113,6 +140,7 @@ type BaseReq struct {
 	Sequence      int64  `json:"sequence"`
 	Gas           string `json:"gas"`
 	GasAdjustment string `json:"gas_adjustment"`
+	GasPrices     string `json:"gas_prices"`
 }
 
 // Sanitize performs basic sanitization on a BaseReq object.
@@ -123,6 +151,7 @@ func (br BaseReq) Sanitize() BaseReq {
 		ChainID:       strings.TrimSpace(br.ChainID),
 		Gas:           strings.TrimSpace(br.Gas),
 		GasAdjustment: strings.TrimSpace(br.GasAdjustment),
+		GasPrices:     strings.TrimSpace(br.GasPrices),
 		AccountNumber: br.AccountNumber,
 		Sequence:      br.Sequence,
 	}
@@ -203,6 +232,7 @@ func CompleteAndBroadcastTxREST(w http.ResponseWriter, r *http.Request, cliCtx c
 		Gas:           gas,
 		GasAdjustment: adjustment,
 		SimulateGas:   simulateGas,
+		GasPrices:     baseReq.GasPrices,
 		ChainID:       baseReq.ChainID,
 		AccountNumber: baseReq.AccountNumber,
 		Sequence:      baseReq.Sequence,
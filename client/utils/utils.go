@@ -86,6 +86,34 @@ func CalculateGas(queryFunc func(string, common.HexBytes) ([]byte, error), cdc *
 	return
 }
 
+// EstimateStdTxGas simulates the execution of an unsigned StdTx on behalf of
+// its signers, whose account numbers and sequences are queried from the node,
+// and returns both the estimate and the adjusted amount. No keys are needed as
+// signatures are not verified by simulations.
+func EstimateStdTxGas(cliCtx context.CLIContext, stdTx auth.StdTx, adjustment float64) (estimate, adjusted int64, err error) {
+	signers := stdTx.GetSigners()
+	sigs := make([]auth.StdSignature, len(signers))
+	for i, signer := range signers {
+		account, err := cliCtx.GetAccount(signer)
+		if err != nil {
+			return 0, 0, err
+		}
+		if account == nil {
+			return 0, 0, fmt.Errorf("no account with address %s was found in the state", signer)
+		}
+		sigs[i] = auth.StdSignature{
+			AccountNumber: account.GetAccountNumber(),
+			Sequence:      account.GetSequence(),
+		}
+	}
+
+	txBytes, err := cliCtx.Codec.MarshalBinary(auth.NewStdTx(stdTx.GetMsgs(), stdTx.Fee, sigs, stdTx.GetMemo()))
+	if err != nil {
+		return
+	}
+	return CalculateGas(cliCtx.Query, cliCtx.Codec, txBytes, adjustment)
+}
+
 // PrintUnsignedStdTx builds an unsigned StdTx and prints it to os.Stdout.
 // Don't perform online validation or lookups if offline is true.
 func PrintUnsignedStdTx(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, msgs []sdk.Msg, offline bool) (err error) {
@@ -149,6 +177,42 @@ func SignStdTx(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, name string,
 	return txBldr.SignStdTx(name, passphrase, stdTx, appendSig)
 }
 
+// SignStdTxWithSignerAddress attaches a signature to a StdTx and returns a copy of a it.
+// Don't perform online validation or lookups if offline is true, else
+// populate account and sequence numbers from a foreign account.
+func SignStdTxWithSignerAddress(txBldr authtxb.TxBuilder, cliCtx context.CLIContext,
+	addr sdk.AccAddress, name string, stdTx auth.StdTx,
+	offline bool) (signedStdTx auth.StdTx, err error) {
+
+	// check whether the address is a signer
+	if !isTxSigner(addr, stdTx.GetSigners()) {
+		fmt.Fprintf(os.Stderr, "WARNING: The generated transaction's intended signer does not match the given signer: '%v'\n", name)
+	}
+
+	if !offline && txBldr.AccountNumber == 0 {
+		accNum, err := cliCtx.GetAccountNumber(addr)
+		if err != nil {
+			return signedStdTx, err
+		}
+		txBldr = txBldr.WithAccountNumber(accNum)
+	}
+
+	if !offline && txBldr.Sequence == 0 {
+		accSeq, err := cliCtx.GetAccountSequence(addr)
+		if err != nil {
+			return signedStdTx, err
+		}
+		txBldr = txBldr.WithSequence(accSeq)
+	}
+
+	passphrase, err := keys.GetPassphrase(name)
+	if err != nil {
+		return signedStdTx, err
+	}
+
+	return txBldr.SignStdTx(name, passphrase, stdTx, false)
+}
+
 // nolint
 // SimulateMsgs simulates the transaction and returns the gas estimate and the adjusted value.
 func simulateMsgs(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, name string, msgs []sdk.Msg) (estimated, adjusted int64, err error) {
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+)
+
+// the IAVL stores of the app
+var benchmarkStores = []string{
+	"main", "acc", "stake", "mint", "distr", "slashing", "gov", "upgrade", "fee", "params",
+}
+
+// benchmarkBankSendTxPerBlock processes blocks of one bank send tx each with a
+// gaia app persisting its state to disk, as a node does.
+func benchmarkBankSendTxPerBlock(b *testing.B, options ...func(*bam.BaseApp)) {
+	dir, err := ioutil.TempDir("", "gaia-bench")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := dbm.NewGoLevelDB("application", dir)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+	gapp := NewGaiaApp(log.NewNopLogger(), db, nil, options...)
+
+	priv1 := secp256k1.GenPrivKey()
+	addr1 := sdk.AccAddress(priv1.PubKey().Address())
+	addr2 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 1)}
+	sendMsg := bank.MsgSend{
+		Inputs:  []bank.Input{bank.NewInput(addr1, coins)},
+		Outputs: []bank.Output{bank.NewOutput(addr2, coins)},
+	}
+
+	// Add an account at genesis
+	acc := &auth.BaseAccount{
+		Address: addr1,
+		// Some value conceivably higher than the benchmarks would ever go
+		Coins: sdk.Coins{sdk.NewInt64Coin("foocoin", 100000000000)},
+	}
+	if err := setGenesis(gapp, acc); err != nil {
+		b.Fatal(err)
+	}
+	// Precompute all txs
+	txs := mock.GenSequenceOfTxs([]sdk.Msg{sendMsg}, []int64{0}, []int64{0}, b.N, priv1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		gapp.BeginBlock(abci.RequestBeginBlock{})
+		if res := gapp.Check(txs[i]); !res.IsOK() {
+			b.Fatalf("failed to check tx: %s", res.Log)
+		}
+		if res := gapp.Deliver(txs[i]); !res.IsOK() {
+			b.Fatalf("failed to deliver tx: %s", res.Log)
+		}
+		gapp.EndBlock(abci.RequestEndBlock{})
+		gapp.Commit()
+	}
+}
+
+func BenchmarkOneBankSendTxPerBlock(b *testing.B) {
+	benchmarkBankSendTxPerBlock(b)
+}
+
+func BenchmarkOneBankSendTxPerBlockInterBlockCache(b *testing.B) {
+	benchmarkBankSendTxPerBlock(b, bam.SetInterBlockCache(store.DefaultInterBlockCacheSize, benchmarkStores))
+}
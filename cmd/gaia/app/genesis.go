@@ -19,6 +19,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 	tmtypes "github.com/tendermint/tendermint/types"
 )
 
@@ -31,24 +32,29 @@ var (
 // State to Unmarshal
 type GenesisState struct {
 	Accounts     []GenesisAccount      `json:"accounts"`
+	AuthData     auth.GenesisState     `json:"auth"`
 	StakeData    stake.GenesisState    `json:"stake"`
 	MintData     mint.GenesisState     `json:"mint"`
 	DistrData    distr.GenesisState    `json:"distr"`
 	GovData      gov.GenesisState      `json:"gov"`
 	SlashingData slashing.GenesisState `json:"slashing"`
+	UpgradeData  upgrade.GenesisState  `json:"upgrade"`
 	GenTxs       []json.RawMessage     `json:"gentxs"`
 }
 
-func NewGenesisState(accounts []GenesisAccount, stakeData stake.GenesisState, mintData mint.GenesisState,
-	distrData distr.GenesisState, govData gov.GenesisState, slashingData slashing.GenesisState) GenesisState {
+func NewGenesisState(accounts []GenesisAccount, authData auth.GenesisState, stakeData stake.GenesisState, mintData mint.GenesisState,
+	distrData distr.GenesisState, govData gov.GenesisState, slashingData slashing.GenesisState,
+	upgradeData upgrade.GenesisState) GenesisState {
 
 	return GenesisState{
 		Accounts:     accounts,
+		AuthData:     authData,
 		StakeData:    stakeData,
 		MintData:     mintData,
 		DistrData:    distrData,
 		GovData:      govData,
 		SlashingData: slashingData,
+		UpgradeData:  upgradeData,
 	}
 }
 
@@ -56,6 +62,13 @@ func NewGenesisState(accounts []GenesisAccount, stakeData stake.GenesisState, mi
 type GenesisAccount struct {
 	Address sdk.AccAddress `json:"address"`
 	Coins   sdk.Coins      `json:"coins"`
+
+	// vesting account fields
+	OriginalVesting  sdk.Coins `json:"original_vesting"`  // total vesting coins upon initialization
+	DelegatedFree    sdk.Coins `json:"delegated_free"`    // delegated vested coins at time of delegation
+	DelegatedVesting sdk.Coins `json:"delegated_vesting"` // delegated vesting coins at time of delegation
+	StartTime        int64     `json:"start_time"`        // vesting start time (UNIX Epoch time)
+	EndTime          int64     `json:"end_time"`          // vesting end time (UNIX Epoch time)
 }
 
 func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
@@ -66,18 +79,73 @@ func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
 }
 
 func NewGenesisAccountI(acc auth.Account) GenesisAccount {
-	return GenesisAccount{
+	gacc := GenesisAccount{
 		Address: acc.GetAddress(),
 		Coins:   acc.GetCoins(),
 	}
+
+	vacc, ok := acc.(auth.VestingAccount)
+	if ok {
+		gacc.OriginalVesting = vacc.GetOriginalVesting()
+		gacc.DelegatedFree = vacc.GetDelegatedFree()
+		gacc.DelegatedVesting = vacc.GetDelegatedVesting()
+		gacc.StartTime = vacc.GetStartTime()
+		gacc.EndTime = vacc.GetEndTime()
+	}
+
+	return gacc
 }
 
-// convert GenesisAccount to auth.BaseAccount
-func (ga *GenesisAccount) ToAccount() (acc *auth.BaseAccount) {
-	return &auth.BaseAccount{
+// convert GenesisAccount to auth.Account, which is a vesting account if the
+// genesis account declares a vesting schedule
+func (ga *GenesisAccount) ToAccount() auth.Account {
+	bacc := &auth.BaseAccount{
 		Address: ga.Address,
 		Coins:   ga.Coins.Sort(),
 	}
+
+	if !ga.OriginalVesting.IsZero() {
+		baseVestingAcc := auth.BaseVestingAccount{
+			BaseAccount:      *bacc,
+			OriginalVesting:  ga.OriginalVesting,
+			DelegatedFree:    ga.DelegatedFree,
+			DelegatedVesting: ga.DelegatedVesting,
+			EndTime:          ga.EndTime,
+		}
+
+		if ga.StartTime != 0 {
+			return &auth.ContinuousVestingAccount{
+				BaseVestingAccount: baseVestingAcc,
+				StartTime:          ga.StartTime,
+			}
+		}
+		return &auth.DelayedVestingAccount{
+			BaseVestingAccount: baseVestingAcc,
+		}
+	}
+
+	return bacc
+}
+
+// validate checks that the vesting schedule of a genesis account, if any, is
+// consistent.
+func (ga GenesisAccount) validate() error {
+	if ga.OriginalVesting.IsZero() {
+		return nil
+	}
+	if !ga.OriginalVesting.IsValid() {
+		return fmt.Errorf("invalid original vesting coins for account %s: %s", ga.Address, ga.OriginalVesting)
+	}
+	if ga.EndTime == 0 {
+		return fmt.Errorf("missing end time for vesting account %s", ga.Address)
+	}
+	if ga.StartTime >= ga.EndTime {
+		return fmt.Errorf("vesting start time must be before end time for account %s", ga.Address)
+	}
+	if !ga.Coins.Plus(ga.DelegatedFree).Plus(ga.DelegatedVesting).IsGTE(ga.OriginalVesting) {
+		return fmt.Errorf("vesting amount cannot be greater than total amount for account %s", ga.Address)
+	}
+	return nil
 }
 
 // get app init parameters for server init command
@@ -124,11 +192,13 @@ func GaiaAppGenState(cdc *codec.Codec, appGenTxs []json.RawMessage) (genesisStat
 	// create the final app state
 	genesisState = GenesisState{
 		Accounts:     genaccs,
+		AuthData:     auth.DefaultGenesisState(),
 		StakeData:    stakeData,
 		MintData:     mint.DefaultGenesisState(),
 		DistrData:    distr.DefaultGenesisState(),
 		GovData:      gov.DefaultGenesisState(),
 		SlashingData: slashingData,
+		UpgradeData:  upgrade.DefaultGenesisState(),
 		GenTxs:       appGenTxs,
 	}
 
@@ -153,6 +223,18 @@ func GaiaValidateGenesisState(genesisState GenesisState) (err error) {
 	if err != nil {
 		return
 	}
+	err = auth.ValidateGenesis(genesisState.AuthData)
+	if err != nil {
+		return
+	}
+	err = gov.ValidateGenesis(genesisState.GovData)
+	if err != nil {
+		return
+	}
+	err = upgrade.ValidateGenesis(genesisState.UpgradeData)
+	if err != nil {
+		return
+	}
 	// skip stakeData validation as genesis is created from txs
 	if len(genesisState.GenTxs) > 0 {
 		return nil
@@ -169,6 +251,9 @@ func validateGenesisStateAccounts(accs []GenesisAccount) (err error) {
 		if _, ok := addrMap[strAddr]; ok {
 			return fmt.Errorf("Duplicate account in genesis state: Address %v", acc.Address)
 		}
+		if err = acc.validate(); err != nil {
+			return
+		}
 		addrMap[strAddr] = true
 	}
 	return
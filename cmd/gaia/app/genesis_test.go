@@ -43,6 +43,7 @@ func makeGenesisState(t *testing.T, genTxs []auth.StdTx) GenesisState {
 	// create the final app state
 	return GenesisState{
 		Accounts:  genAccs,
+		AuthData:  auth.DefaultGenesisState(),
 		StakeData: stakeData,
 		GovData:   gov.DefaultGenesisState(),
 	}
@@ -53,7 +54,22 @@ func TestToAccount(t *testing.T) {
 	addr := sdk.AccAddress(priv.PubKey().Address())
 	authAcc := auth.NewBaseAccountWithAddress(addr)
 	genAcc := NewGenesisAccount(&authAcc)
-	require.Equal(t, authAcc, *genAcc.ToAccount())
+	acc := genAcc.ToAccount()
+	require.IsType(t, &auth.BaseAccount{}, acc)
+	require.Equal(t, &authAcc, acc.(*auth.BaseAccount))
+
+	authAcc.Coins = sdk.Coins{sdk.NewInt64Coin("steak", 50)}
+	vacc := auth.NewContinuousVestingAccount(&authAcc, 1000, 2000)
+	genAcc = NewGenesisAccountI(vacc)
+	acc = genAcc.ToAccount()
+	require.IsType(t, &auth.ContinuousVestingAccount{}, acc)
+	require.Equal(t, vacc, acc.(*auth.ContinuousVestingAccount))
+
+	dvacc := auth.NewDelayedVestingAccount(&authAcc, 2000)
+	genAcc = NewGenesisAccountI(dvacc)
+	acc = genAcc.ToAccount()
+	require.IsType(t, &auth.DelayedVestingAccount{}, acc)
+	require.Equal(t, dvacc, acc.(*auth.DelayedVestingAccount))
 }
 
 func TestGaiaAppGenTx(t *testing.T) {
@@ -105,4 +121,10 @@ func TestGaiaGenesisValidation(t *testing.T) {
 	genesisState.StakeData.Validators = append(genesisState.StakeData.Validators, val2)
 	err = GaiaValidateGenesisState(genesisState)
 	require.NotNil(t, err)
+	// Test gov genesis without a quorum fails
+	genesisState = makeGenesisState(t, genTxs[:1])
+	require.Nil(t, GaiaValidateGenesisState(genesisState))
+	genesisState.GovData.TallyingProcedure.Quorum = sdk.Dec{}
+	err = GaiaValidateGenesisState(genesisState)
+	require.NotNil(t, err)
 }
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Metrics contains the gauges of the state of the gaia modules, set at the
+// end of every block.
+type Metrics struct {
+	// Ratio of the staking tokens which are bonded
+	BondedRatio metrics.Gauge
+	// Annual inflation rate of the minter
+	Inflation metrics.Gauge
+	// Amount of the community pool, by denomination
+	CommunityPool metrics.Gauge
+	// Number of proposals in their voting period
+	ActiveProposals metrics.Gauge
+}
+
+// PrometheusMetrics returns the gaia module metrics registered to the
+// Prometheus registerer under the namespace.
+func PrometheusMetrics(namespace string, registerer stdprometheus.Registerer) *Metrics {
+	return &Metrics{
+		BondedRatio: prometheus.NewGauge(registerGaugeVec(registerer, stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "stake",
+			Name:      "bonded_ratio",
+			Help:      "Ratio of the staking tokens which are bonded.",
+		}, []string{})),
+		Inflation: prometheus.NewGauge(registerGaugeVec(registerer, stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "mint",
+			Name:      "inflation",
+			Help:      "Annual inflation rate of the minter.",
+		}, []string{})),
+		CommunityPool: prometheus.NewGauge(registerGaugeVec(registerer, stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "distr",
+			Name:      "community_pool",
+			Help:      "Amount of the community pool.",
+		}, []string{"denom"})),
+		ActiveProposals: prometheus.NewGauge(registerGaugeVec(registerer, stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "gov",
+			Name:      "active_proposals",
+			Help:      "Number of proposals in their voting period.",
+		}, []string{})),
+	}
+}
+
+// registerGaugeVec returns a new gauge vector registered to the registerer.
+func registerGaugeVec(registerer stdprometheus.Registerer, opts stdprometheus.GaugeOpts, labels []string) *stdprometheus.GaugeVec {
+	vec := stdprometheus.NewGaugeVec(opts, labels)
+	registerer.MustRegister(vec)
+	return vec
+}
+
+// recordMetrics sets the module gauges to the state of the block, if
+// telemetry is enabled.
+func (app *GaiaApp) recordMetrics(ctx sdk.Context) {
+	if app.metrics == nil {
+		return
+	}
+	app.metrics.BondedRatio.Set(app.stakeKeeper.BondedRatio(ctx).Float64())
+	app.metrics.Inflation.Set(app.mintKeeper.GetMinter(ctx).Inflation.Float64())
+	for _, coin := range app.distrKeeper.GetFeePool(ctx).CommunityPool {
+		app.metrics.CommunityPool.With("denom", coin.Denom).Set(coin.Amount.Float64())
+	}
+	app.metrics.ActiveProposals.Set(float64(app.govKeeper.ActiveProposalCount(ctx)))
+}
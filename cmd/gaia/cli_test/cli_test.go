@@ -31,6 +31,9 @@ import (
 var (
 	gaiadHome   = ""
 	gaiacliHome = ""
+
+	// mnemonic of the keys held by the emulated Ledger device
+	ledgerEmulatorMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
 )
 
 func init() {
@@ -42,7 +45,7 @@ func TestGaiaCLIMinimumFees(t *testing.T) {
 	flags := fmt.Sprintf("--home=%s --node=%v --chain-id=%v", gaiacliHome, servAddr, chainID)
 
 	// start gaiad server with minimum fees
-	proc := tests.GoExecuteTWithStdout(t, fmt.Sprintf("gaiad start --home=%s --rpc.laddr=%v --minimum_fees=2feeToken", gaiadHome, servAddr))
+	proc := tests.GoExecuteTWithStdout(t, fmt.Sprintf("gaiad start --home=%s --rpc.laddr=%v --minimum-gas-prices=0.00001feeToken", gaiadHome, servAddr))
 
 	defer proc.Stop(false)
 	tests.WaitForTMStart(port)
@@ -66,7 +69,7 @@ func TestGaiaCLIFeesDeduction(t *testing.T) {
 	flags := fmt.Sprintf("--home=%s --node=%v --chain-id=%v", gaiacliHome, servAddr, chainID)
 
 	// start gaiad server with minimum fees
-	proc := tests.GoExecuteTWithStdout(t, fmt.Sprintf("gaiad start --home=%s --rpc.laddr=%v --minimum_fees=1fooToken", gaiadHome, servAddr))
+	proc := tests.GoExecuteTWithStdout(t, fmt.Sprintf("gaiad start --home=%s --rpc.laddr=%v --minimum-gas-prices=0.000005fooToken", gaiadHome, servAddr))
 
 	defer proc.Stop(false)
 	tests.WaitForTMStart(port)
@@ -101,6 +104,14 @@ func TestGaiaCLIFeesDeduction(t *testing.T) {
 		"gaiacli tx send %v --fee=300fooToken --amount=500fooToken --to=%s --from=foo", flags, barAddr), app.DefaultKeyPass)
 	require.True(t, success)
 	tests.WaitForNextNBlocksTM(2, port)
+
+	// fees computed from the gas prices: 0.000005 * 200000 gas
+	success = executeWrite(t, fmt.Sprintf(
+		"gaiacli tx send %v --gas-prices=0.000005fooToken --amount=100fooToken --to=%s --from=foo", flags, barAddr), app.DefaultKeyPass)
+	require.True(t, success)
+	tests.WaitForNextNBlocksTM(2, port)
+	fooAcc = executeGetAccount(t, fmt.Sprintf("gaiacli query account %s %v", fooAddr, flags))
+	require.Equal(t, int64(99), fooAcc.GetCoins().AmountOf("fooToken").Int64())
 }
 
 func TestGaiaCLISend(t *testing.T) {
@@ -188,7 +199,7 @@ func TestGaiaCLIGasAuto(t *testing.T) {
 	require.False(t, success)
 
 	// Enable auto gas
-	success, stdout, _ := executeWriteRetStdStreams(t, fmt.Sprintf("gaiacli tx send %v --json --gas=simulate --amount=10steak --to=%s --from=foo", flags, barAddr), app.DefaultKeyPass)
+	success, stdout, _ := executeWriteRetStdStreams(t, fmt.Sprintf("gaiacli tx send %v --json --gas=auto --amount=10steak --to=%s --from=foo", flags, barAddr), app.DefaultKeyPass)
 	require.True(t, success)
 	// check that gas wanted == gas used
 	cdc := app.MakeCodec()
@@ -456,7 +467,7 @@ func TestGaiaCLISendGenerateSignAndBroadcast(t *testing.T) {
 
 	// Test generate sendTx, estimate gas
 	success, stdout, stderr = executeWriteRetStdStreams(t, fmt.Sprintf(
-		"gaiacli tx send %v --amount=10steak --to=%s --from=foo --gas=simulate --generate-only",
+		"gaiacli tx send %v --amount=10steak --to=%s --from=foo --gas=auto --generate-only",
 		flags, barAddr), []string{}...)
 	require.True(t, success)
 	require.NotEmpty(t, stderr)
@@ -513,6 +524,57 @@ func TestGaiaCLISendGenerateSignAndBroadcast(t *testing.T) {
 	require.Equal(t, int64(40), fooAcc.GetCoins().AmountOf("steak").Int64())
 }
 
+func TestGaiaCLILedgerEmulator(t *testing.T) {
+	chainID, servAddr, port := initializeFixtures(t)
+	flags := fmt.Sprintf("--home=%s --node=%v --chain-id=%v", gaiacliHome, servAddr, chainID)
+
+	// use the emulator in place of a Ledger device
+	os.Setenv("GA_SIGNING_DEVICE", client.SigningDeviceEmulator)
+	os.Setenv("GA_LEDGER_EMULATOR_MNEMONIC", ledgerEmulatorMnemonic)
+	defer os.Unsetenv("GA_SIGNING_DEVICE")
+	defer os.Unsetenv("GA_LEDGER_EMULATOR_MNEMONIC")
+
+	// start gaiad server
+	proc := tests.GoExecuteTWithStdout(t, fmt.Sprintf("gaiad start --home=%s --rpc.laddr=%v", gaiadHome, servAddr))
+
+	defer proc.Stop(false)
+	tests.WaitForTMStart(port)
+	tests.WaitForNextNBlocksTM(2, port)
+
+	executeWrite(t, fmt.Sprintf("gaiacli keys delete --home=%s ledger", gaiacliHome), app.DefaultKeyPass)
+	require.True(t, executeWrite(t, fmt.Sprintf("gaiacli keys add --home=%s --ledger ledger", gaiacliHome)))
+	ledgerAddr, _ := executeGetAddrPK(t, fmt.Sprintf("gaiacli keys show ledger --output=json --home=%s", gaiacliHome))
+	barAddr, _ := executeGetAddrPK(t, fmt.Sprintf("gaiacli keys show bar --output=json --home=%s", gaiacliHome))
+
+	// fund the ledger account
+	success := executeWrite(t, fmt.Sprintf(
+		"gaiacli tx send %v --amount=20steak --to=%s --from=foo", flags, ledgerAddr), app.DefaultKeyPass)
+	require.True(t, success)
+	tests.WaitForNextNBlocksTM(2, port)
+
+	// generate, sign with the emulated device and broadcast a send
+	success, stdout, _ := executeWriteRetStdStreams(t, fmt.Sprintf(
+		"gaiacli tx send %v --amount=10steak --to=%s --from=ledger --generate-only", flags, barAddr))
+	require.True(t, success)
+	unsignedTxFile := writeToNewTempFile(t, stdout)
+	defer os.Remove(unsignedTxFile.Name())
+
+	success, stdout, _ = executeWriteRetStdStreams(t, fmt.Sprintf(
+		"gaiacli tx sign %v --name=ledger %v", flags, unsignedTxFile.Name()), app.DefaultKeyPass)
+	require.True(t, success)
+	msg := unmarshalStdTx(t, stdout)
+	require.Equal(t, 1, len(msg.GetSignatures()))
+	signedTxFile := writeToNewTempFile(t, stdout)
+	defer os.Remove(signedTxFile.Name())
+
+	success = executeWrite(t, fmt.Sprintf("gaiacli tx broadcast %v %v", flags, signedTxFile.Name()))
+	require.True(t, success)
+	tests.WaitForNextNBlocksTM(2, port)
+
+	ledgerAcc := executeGetAccount(t, fmt.Sprintf("gaiacli query account %s %v", ledgerAddr, flags))
+	require.Equal(t, int64(10), ledgerAcc.GetCoins().AmountOf("steak").Int64())
+}
+
 func TestGaiaCLIConfig(t *testing.T) {
 	require.NoError(t, os.RemoveAll(gaiacliHome))
 	require.NoError(t, os.RemoveAll(gaiadHome))
@@ -528,6 +590,7 @@ encoding = "btc"
 home = "%s"
 node = "%s"
 output = "text"
+signing_device = "ledger"
 trace = false
 trust_node = true
 `, chainID, gaiacliHome, node)
@@ -547,6 +610,7 @@ encoding = "btc"
 home = "%s"
 node = "%s"
 output = "text"
+signing_device = "ledger"
 trace = false
 trust_node = true
 `, gaiacliHome, node)
@@ -29,6 +29,7 @@ import (
 
 const (
 	storeAcc      = "acc"
+	storeDistr    = "distr"
 	storeGov      = "gov"
 	storeSlashing = "slashing"
 	storeStake    = "stake"
@@ -66,10 +67,14 @@ func main() {
 	)
 	tx.AddCommands(queryCmd, cdc)
 	queryCmd.AddCommand(client.LineBreak)
+	queryCmd.AddCommand(authcmd.GetQueryCmd(cdc))
 	queryCmd.AddCommand(client.GetCommands(
 		authcmd.GetAccountCmd(storeAcc, cdc, authcmd.GetAccountDecoder(cdc)),
 		stakecmd.GetCmdQueryDelegation(storeStake, cdc),
 		stakecmd.GetCmdQueryDelegations(storeStake, cdc),
+		distrcmd.GetCmdQueryDelegationDistInfos(storeDistr, cdc),
+		govcmd.GetCmdQueryDeposit(storeGov, cdc),
+		govcmd.GetCmdQueryDeposits(storeGov, cdc),
 		stakecmd.GetCmdQueryParams(storeStake, cdc),
 		stakecmd.GetCmdQueryPool(storeStake, cdc),
 		govcmd.GetCmdQueryProposal(storeGov, cdc),
@@ -77,9 +82,11 @@ func main() {
 		stakecmd.GetCmdQueryRedelegation(storeStake, cdc),
 		stakecmd.GetCmdQueryRedelegations(storeStake, cdc),
 		slashingcmd.GetCmdQuerySigningInfo(storeSlashing, cdc),
+		slashingcmd.GetCmdQuerySigningInfos(storeSlashing, cdc),
 		stakecmd.GetCmdQueryUnbondingDelegation(storeStake, cdc),
 		stakecmd.GetCmdQueryUnbondingDelegations(storeStake, cdc),
 		stakecmd.GetCmdQueryValidator(storeStake, cdc),
+		distrcmd.GetCmdQueryValidatorDistInfos(storeDistr, cdc),
 		stakecmd.GetCmdQueryValidators(storeStake, cdc),
 		govcmd.GetCmdQueryVote(storeGov, cdc),
 		govcmd.GetCmdQueryVotes(storeGov, cdc),
@@ -96,6 +103,7 @@ func main() {
 		client.PostCommands(
 			bankcmd.GetBroadcastCommand(cdc),
 			authcmd.GetSignCommand(cdc, authcmd.GetAccountDecoder(cdc)),
+			authcmd.GetMultiSignCommand(cdc, authcmd.GetAccountDecoder(cdc)),
 		)...)
 	txCmd.AddCommand(client.LineBreak)
 
@@ -47,9 +47,31 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
+	pruning, err := server.GetPruningStrategy()
+	if err != nil {
+		panic(err)
+	}
 	return app.NewGaiaApp(logger, db, traceStore,
-		baseapp.SetPruning(viper.GetString("pruning")),
-		baseapp.SetMinimumFees(viper.GetString("minimum_fees")),
+		baseapp.SetPruning(pruning),
+		baseapp.SetMinGasPrices(viper.GetString("minimum-gas-prices")),
+		baseapp.SetFeeWeights(viper.GetString("fee-weights")),
+		baseapp.SetPriorityMempool(viper.GetInt("priority-mempool-size")),
+		baseapp.SetSnapshots(
+			server.SnapshotsDir(viper.GetString(cli.HomeFlag)),
+			viper.GetInt64("snapshot-interval"),
+			viper.GetInt("snapshot-keep-recent"),
+		),
+		baseapp.SetStreamingFile(
+			viper.GetString("streaming-file"),
+			viper.GetStringSlice("streaming-stores"),
+		),
+		baseapp.SetInterBlockCache(
+			viper.GetInt("inter-block-cache-size"),
+			viper.GetStringSlice("inter-block-cache-stores"),
+		),
+		baseapp.SetHaltHeight(viper.GetInt64("halt-height")),
+		baseapp.SetHaltTime(viper.GetInt64("halt-time")),
+		baseapp.SetTelemetry(viper.GetBool("telemetry")),
 	)
 }
 
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	gaia "github.com/cosmos/cosmos-sdk/cmd/gaia/app"
+	"github.com/cosmos/cosmos-sdk/server"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/spf13/cobra"
@@ -23,6 +24,7 @@ func init() {
 	rootCmd.AddCommand(pubkeyCmd)
 	rootCmd.AddCommand(addrCmd)
 	rootCmd.AddCommand(hackCmd)
+	server.AddPruningFlags(hackCmd)
 	rootCmd.AddCommand(rawBytesCmd)
 }
 
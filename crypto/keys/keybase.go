@@ -72,12 +72,23 @@ var (
 // a full-featured key manager
 type dbKeybase struct {
 	db dbm.DB
+
+	// discovers the signing device holding the Ledger keys
+	discoverDevice crypto.DiscoverSigningDeviceFn
 }
 
 // New creates a new keybase instance using the passed DB for reading and writing keys.
 func New(db dbm.DB) Keybase {
+	return NewWithSigningDevice(db, crypto.DiscoverLedger)
+}
+
+// NewWithSigningDevice creates a new keybase instance using the passed DB for
+// reading and writing keys, whose Ledger keys are held by the signing device
+// returned by discover, e.g. a LedgerEmulator.
+func NewWithSigningDevice(db dbm.DB, discover crypto.DiscoverSigningDeviceFn) Keybase {
 	return dbKeybase{
-		db: db,
+		db:             db,
+		discoverDevice: discover,
 	}
 }
 
@@ -160,7 +171,7 @@ func (kb dbKeybase) CreateLedger(name string, path crypto.DerivationPath, algo S
 	if algo != Secp256k1 {
 		return nil, ErrUnsupportedSigningAlgo
 	}
-	priv, err := crypto.NewPrivKeyLedgerSecp256k1(path)
+	priv, err := crypto.NewPrivKeySigningDeviceSecp256k1(kb.discoverDevice, path)
 	if err != nil {
 		return nil, err
 	}
@@ -252,7 +263,7 @@ func (kb dbKeybase) Sign(name, passphrase string, msg []byte) (sig []byte, pub t
 		}
 	case ledgerInfo:
 		linfo := info.(ledgerInfo)
-		priv, err = crypto.NewPrivKeyLedgerSecp256k1(linfo.Path)
+		priv, err = crypto.NewPrivKeySigningDeviceSecp256k1(kb.discoverDevice, linfo.Path)
 		if err != nil {
 			return
 		}
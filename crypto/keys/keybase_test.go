@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	ccrypto "github.com/cosmos/cosmos-sdk/crypto"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/mintkey"
 
@@ -110,6 +111,36 @@ func TestKeyManagement(t *testing.T) {
 	require.False(t, db.Has(addrKey(i2.GetAddress())))
 }
 
+// TestLedgerEmulator makes sure Ledger keys are created and sign through the
+// configured signing device
+func TestLedgerEmulator(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	cstore := NewWithSigningDevice(
+		dbm.NewMemDB(),
+		ccrypto.DiscoverLedgerEmulator(mnemonic),
+	)
+
+	// the device holds the keys derived from its mnemonic
+	ledger, err := cstore.CreateLedger("ledger", ccrypto.DerivationPath{44, 118, 0, 0, 0}, Secp256k1)
+	require.NoError(t, err)
+	require.Equal(t, TypeLedger, ledger.GetType())
+	local, err := cstore.CreateKey("local", mnemonic, "1234")
+	require.NoError(t, err)
+	require.Equal(t, local.GetPubKey(), ledger.GetPubKey())
+
+	// sign a message longer than an APDU chunk
+	msg := make([]byte, 600)
+	sig, pub, err := cstore.Sign("ledger", "", msg)
+	require.NoError(t, err)
+	require.Equal(t, ledger.GetPubKey(), pub)
+	require.True(t, pub.VerifyBytes(msg, sig))
+
+	// without a device, Ledger keys can neither be created nor used
+	cstore = New(dbm.NewMemDB())
+	_, err = cstore.CreateLedger("ledger", ccrypto.DerivationPath{44, 118, 0, 0, 0}, Secp256k1)
+	require.Error(t, err)
+}
+
 // TestSignVerify does some detailed checks on how we sign and validate
 // signatures
 func TestSignVerify(t *testing.T) {
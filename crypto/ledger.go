@@ -10,7 +10,7 @@ import (
 // set the discoverLedger function which is responsible for loading the Ledger
 // device at runtime or returning an error.
 func init() {
-	discoverLedger = func() (LedgerSECP256K1, error) {
+	discoverLedger = func() (SigningDevice, error) {
 		device, err := ledger.FindLedger()
 		if err != nil {
 			return nil, err
@@ -0,0 +1,155 @@
+package crypto
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// APDU framing of the Cosmos app running on a Ledger device. A command is
+// made of a header, its class, instruction, and two parameters, followed by
+// the length of its payload and the payload itself. A response carries its
+// data followed by a two bytes status word.
+const (
+	apduCLA = 0x55
+
+	apduINSGetVersion          = 0x00
+	apduINSSignSECP256K1       = 0x02
+	apduINSPublicKeySECP256K1  = 0x04
+	apduHeaderLen              = 5
+	apduMessageChunkSize       = 250
+	apduHardenedPathComponents = 3
+
+	apduStatusOK                  = 0x9000
+	apduStatusWrongLength         = 0x6700
+	apduStatusDataInvalid         = 0x6984
+	apduStatusConditionsNotMet    = 0x6985
+	apduStatusCommandNotAllowed   = 0x6986
+	apduStatusInstructionNotFound = 0x6D00
+	apduStatusClassNotSupported   = 0x6E00
+)
+
+// DeviceTransport exchanges APDU frames with a signing device, e.g. over USB
+// HID for a Ledger or in memory for a LedgerEmulator.
+type DeviceTransport interface {
+	Exchange(command []byte) ([]byte, error)
+}
+
+// apduSigningDevice implements SigningDevice by speaking the APDU framing of
+// the Cosmos Ledger app over a transport.
+type apduSigningDevice struct {
+	transport DeviceTransport
+}
+
+var _ SigningDevice = apduSigningDevice{}
+
+// NewAPDUSigningDevice returns a SigningDevice exchanging the commands of the
+// Cosmos Ledger app over the given transport.
+func NewAPDUSigningDevice(transport DeviceTransport) SigningDevice {
+	return apduSigningDevice{transport: transport}
+}
+
+// GetPublicKeySECP256K1 implements SigningDevice. It returns the public key
+// in the uncompressed format.
+func (d apduSigningDevice) GetPublicKeySECP256K1(path []uint32) ([]byte, error) {
+	pathBytes := encodeAPDUPath(path)
+	command := append([]byte{apduCLA, apduINSPublicKeySECP256K1, 0, 0, byte(len(pathBytes))}, pathBytes...)
+	return d.exchange(command)
+}
+
+// SignSECP256K1 implements SigningDevice. The derivation path and the message
+// are sent in chunks, the signature is returned with the last one in the DER
+// format.
+func (d apduSigningDevice) SignSECP256K1(path []uint32, msg []byte) ([]byte, error) {
+	chunks := [][]byte{encodeAPDUPath(path)}
+	for i := 0; i < len(msg); i += apduMessageChunkSize {
+		end := i + apduMessageChunkSize
+		if end > len(msg) {
+			end = len(msg)
+		}
+		chunks = append(chunks, msg[i:end])
+	}
+	if len(chunks) > 0xFF {
+		return nil, fmt.Errorf("message of %d bytes is too long to be signed", len(msg))
+	}
+
+	var res []byte
+	for i, chunk := range chunks {
+		command := append([]byte{apduCLA, apduINSSignSECP256K1, byte(i + 1), byte(len(chunks)), byte(len(chunk))}, chunk...)
+		var err error
+		res, err = d.exchange(command)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// Exchanges a command and checks the status word of the response.
+func (d apduSigningDevice) exchange(command []byte) ([]byte, error) {
+	res, err := d.transport.Exchange(command)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) < 2 {
+		return nil, errors.New("APDU response too short")
+	}
+	data, status := res[:len(res)-2], binary.BigEndian.Uint16(res[len(res)-2:])
+	if status != apduStatusOK {
+		return nil, apduStatusError(status)
+	}
+	return data, nil
+}
+
+// Encodes a derivation path as its number of components followed by each
+// of them, the first ones being hardened.
+func encodeAPDUPath(path []uint32) []byte {
+	bz := make([]byte, 1+4*len(path))
+	bz[0] = byte(len(path))
+	for i, component := range path {
+		if i < apduHardenedPathComponents {
+			component |= 0x80000000
+		}
+		binary.LittleEndian.PutUint32(bz[1+4*i:], component)
+	}
+	return bz
+}
+
+// Decodes a derivation path encoded by encodeAPDUPath.
+func decodeAPDUPath(bz []byte) (DerivationPath, error) {
+	if len(bz) == 0 || len(bz) != 1+4*int(bz[0]) {
+		return nil, errors.New("invalid derivation path")
+	}
+	path := make(DerivationPath, bz[0])
+	for i := range path {
+		component := binary.LittleEndian.Uint32(bz[1+4*i:])
+		if i < apduHardenedPathComponents {
+			if component&0x80000000 == 0 {
+				return nil, errors.New("invalid derivation path: missing hardened component")
+			}
+			component &^= 0x80000000
+		}
+		path[i] = component
+	}
+	return path, nil
+}
+
+func apduStatusError(status uint16) error {
+	switch status {
+	case apduStatusWrongLength:
+		return errors.New("wrong APDU length")
+	case apduStatusDataInvalid:
+		return errors.New("invalid data")
+	case apduStatusConditionsNotMet:
+		return errors.New("conditions not satisfied")
+	case apduStatusCommandNotAllowed:
+		return errors.New("command rejected")
+	case apduStatusInstructionNotFound:
+		return errors.New("instruction not supported, is the Cosmos app open?")
+	case apduStatusClassNotSupported:
+		return errors.New("class not supported, is the Cosmos app open?")
+	default:
+		return fmt.Errorf("unexpected APDU status: 0x%04X", status)
+	}
+}
@@ -0,0 +1,144 @@
+package crypto
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	secp256k1 "github.com/btcsuite/btcd/btcec"
+	"github.com/cosmos/go-bip39"
+	tmsecp256k1 "github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
+)
+
+// version of the Cosmos Ledger app reported by the emulator
+var ledgerEmulatorVersion = []byte{0, 0, 1, 0}
+
+// LedgerEmulator is a software DeviceTransport answering the APDU commands of
+// the Cosmos Ledger app with keys derived from a mnemonic. It lets the flows
+// using a Ledger run without the device, e.g. in tests, and must not be used
+// to hold real funds.
+type LedgerEmulator struct {
+	mtx       sync.Mutex
+	masterKey [32]byte
+	chainCode [32]byte
+
+	// chunks of the message being signed
+	signPath   DerivationPath
+	signMsg    []byte
+	signChunks int
+}
+
+var _ DeviceTransport = (*LedgerEmulator)(nil)
+
+// NewLedgerEmulator returns an emulator holding the keys derived from the
+// given mnemonic, as a Ledger initialized with it would.
+func NewLedgerEmulator(mnemonic string) (*LedgerEmulator, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	masterKey, chainCode := hd.ComputeMastersFromSeed(seed)
+	return &LedgerEmulator{masterKey: masterKey, chainCode: chainCode}, nil
+}
+
+// DiscoverLedgerEmulator returns a discovery function connecting to an
+// emulator holding the keys derived from the given mnemonic.
+func DiscoverLedgerEmulator(mnemonic string) DiscoverSigningDeviceFn {
+	return func() (SigningDevice, error) {
+		emulator, err := NewLedgerEmulator(mnemonic)
+		if err != nil {
+			return nil, err
+		}
+		return NewAPDUSigningDevice(emulator), nil
+	}
+}
+
+// Exchange implements DeviceTransport.
+func (e *LedgerEmulator) Exchange(command []byte) ([]byte, error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if len(command) < apduHeaderLen {
+		return apduResponse(nil, apduStatusWrongLength), nil
+	}
+	if command[0] != apduCLA {
+		return apduResponse(nil, apduStatusClassNotSupported), nil
+	}
+	payload := command[apduHeaderLen:]
+	if len(payload) != int(command[4]) {
+		return apduResponse(nil, apduStatusWrongLength), nil
+	}
+
+	switch command[1] {
+	case apduINSGetVersion:
+		return apduResponse(ledgerEmulatorVersion, apduStatusOK), nil
+
+	case apduINSPublicKeySECP256K1:
+		path, err := decodeAPDUPath(payload)
+		if err != nil {
+			return apduResponse(nil, apduStatusDataInvalid), nil
+		}
+		priv, err := e.derivePrivKey(path)
+		if err != nil {
+			return apduResponse(nil, apduStatusDataInvalid), nil
+		}
+		_, pub := secp256k1.PrivKeyFromBytes(secp256k1.S256(), priv[:])
+		return apduResponse(pub.SerializeUncompressed(), apduStatusOK), nil
+
+	case apduINSSignSECP256K1:
+		return e.sign(int(command[2]), int(command[3]), payload), nil
+
+	default:
+		return apduResponse(nil, apduStatusInstructionNotFound), nil
+	}
+}
+
+// Accumulates the chunks of a sign command, the first one holding the
+// derivation path, and signs the message once the last one is received.
+func (e *LedgerEmulator) sign(index, count int, payload []byte) []byte {
+	if index == 1 {
+		path, err := decodeAPDUPath(payload)
+		if err != nil || count < 2 {
+			return apduResponse(nil, apduStatusDataInvalid)
+		}
+		e.signPath, e.signMsg, e.signChunks = path, nil, count
+		return apduResponse(nil, apduStatusOK)
+	}
+	if e.signPath == nil || count != e.signChunks || index < 2 || index > count {
+		return apduResponse(nil, apduStatusConditionsNotMet)
+	}
+	e.signMsg = append(e.signMsg, payload...)
+	if index < count {
+		return apduResponse(nil, apduStatusOK)
+	}
+
+	path, msg := e.signPath, e.signMsg
+	e.signPath, e.signMsg, e.signChunks = nil, nil, 0
+
+	priv, err := e.derivePrivKey(path)
+	if err != nil {
+		return apduResponse(nil, apduStatusDataInvalid)
+	}
+	sig, err := tmsecp256k1.PrivKeySecp256k1(priv).Sign(msg)
+	if err != nil {
+		return apduResponse(nil, apduStatusDataInvalid)
+	}
+	return apduResponse(sig, apduStatusOK)
+}
+
+func (e *LedgerEmulator) derivePrivKey(path DerivationPath) ([32]byte, error) {
+	if len(path) != 5 {
+		return [32]byte{}, fmt.Errorf("expected a BIP44 derivation path, got %v", path)
+	}
+	hdPath := fmt.Sprintf("%d'/%d'/%d'/%d/%d", path[0], path[1], path[2], path[3], path[4])
+	return hd.DerivePrivateKeyForPath(e.masterKey, e.chainCode, hdPath)
+}
+
+func apduResponse(data []byte, status uint16) []byte {
+	res := make([]byte, len(data)+2)
+	copy(res, data)
+	binary.BigEndian.PutUint16(res[len(data):], status)
+	return res
+}
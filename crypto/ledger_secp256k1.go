@@ -13,21 +13,23 @@ import (
 var (
 	// discoverLedger defines a function to be invoked at runtime for discovering
 	// a connected Ledger device.
-	discoverLedger discoverLedgerFn
+	discoverLedger DiscoverSigningDeviceFn
 )
 
 type (
-	// discoverLedgerFn defines a Ledger discovery function that returns a
-	// connected device or an error upon failure. Its allows a method to avoid CGO
-	// dependencies when Ledger support is potentially not enabled.
-	discoverLedgerFn func() (LedgerSECP256K1, error)
+	// DiscoverSigningDeviceFn defines a signing device discovery function that
+	// returns a connected device or an error upon failure. Its allows a method
+	// to avoid CGO dependencies when Ledger support is potentially not enabled.
+	DiscoverSigningDeviceFn func() (SigningDevice, error)
 
 	// DerivationPath represents a Ledger derivation path.
 	DerivationPath []uint32
 
-	// LedgerSECP256K1 reflects an interface a Ledger API must implement for
-	// the SECP256K1 scheme.
-	LedgerSECP256K1 interface {
+	// SigningDevice reflects an interface a device holding keys, such as a
+	// Ledger, must implement for the SECP256K1 scheme. The keys never leave
+	// the device, which returns public keys and signatures for a derivation
+	// path.
+	SigningDevice interface {
 		GetPublicKeySECP256K1([]uint32) ([]byte, error)
 		SignSECP256K1([]uint32, []byte) ([]byte, error)
 	}
@@ -40,21 +42,33 @@ type (
 		// ledger attached.
 		CachedPubKey tmcrypto.PubKey
 		Path         DerivationPath
-		ledger       LedgerSECP256K1
+		ledger       SigningDevice
 	}
 )
 
+// DiscoverLedger returns the connected Ledger device, or an error if none is
+// found or Ledger support is not enabled.
+func DiscoverLedger() (SigningDevice, error) {
+	if discoverLedger == nil {
+		return nil, errors.New("no Ledger discovery function defined")
+	}
+
+	return discoverLedger()
+}
+
 // NewPrivKeyLedgerSecp256k1 will generate a new key and store the public key
 // for later use.
 //
 // CONTRACT: The ledger device, ledgerDevice, must be loaded and set prior to
 // any creation of a PrivKeyLedgerSecp256k1.
 func NewPrivKeyLedgerSecp256k1(path DerivationPath) (tmcrypto.PrivKey, error) {
-	if discoverLedger == nil {
-		return nil, errors.New("no Ledger discovery function defined")
-	}
+	return NewPrivKeySigningDeviceSecp256k1(DiscoverLedger, path)
+}
 
-	device, err := discoverLedger()
+// NewPrivKeySigningDeviceSecp256k1 will generate a new key held by the signing
+// device returned by discover and store the public key for later use.
+func NewPrivKeySigningDeviceSecp256k1(discover DiscoverSigningDeviceFn, path DerivationPath) (tmcrypto.PrivKey, error) {
+	device, err := discover()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create PrivKeyLedgerSecp256k1")
 	}
@@ -5,8 +5,12 @@ import (
 	"os"
 	"testing"
 
+	"github.com/cosmos/go-bip39"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto/encoding/amino"
+	tmsecp256k1 "github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
 )
 
 var ledgerEnabledEnv = "TEST_WITH_LEDGER"
@@ -62,3 +66,73 @@ func TestRealLedgerErrorHandling(t *testing.T) {
 	_, err := NewPrivKeyLedgerSecp256k1(path)
 	require.Error(t, err)
 }
+
+func TestLedgerEmulatorSecp256k1(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	path := DerivationPath{44, 118, 0, 0, 3}
+
+	priv, err := NewPrivKeySigningDeviceSecp256k1(DiscoverLedgerEmulator(mnemonic), path)
+	require.NoError(t, err)
+
+	// the emulator must hold the same key as a local derivation
+	seed := bip39.NewSeed(mnemonic, "")
+	master, ch := hd.ComputeMastersFromSeed(seed)
+	derived, err := hd.DerivePrivateKeyForPath(master, ch, "44'/118'/0'/0/3")
+	require.NoError(t, err)
+	require.Equal(t, tmsecp256k1.PrivKeySecp256k1(derived).PubKey(), priv.PubKey())
+
+	// messages longer than a chunk are signed as a whole
+	msg := make([]byte, 3*apduMessageChunkSize+1)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	sig, err := priv.Sign(msg)
+	require.NoError(t, err)
+	require.True(t, priv.PubKey().VerifyBytes(msg, sig))
+	require.False(t, priv.PubKey().VerifyBytes(msg[1:], sig))
+}
+
+func TestLedgerEmulatorAPDU(t *testing.T) {
+	emulator, err := NewLedgerEmulator("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+	require.NoError(t, err)
+
+	status := func(res []byte) uint16 {
+		return uint16(res[len(res)-2])<<8 | uint16(res[len(res)-1])
+	}
+
+	res, err := emulator.Exchange([]byte{apduCLA, apduINSGetVersion, 0, 0, 0})
+	require.NoError(t, err)
+	require.Equal(t, uint16(apduStatusOK), status(res))
+
+	res, err = emulator.Exchange([]byte{0x00, apduINSGetVersion, 0, 0, 0})
+	require.NoError(t, err)
+	require.Equal(t, uint16(apduStatusClassNotSupported), status(res))
+
+	res, err = emulator.Exchange([]byte{apduCLA, 0x42, 0, 0, 0})
+	require.NoError(t, err)
+	require.Equal(t, uint16(apduStatusInstructionNotFound), status(res))
+
+	// message chunks must follow a derivation path
+	res, err = emulator.Exchange([]byte{apduCLA, apduINSSignSECP256K1, 2, 2, 1, 0})
+	require.NoError(t, err)
+	require.Equal(t, uint16(apduStatusConditionsNotMet), status(res))
+
+	// errors are reported by the device
+	device := NewAPDUSigningDevice(emulator)
+	_, err = device.GetPublicKeySECP256K1([]uint32{44, 118, 0})
+	require.Error(t, err)
+}
+
+func TestAPDUPath(t *testing.T) {
+	path := DerivationPath{44, 118, 2, 0, 7}
+	bz := encodeAPDUPath(path)
+	require.Equal(t, byte(5), bz[0])
+	decoded, err := decodeAPDUPath(bz)
+	require.NoError(t, err)
+	require.Equal(t, path, decoded)
+
+	_, err = decodeAPDUPath(bz[:len(bz)-1])
+	require.Error(t, err)
+	_, err = decodeAPDUPath(nil)
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	abci "github.com/tendermint/tendermint/abci/types"
 	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
@@ -11,6 +12,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 const (
@@ -28,21 +30,31 @@ func NewApp3(logger log.Logger, db dbm.DB) *bapp.BaseApp {
 	// Create a key for accessing the account store.
 	keyAccount := sdk.NewKVStoreKey("acc")
 	keyFees := sdk.NewKVStoreKey("fee") // TODO
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
 
 	// Set various mappers/keepers to interact easily with underlying stores
-	accountKeeper := auth.NewAccountKeeper(cdc, keyAccount, auth.ProtoBaseAccount)
+	paramsKeeper := params.NewKeeper(cdc, keyParams, tkeyParams)
+	accountKeeper := auth.NewAccountKeeper(cdc, keyAccount, paramsKeeper.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
 	bankKeeper := bank.NewBaseKeeper(accountKeeper)
 	feeKeeper := auth.NewFeeCollectionKeeper(cdc, keyFees)
 
 	app.SetAnteHandler(auth.NewAnteHandler(accountKeeper, feeKeeper))
 
+	// Set the default limits and gas costs of the AnteHandler at genesis
+	app.SetInitChainer(func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+		auth.InitGenesis(ctx, accountKeeper, auth.DefaultGenesisState())
+		return abci.ResponseInitChain{}
+	})
+
 	// Register message routes.
 	// Note the handler gets access to
 	app.Router().
 		AddRoute("bank", bank.NewHandler(bankKeeper))
 
 	// Mount stores and load the latest state.
-	app.MountStoresIAVL(keyAccount, keyFees)
+	app.MountStoresIAVL(keyAccount, keyFees, keyParams)
+	app.MountStore(tkeyParams, sdk.StoreTypeTransient)
 	err := app.LoadLatestVersion(keyAccount)
 	if err != nil {
 		cmn.Exit(err.Error())
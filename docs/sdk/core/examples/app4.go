@@ -11,6 +11,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 const (
@@ -26,9 +27,12 @@ func NewApp4(logger log.Logger, db dbm.DB) *bapp.BaseApp {
 
 	// Create a key for accessing the account store.
 	keyAccount := sdk.NewKVStoreKey("acc")
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
 
 	// Set various mappers/keepers to interact easily with underlying stores
-	accountKeeper := auth.NewAccountKeeper(cdc, keyAccount, auth.ProtoBaseAccount)
+	paramsKeeper := params.NewKeeper(cdc, keyParams, tkeyParams)
+	accountKeeper := auth.NewAccountKeeper(cdc, keyAccount, paramsKeeper.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
 	bankKeeper := bank.NewBaseKeeper(accountKeeper)
 
 	// TODO
@@ -46,7 +50,8 @@ func NewApp4(logger log.Logger, db dbm.DB) *bapp.BaseApp {
 		AddRoute("bank", bank.NewHandler(bankKeeper))
 
 	// Mount stores and load the latest state.
-	app.MountStoresIAVL(keyAccount, keyFees)
+	app.MountStoresIAVL(keyAccount, keyFees, keyParams)
+	app.MountStore(tkeyParams, sdk.StoreTypeTransient)
 	err := app.LoadLatestVersion(keyAccount)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -95,6 +100,9 @@ func NewInitChainer(cdc *codec.Codec, accountKeeper auth.AccountKeeper) sdk.Init
 			accountKeeper.SetAccount(ctx, acc)
 		}
 
+		// Set the default limits and gas costs of the AnteHandler
+		auth.InitGenesis(ctx, accountKeeper, auth.DefaultGenesisState())
+
 		return abci.ResponseInitChain{}
 	}
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/params"
 
 	"github.com/cosmos/cosmos-sdk/examples/democoin/types"
 	"github.com/cosmos/cosmos-sdk/examples/democoin/x/cool"
@@ -45,6 +46,8 @@ type DemocoinApp struct {
 	capKeyPowStore     *sdk.KVStoreKey
 	capKeyIBCStore     *sdk.KVStoreKey
 	capKeyStakingStore *sdk.KVStoreKey
+	keyParams          *sdk.KVStoreKey
+	tkeyParams         *sdk.TransientStoreKey
 
 	// keepers
 	feeCollectionKeeper auth.FeeCollectionKeeper
@@ -53,6 +56,7 @@ type DemocoinApp struct {
 	powKeeper           pow.Keeper
 	ibcMapper           ibc.Mapper
 	stakeKeeper         simplestake.Keeper
+	paramsKeeper        params.Keeper
 
 	// Manage getting and setting accounts
 	accountKeeper auth.AccountKeeper
@@ -72,13 +76,18 @@ func NewDemocoinApp(logger log.Logger, db dbm.DB) *DemocoinApp {
 		capKeyPowStore:     sdk.NewKVStoreKey("pow"),
 		capKeyIBCStore:     sdk.NewKVStoreKey("ibc"),
 		capKeyStakingStore: sdk.NewKVStoreKey("stake"),
+		keyParams:          sdk.NewKVStoreKey("params"),
+		tkeyParams:         sdk.NewTransientStoreKey("transient_params"),
 	}
 
+	app.paramsKeeper = params.NewKeeper(cdc, app.keyParams, app.tkeyParams)
+
 	// Define the accountKeeper.
 	app.accountKeeper = auth.NewAccountKeeper(
 		cdc,
 		app.capKeyAccountStore, // target store
-		types.ProtoAppAccount,  // prototype
+		app.paramsKeeper.Subspace(auth.DefaultParamspace),
+		types.ProtoAppAccount, // prototype
 	)
 
 	// Add handlers.
@@ -97,7 +106,8 @@ func NewDemocoinApp(logger log.Logger, db dbm.DB) *DemocoinApp {
 
 	// Initialize BaseApp.
 	app.SetInitChainer(app.initChainerFn(app.coolKeeper, app.powKeeper))
-	app.MountStoresIAVL(app.capKeyMainStore, app.capKeyAccountStore, app.capKeyPowStore, app.capKeyIBCStore, app.capKeyStakingStore)
+	app.MountStoresIAVL(app.capKeyMainStore, app.capKeyAccountStore, app.capKeyPowStore, app.capKeyIBCStore, app.capKeyStakingStore, app.keyParams)
+	app.MountStore(app.tkeyParams, sdk.StoreTypeTransient)
 	app.SetAnteHandler(auth.NewAnteHandler(app.accountKeeper, app.feeCollectionKeeper))
 	err := app.LoadLatestVersion(app.capKeyMainStore)
 	if err != nil {
@@ -151,6 +161,8 @@ func (app *DemocoinApp) initChainerFn(coolKeeper cool.Keeper, powKeeper pow.Keep
 			app.accountKeeper.SetAccount(ctx, acc)
 		}
 
+		auth.InitGenesis(ctx, app.accountKeeper, auth.DefaultGenesisState())
+
 		// Application specific genesis handling
 		err = cool.InitGenesis(ctx, app.coolKeeper, genesisState.CoolGenesis)
 		if err != nil {
@@ -48,7 +48,7 @@ func handleMsgQuiz(ctx sdk.Context, k Keeper, msg MsgQuiz) sdk.Result {
 
 	bonusCoins := sdk.Coins{sdk.NewInt64Coin(msg.CoolAnswer, 69)}
 
-	_, _, err := k.ck.AddCoins(ctx, msg.Sender, bonusCoins)
+	_, err := k.ck.AddCoins(ctx, msg.Sender, bonusCoins)
 	if err != nil {
 		return err.Result()
 	}
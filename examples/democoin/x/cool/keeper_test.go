@@ -13,23 +13,29 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	auth "github.com/cosmos/cosmos-sdk/x/auth"
 	bank "github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
-func setupMultiStore() (sdk.MultiStore, *sdk.KVStoreKey) {
+func setupMultiStore() (sdk.MultiStore, *sdk.KVStoreKey, *sdk.KVStoreKey, *sdk.TransientStoreKey) {
 	db := dbm.NewMemDB()
 	capKey := sdk.NewKVStoreKey("capkey")
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(capKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
 	ms.LoadLatestVersion()
-	return ms, capKey
+	return ms, capKey, keyParams, tkeyParams
 }
 
 func TestCoolKeeper(t *testing.T) {
-	ms, capKey := setupMultiStore()
+	ms, capKey, keyParams, tkeyParams := setupMultiStore()
 	cdc := codec.New()
 	auth.RegisterBaseAccount(cdc)
 
-	am := auth.NewAccountKeeper(cdc, capKey, auth.ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	am := auth.NewAccountKeeper(cdc, capKey, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
 	ctx := sdk.NewContext(ms, abci.Header{}, false, nil)
 	ck := bank.NewBaseKeeper(am)
 	keeper := NewKeeper(capKey, ck, DefaultCodespace)
@@ -12,14 +12,16 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	auth "github.com/cosmos/cosmos-sdk/x/auth"
 	bank "github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 func TestPowHandler(t *testing.T) {
-	ms, capKey := setupMultiStore()
+	ms, capKey, keyParams, tkeyParams := setupMultiStore()
 	cdc := codec.New()
 	auth.RegisterBaseAccount(cdc)
 
-	am := auth.NewAccountKeeper(cdc, capKey, auth.ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	am := auth.NewAccountKeeper(cdc, capKey, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
 	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
 	config := NewConfig("pow", int64(1))
 	ck := bank.NewBaseKeeper(am)
@@ -7,13 +7,74 @@ import (
 )
 
 const (
-	defaultMinimumFees = ""
+	defaultMinGasPrices        = ""
+	defaultFeeWeights          = ""
+	defaultPriorityMempoolSize = 5000
+	defaultPruning             = "syncable"
+	defaultPruningInterval     = 10
+	defaultSnapshotInterval    = 0
+	defaultSnapshotKeepRecent  = 2
+	defaultTelemetryAddress    = "0.0.0.0:26670"
+
+	// gas assumed when converting a legacy minimum fee into gas prices, the
+	// default gas limit of the clients
+	legacyMinFeesGas = 200000
+	// gas which cost one unit of every fee denomination under the legacy
+	// minimum fee rule
+	legacyGasPerUnitCost = 1000
 )
 
 // BaseConfig defines the server's basic configuration
 type BaseConfig struct {
+	// The minimum gas prices a validator is willing to accept for processing a
+	// transaction. A transaction's fees must meet the minimum of any denomination
+	// specified in this config (e.g. 0.01photino,0.0001stake).
+	MinGasPrices string `mapstructure:"minimum-gas-prices"`
+
 	// Tx minimum fee
+	//
+	// DEPRECATED: replaced by MinGasPrices, see MigrateMinimumFees.
 	MinFees string `mapstructure:"minimum_fees"`
+
+	// Weights of the fee denominations when computing the gas price of a tx
+	FeeWeights string `mapstructure:"fee-weights"`
+
+	// Maximum number of txs in the priority mempool
+	PriorityMempoolSize int `mapstructure:"priority-mempool-size"`
+
+	// Pruning strategy of the application state: syncable, nothing,
+	// everything, or custom with the values below
+	Pruning           string `mapstructure:"pruning"`
+	PruningKeepRecent int64  `mapstructure:"pruning-keep-recent"`
+	PruningKeepEvery  int64  `mapstructure:"pruning-keep-every"`
+	PruningInterval   int64  `mapstructure:"pruning-interval"`
+
+	// Number of heights between state snapshots, 0 disables them
+	SnapshotInterval int64 `mapstructure:"snapshot-interval"`
+
+	// Number of recent state snapshots to keep, 0 keeps them all
+	SnapshotKeepRecent int `mapstructure:"snapshot-keep-recent"`
+
+	// File the changes committed to the streaming stores are appended to at
+	// every block, empty to disable the streaming
+	StreamingFile   string   `mapstructure:"streaming-file"`
+	StreamingStores []string `mapstructure:"streaming-stores"`
+
+	// Number of values of each of the inter-block cache stores kept cached
+	// across blocks, 0 to disable the cache
+	InterBlockCacheSize   int      `mapstructure:"inter-block-cache-size"`
+	InterBlockCacheStores []string `mapstructure:"inter-block-cache-stores"`
+
+	// Height of the last block committed before the node shuts down, and
+	// time (in unix seconds) from which it shuts down after committing a
+	// block, 0 to disable them
+	HaltHeight int64 `mapstructure:"halt-height"`
+	HaltTime   int64 `mapstructure:"halt-time"`
+
+	// Whether the application metrics are served to Prometheus, and the
+	// address they are served on
+	Telemetry        bool   `mapstructure:"telemetry"`
+	TelemetryAddress string `mapstructure:"telemetry-address"`
 }
 
 // Config defines the server's top level configuration
@@ -21,17 +82,62 @@ type Config struct {
 	BaseConfig `mapstructure:",squash"`
 }
 
-// SetMinimumFee sets the minimum fee.
-func (c *Config) SetMinimumFees(fees sdk.Coins) { c.MinFees = fees.String() }
+// SetMinGasPrices sets the validator's minimum gas prices.
+func (c *Config) SetMinGasPrices(gasPrices sdk.DecCoins) { c.MinGasPrices = gasPrices.String() }
 
-// SetMinimumFee sets the minimum fee.
-func (c *Config) MinimumFees() sdk.Coins {
-	fees, err := sdk.ParseCoins(c.MinFees)
+// GetMinGasPrices returns the validator's minimum gas prices based on the set
+// configuration.
+func (c *Config) GetMinGasPrices() sdk.DecCoins {
+	gasPrices, err := sdk.ParseDecCoins(c.MinGasPrices)
 	if err != nil {
-		panic(fmt.Sprintf("invalid minimum fees: %v", err))
+		panic(fmt.Sprintf("invalid minimum gas prices: %v", err))
 	}
-	return fees
+	return gasPrices
+}
+
+// SetFeeWeights sets the weights of the fee denominations.
+func (c *Config) SetFeeWeights(weights string) { c.FeeWeights = weights }
+
+// GetFeeWeights returns the weights of the fee denominations.
+func (c *Config) GetFeeWeights() sdk.FeeWeights {
+	weights, err := sdk.ParseFeeWeights(c.FeeWeights)
+	if err != nil {
+		panic(fmt.Sprintf("invalid fee weights: %v", err))
+	}
+	return weights
+}
+
+// MigrateMinimumFees converts a legacy minimum_fees value into minimum gas
+// prices. The legacy rule required the minimum fee plus one unit per
+// legacyGasPerUnitCost gas of each denomination, so every denomination is
+// priced such that a tx using the default gas limit pays what it used to.
+func MigrateMinimumFees(minFees string) (sdk.DecCoins, error) {
+	fees, err := sdk.ParseCoins(minFees)
+	if err != nil {
+		return nil, err
+	}
+	gasPrices := make(sdk.DecCoins, 0, len(fees))
+	for _, fee := range fees {
+		if !fee.IsPositive() {
+			continue
+		}
+		price := sdk.NewDecFromInt(fee.Amount).QuoInt(sdk.NewInt(legacyMinFeesGas))
+		price = price.Add(sdk.OneDec().QuoInt(sdk.NewInt(legacyGasPerUnitCost)))
+		gasPrices = append(gasPrices, sdk.DecCoin{Denom: fee.Denom, Amount: price})
+	}
+	return gasPrices, nil
 }
 
 // DefaultConfig returns server's default configuration.
-func DefaultConfig() *Config { return &Config{BaseConfig{MinFees: defaultMinimumFees}} }
+func DefaultConfig() *Config {
+	return &Config{BaseConfig{
+		MinGasPrices:        defaultMinGasPrices,
+		FeeWeights:          defaultFeeWeights,
+		PriorityMempoolSize: defaultPriorityMempoolSize,
+		Pruning:             defaultPruning,
+		PruningInterval:     defaultPruningInterval,
+		SnapshotInterval:    defaultSnapshotInterval,
+		SnapshotKeepRecent:  defaultSnapshotKeepRecent,
+		TelemetryAddress:    defaultTelemetryAddress,
+	}}
+}
@@ -9,11 +9,32 @@ import (
 
 func TestDefaultConfig(t *testing.T) {
 	cfg := DefaultConfig()
-	require.True(t, cfg.MinimumFees().IsZero())
+	require.True(t, cfg.GetMinGasPrices().IsZero())
 }
 
-func TestSetMinimumFees(t *testing.T) {
+func TestSetMinGasPrices(t *testing.T) {
 	cfg := DefaultConfig()
-	cfg.SetMinimumFees(sdk.Coins{sdk.NewCoin("foo", sdk.NewInt(100))})
-	require.Equal(t, "100foo", cfg.MinFees)
+	cfg.SetMinGasPrices(sdk.DecCoins{sdk.NewDecCoin("foo", 5)})
+	require.Equal(t, "5.0000000000foo", cfg.MinGasPrices)
+}
+
+func TestMigrateMinimumFees(t *testing.T) {
+	gasPrices, err := MigrateMinimumFees("")
+	require.NoError(t, err)
+	require.True(t, gasPrices.IsZero())
+
+	// 2 / 200000 gas + 1 / 1000 gas
+	gasPrices, err = MigrateMinimumFees("2feeToken,1fooToken")
+	require.NoError(t, err)
+	require.Equal(t, "0.0010100000feeToken,0.0010050000fooToken", gasPrices.String())
+
+	_, err = MigrateMinimumFees("2 fee token")
+	require.Error(t, err)
+}
+
+func TestSetFeeWeights(t *testing.T) {
+	cfg := DefaultConfig()
+	require.Empty(t, cfg.GetFeeWeights())
+	cfg.SetFeeWeights("1steak,0.5photino")
+	require.True(t, sdk.NewDecWithPrec(5, 1).Equal(cfg.GetFeeWeights()["photino"]))
 }
@@ -13,8 +13,63 @@ const defaultConfigTemplate = `# This is a TOML config file.
 
 ##### main base config options #####
 
-# Validators reject any tx from the mempool with less than the minimum fee per gas.
-minimum_fees = "{{ .BaseConfig.MinFees }}"
+# The minimum gas prices a validator is willing to accept for processing a
+# transaction. A transaction's fees must meet the minimum of any denomination
+# specified in this config (e.g. 0.01photino,0.0001stake).
+minimum-gas-prices = "{{ .BaseConfig.MinGasPrices }}"
+
+# Weights of the fee denominations when computing the gas price of a tx, which
+# orders the txs in the mempool, e.g. "1steak,0.5photino". When empty, every
+# denomination has a weight of one.
+fee-weights = "{{ .BaseConfig.FeeWeights }}"
+
+# Maximum number of txs kept in the priority mempool. Once full, a tx is only
+# admitted by evicting one paying a lower gas price. 0 disables it.
+priority-mempool-size = {{ .BaseConfig.PriorityMempoolSize }}
+
+# Pruning strategy of the application state:
+# syncable: keep the last 100 versions and every 10000th
+# nothing: keep every version, e.g. on archive nodes
+# everything: keep only the latest version
+# custom: keep the last pruning-keep-recent versions and every
+# pruning-keep-every-th version, pruning the others every pruning-interval
+# blocks (0 never prunes)
+pruning = "{{ .BaseConfig.Pruning }}"
+pruning-keep-recent = {{ .BaseConfig.PruningKeepRecent }}
+pruning-keep-every = {{ .BaseConfig.PruningKeepEvery }}
+pruning-interval = {{ .BaseConfig.PruningInterval }}
+
+# Number of heights between the snapshots of the state, which new nodes can be
+# restored from with "snapshots restore" instead of replaying the chain. The
+# snapshotted heights must not be pruned. 0 disables the snapshots.
+snapshot-interval = {{ .BaseConfig.SnapshotInterval }}
+
+# Number of recent snapshots to keep, 0 keeps them all.
+snapshot-keep-recent = {{ .BaseConfig.SnapshotKeepRecent }}
+
+# File the changes committed to the streaming-stores are appended to at every
+# block, as length-prefixed amino-encoded change sets, e.g. for an indexer.
+# An empty file disables the streaming.
+streaming-file = "{{ .BaseConfig.StreamingFile }}"
+streaming-stores = [{{ range $i, $name := .BaseConfig.StreamingStores }}{{ if $i }}, {{ end }}"{{ $name }}"{{ end }}]
+
+# Number of values of each of the inter-block-cache-stores kept cached across
+# blocks, so that the values read every block, e.g. parameters or pools, are
+# not loaded from the database again. 0 disables the cache.
+inter-block-cache-size = {{ .BaseConfig.InterBlockCacheSize }}
+inter-block-cache-stores = [{{ range $i, $name := .BaseConfig.InterBlockCacheStores }}{{ if $i }}, {{ end }}"{{ $name }}"{{ end }}]
+
+# The node commits the block at halt-height, or the first block whose time is
+# at or after halt-time (in unix seconds), then shuts down, e.g. to upgrade at
+# a coordinated height. 0 disables them.
+halt-height = {{ .BaseConfig.HaltHeight }}
+halt-time = {{ .BaseConfig.HaltTime }}
+
+# Serve the application metrics, e.g. of the txs, stores and modules, to
+# Prometheus at http://telemetry-address/metrics. They are distinct from the
+# Tendermint metrics enabled in config.toml, which use another address.
+telemetry = {{ .BaseConfig.Telemetry }}
+telemetry-address = "{{ .BaseConfig.TelemetryAddress }}"
 `
 
 var configTemplate *template.Template
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/prometheus/client_golang/prometheus"
 	abci "github.com/tendermint/tendermint/abci/types"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
@@ -20,6 +21,12 @@ type (
 	// AppExporter is a function that dumps all app state to
 	// JSON-serializable structure and returns the current validator set.
 	AppExporter func(log.Logger, dbm.DB, io.Writer) (json.RawMessage, []tmtypes.GenesisValidator, error)
+
+	// InstrumentedApp is an application recording its metrics in a
+	// Prometheus registry, served with telemetry, e.g. one built on BaseApp.
+	InstrumentedApp interface {
+		MetricsRegistry() *prometheus.Registry
+	}
 )
 
 func openDB(rootDir string) (dbm.DB, error) {
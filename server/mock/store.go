@@ -74,6 +74,14 @@ func (ms multiStore) LoadVersion(ver int64) error {
 	panic("not implemented")
 }
 
+func (ms multiStore) LoadLatestVersionAndUpgrade(upgrades *sdk.StoreUpgrades) error {
+	panic("not implemented")
+}
+
+func (ms multiStore) LoadVersionAndUpgrade(ver int64, upgrades *sdk.StoreUpgrades) error {
+	panic("not implemented")
+}
+
 func (ms multiStore) GetKVStore(key sdk.StoreKey) sdk.KVStore {
 	return ms.kv[key]
 }
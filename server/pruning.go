@@ -0,0 +1,80 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagPruningKeepRecent = "pruning-keep-recent"
+	flagPruningKeepEvery  = "pruning-keep-every"
+	flagPruningInterval   = "pruning-interval"
+
+	pruningCustom = "custom"
+)
+
+// PruneCmd prunes the versions of the application state which the pruning
+// strategy doesn't keep, and compacts the database.
+func PruneCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Prune and compact the application state of a stopped node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pruning, err := GetPruningStrategy()
+			if err != nil {
+				return err
+			}
+
+			db, err := openDB(viper.GetString("home"))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			ctx.Logger.Info("Pruning application state", "strategy", fmt.Sprintf("%+v", pruning))
+			if err := store.PruneVersions(db, pruning); err != nil {
+				return err
+			}
+
+			if levelDB, ok := db.(*dbm.GoLevelDB); ok {
+				ctx.Logger.Info("Compacting database")
+				return levelDB.DB().CompactRange(util.Range{})
+			}
+			return nil
+		},
+	}
+	AddPruningFlags(cmd)
+	return cmd
+}
+
+// GetPruningStrategy returns the pruning strategy set by the pruning flags or
+// config: a named strategy, or a custom one keeping the given recent and
+// every n-th versions, pruned on the given interval.
+func GetPruningStrategy() (sdk.PruningStrategy, error) {
+	strategy := viper.GetString(flagPruning)
+	if strategy != pruningCustom {
+		return sdk.NewPruningStrategyFromString(strategy)
+	}
+
+	pruning := sdk.NewPruningStrategy(
+		viper.GetInt64(flagPruningKeepRecent),
+		viper.GetInt64(flagPruningKeepEvery),
+		viper.GetInt64(flagPruningInterval),
+	)
+	return pruning, pruning.Validate()
+}
+
+// AddPruningFlags adds the flags read by GetPruningStrategy to the command.
+func AddPruningFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything or custom")
+	cmd.Flags().Int64(flagPruningKeepRecent, 0, "Number of recent versions to keep with the custom pruning strategy")
+	cmd.Flags().Int64(flagPruningKeepEvery, 0, "Keep every n-th version with the custom pruning strategy, 0 keeps none of them")
+	cmd.Flags().Int64(flagPruningInterval, 10, "Number of blocks between pruning runs with the custom pruning strategy, 0 disables pruning")
+}
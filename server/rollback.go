@@ -0,0 +1,44 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/store"
+)
+
+const flagHeight = "height"
+
+// RollbackCmd reverts the application state of a stopped node to an earlier
+// height, e.g. to re-execute the blocks after a bad one with a fixed binary.
+func RollbackCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Roll back the application state of a stopped node to an earlier height",
+		Long: `Roll back the application state of a stopped node to an earlier height,
+the previous one by default, deleting the later versions of the state.
+
+The height must not have been pruned. Tendermint replays the blocks after that
+height when the node restarts.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDB(viper.GetString("home"))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			commitID, err := store.Rollback(db, viper.GetInt64(flagHeight))
+			if err != nil {
+				return err
+			}
+			ctx.Logger.Info("Rolled back application state", "height", commitID.Version)
+			fmt.Printf("Rolled back application state to height %d with hash %X\n", commitID.Version, commitID.Hash)
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagHeight, 0, "Height to roll back to, the previous one by default")
+	return cmd
+}
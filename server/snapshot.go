@@ -0,0 +1,118 @@
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/store"
+)
+
+// SnapshotsDir returns the directory the state snapshots of a node are kept
+// in. It is outside of the data directory so that the snapshots survive an
+// unsafe-reset-all.
+func SnapshotsDir(home string) string {
+	return filepath.Join(home, "snapshots")
+}
+
+// SnapshotsCmd groups the commands managing the state snapshots of the node
+// offline, i.e. while it is stopped.
+func SnapshotsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshots",
+		Short: "Manage the state snapshots of the node",
+	}
+	cmd.AddCommand(
+		listSnapshotsCmd(),
+		exportSnapshotCmd(),
+		restoreSnapshotCmd(),
+	)
+	return cmd
+}
+
+func listSnapshotsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the state snapshots, the most recent first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snapshots, err := store.NewSnapshotStore(SnapshotsDir(viper.GetString("home"))).List()
+			if err != nil {
+				return err
+			}
+			for _, snapshot := range snapshots {
+				fmt.Printf("height: %d\tformat: %d\tchunks: %d\thash: %X\n",
+					snapshot.Height, snapshot.Format, len(snapshot.Chunks), snapshot.Hash)
+			}
+			return nil
+		},
+	}
+}
+
+func exportSnapshotCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export [height]",
+		Short: "Snapshot the state committed at a height, the latest one by default",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var height int64
+			if len(args) == 1 {
+				var err error
+				if height, err = strconv.ParseInt(args[0], 10, 64); err != nil {
+					return err
+				}
+			}
+
+			home := viper.GetString("home")
+			db, err := openDB(home)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			snapshot, err := store.NewSnapshotStore(SnapshotsDir(home)).Create(
+				store.NewCommitMultiStore(db), height, store.DefaultSnapshotChunkSize)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Exported snapshot at height %d in %d chunks\n", snapshot.Height, len(snapshot.Chunks))
+			return nil
+		},
+	}
+}
+
+func restoreSnapshotCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <height>",
+		Short: "Restore the state of the node from a snapshot",
+		Long: `Restore the application state of a node without state from a snapshot.
+
+The chunks of the snapshot and the restored stores are checked against the
+hash of the snapshot. Tendermint must be brought to the same height as the
+application, e.g. from a copy of its data directory, before starting the node.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			home := viper.GetString("home")
+			db, err := openDB(home)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			rs := store.NewCommitMultiStore(db)
+			if err := store.NewSnapshotStore(SnapshotsDir(home)).Restore(rs, height); err != nil {
+				return err
+			}
+			fmt.Printf("Restored state at height %d with hash %X\n", height, rs.LastCommitID().Hash)
+			return nil
+		},
+	}
+}
@@ -1,11 +1,18 @@
 package server
 
 import (
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/tendermint/tendermint/abci/server"
+	abci "github.com/tendermint/tendermint/abci/types"
 
 	tcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
 	cmn "github.com/tendermint/tendermint/libs/common"
@@ -20,7 +27,24 @@ const (
 	flagAddress        = "address"
 	flagTraceStore     = "trace-store"
 	flagPruning        = "pruning"
-	flagMinimumFees    = "minimum_fees"
+	flagMinGasPrices   = "minimum-gas-prices"
+	flagFeeWeights     = "fee-weights"
+	flagMempoolSize    = "priority-mempool-size"
+
+	flagSnapshotInterval   = "snapshot-interval"
+	flagSnapshotKeepRecent = "snapshot-keep-recent"
+
+	flagStreamingFile   = "streaming-file"
+	flagStreamingStores = "streaming-stores"
+
+	flagInterBlockCacheSize   = "inter-block-cache-size"
+	flagInterBlockCacheStores = "inter-block-cache-stores"
+
+	flagHaltHeight = "halt-height"
+	flagHaltTime   = "halt-time"
+
+	flagTelemetry        = "telemetry"
+	flagTelemetryAddress = "telemetry-address"
 )
 
 // StartCmd runs the service passed in, either stand-alone or in-process with
@@ -46,8 +70,21 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	cmd.Flags().Bool(flagWithTendermint, true, "Run abci app embedded in-process with tendermint")
 	cmd.Flags().String(flagAddress, "tcp://0.0.0.0:26658", "Listen address")
 	cmd.Flags().String(flagTraceStore, "", "Enable KVStore tracing to an output file")
-	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything")
-	cmd.Flags().String(flagMinimumFees, "", "Minimum fees validator will accept for transactions")
+	cmd.Flags().String(flagMinGasPrices, "", "Minimum gas prices to accept for transactions; any fee in a tx must meet this minimum (e.g. 0.01photino,0.0001stake)")
+	cmd.Flags().String(flagFeeWeights, "", "Weights of the fee denominations when ordering transactions by gas price, e.g. 1steak,0.5photino")
+	cmd.Flags().Int(flagMempoolSize, 5000, "Maximum number of transactions kept in the priority mempool, 0 disables it")
+	cmd.Flags().Int64(flagSnapshotInterval, 0, "Number of heights between state snapshots, 0 disables them")
+	cmd.Flags().Int(flagSnapshotKeepRecent, 2, "Number of recent state snapshots to keep, 0 keeps them all")
+	cmd.Flags().String(flagStreamingFile, "", "Append the changes committed to the streaming stores at every block to a file")
+	cmd.Flags().StringSlice(flagStreamingStores, nil, "Names of the stores whose changes are streamed, e.g. acc,bank")
+	cmd.Flags().Int(flagInterBlockCacheSize, 0, "Number of values of each inter-block cache store kept cached across blocks, 0 disables the cache")
+	cmd.Flags().StringSlice(flagInterBlockCacheStores, nil, "Names of the stores whose values are cached across blocks, e.g. stake,params")
+	cmd.Flags().Int64(flagHaltHeight, 0, "Height of the last block to commit before shutting down the node, 0 disables it")
+	cmd.Flags().Int64(flagHaltTime, 0, "Time, in unix seconds, from which the node shuts down after committing a block, 0 disables it")
+	cmd.Flags().Bool(flagTelemetry, false, "Serve the application metrics to Prometheus")
+	cmd.Flags().String(flagTelemetryAddress, "0.0.0.0:26670", "Address the application metrics are served on")
+
+	AddPruningFlags(cmd)
 
 	// add support for all Tendermint-specific command line options
 	tcmd.AddNodeFlags(cmd)
@@ -68,6 +105,9 @@ func startStandAlone(ctx *Context, appCreator AppCreator) error {
 		return err
 	}
 
+	// listen for the signals before the app runs, as it signals itself when
+	// halting
+	quit := notifyQuitSignals()
 	app := appCreator(ctx.Logger, db, traceWriter)
 
 	svr, err := server.NewServer(addr, "socket", app)
@@ -82,14 +122,16 @@ func startStandAlone(ctx *Context, appCreator AppCreator) error {
 		cmn.Exit(err.Error())
 	}
 
-	// wait forever
-	cmn.TrapSignal(func() {
-		// cleanup
-		err = svr.Stop()
-		if err != nil {
-			cmn.Exit(err.Error())
-		}
-	})
+	telemetrySvr := startTelemetry(ctx, app)
+
+	// run until interrupted, then clean up
+	ctx.Logger.Info("Stopping the server", "signal", <-quit)
+	stopTelemetry(ctx, telemetrySvr)
+	err = svr.Stop()
+	if err != nil {
+		cmn.Exit(err.Error())
+	}
+	db.Close()
 	return nil
 }
 
@@ -108,6 +150,9 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 		return nil, err
 	}
 
+	// listen for the signals before the app runs, as it signals itself when
+	// halting
+	quit := notifyQuitSignals()
 	app := appCreator(ctx.Logger, db, traceWriter)
 
 	nodeKey, err := p2p.LoadOrGenNodeKey(cfg.NodeKeyFile())
@@ -135,7 +180,61 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 		return nil, err
 	}
 
-	// trap signal (run forever)
-	tmNode.RunForever()
+	telemetrySvr := startTelemetry(ctx, app)
+
+	// run until interrupted, e.g. by the app halting, then stop the node and
+	// close the application database for other commands, e.g. export, to open
+	ctx.Logger.Info("Stopping the node", "signal", <-quit)
+	stopTelemetry(ctx, telemetrySvr)
+	err = tmNode.Stop()
+	if err != nil {
+		return nil, err
+	}
+	db.Close()
+	ctx.Logger.Info("Node stopped")
 	return tmNode, nil
 }
+
+// startTelemetry serves the metrics of the Prometheus registry of the app, if
+// telemetry is enabled. It returns the server, nil if it's disabled or the app
+// records no metrics.
+func startTelemetry(ctx *Context, app abci.Application) *http.Server {
+	if !viper.GetBool(flagTelemetry) {
+		return nil
+	}
+	instrumented, ok := app.(InstrumentedApp)
+	if !ok || instrumented.MetricsRegistry() == nil {
+		ctx.Logger.Error("Telemetry is enabled but the application records no metrics")
+		return nil
+	}
+	addr := viper.GetString(flagTelemetryAddress)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(instrumented.MetricsRegistry(), promhttp.HandlerOpts{}))
+	svr := &http.Server{Addr: addr, Handler: mux}
+
+	go func() {
+		if err := svr.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			ctx.Logger.Error("Telemetry server failed", "err", err)
+		}
+	}()
+	ctx.Logger.Info("Serving the application metrics", "address", addr)
+	return svr
+}
+
+// stopTelemetry stops the telemetry server, if any.
+func stopTelemetry(ctx *Context, svr *http.Server) {
+	if svr == nil {
+		return
+	}
+	if err := svr.Close(); err != nil {
+		ctx.Logger.Error("Failed to stop the telemetry server", "err", err)
+	}
+}
+
+// notifyQuitSignals returns a channel receiving the signals interrupting or
+// terminating the process, instead of them exiting it.
+func notifyQuitSignals() <-chan os.Signal {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	return quit
+}
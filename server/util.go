@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"net"
 	"os"
 	"path/filepath"
@@ -102,6 +103,9 @@ func interceptLoadConfig() (conf *cfg.Config, err error) {
 	cosmosConfigFilePath := filepath.Join(rootDir, "config/gaiad.toml")
 	viper.SetConfigName("cosmos")
 	_ = viper.MergeInConfig()
+	if err = migrateMinimumFees(); err != nil {
+		return
+	}
 	var cosmosConf *config.Config
 	if _, err := os.Stat(cosmosConfigFilePath); os.IsNotExist(err) {
 		cosmosConf, _ := config.ParseConfig()
@@ -115,6 +119,22 @@ func interceptLoadConfig() (conf *cfg.Config, err error) {
 	return
 }
 
+// Converts a deprecated minimum_fees setting into minimum-gas-prices, unless
+// the latter is set as well.
+func migrateMinimumFees() error {
+	minFees := viper.GetString("minimum_fees")
+	if minFees == "" || viper.GetString("minimum-gas-prices") != "" {
+		return nil
+	}
+	gasPrices, err := config.MigrateMinimumFees(minFees)
+	if err != nil {
+		return errors.Wrap(err, "invalid minimum_fees")
+	}
+	fmt.Fprintf(os.Stderr, "WARNING: minimum_fees is deprecated, using minimum-gas-prices = %q instead\n", gasPrices)
+	viper.Set("minimum-gas-prices", gasPrices.String())
+	return nil
+}
+
 // validate the config with the sdk's requirements.
 func validateConfig(conf *cfg.Config) error {
 	if conf.Consensus.CreateEmptyBlocks == false {
@@ -148,6 +168,9 @@ func AddCommands(
 		client.LineBreak,
 		tendermintCmd,
 		ExportCmd(ctx, cdc, appExport),
+		SnapshotsCmd(),
+		PruneCmd(ctx),
+		RollbackCmd(ctx),
 		client.LineBreak,
 		version.VersionCmd,
 	)
@@ -22,6 +22,10 @@ type cacheKVStore struct {
 	mtx    sync.Mutex
 	cache  map[string]cValue
 	parent KVStore
+
+	// notified of the changes written to parent, the store of storeKey
+	storeKey  StoreKey
+	listeners []WriteListener
 }
 
 var _ CacheKVStore = (*cacheKVStore)(nil)
@@ -34,6 +38,15 @@ func NewCacheKVStore(parent KVStore) *cacheKVStore {
 	}
 }
 
+// newListenCacheKVStore returns a cacheKVStore notifying the listeners of
+// the changes it writes to parent, the store of storeKey.
+func newListenCacheKVStore(parent KVStore, storeKey StoreKey, listeners []WriteListener) *cacheKVStore {
+	store := NewCacheKVStore(parent)
+	store.storeKey = storeKey
+	store.listeners = listeners
+	return store
+}
+
 // Implements Store.
 func (ci *cacheKVStore) GetStoreType() StoreType {
 	return ci.parent.GetStoreType()
@@ -112,10 +125,12 @@ func (ci *cacheKVStore) Write() {
 		cacheValue := ci.cache[key]
 		if cacheValue.deleted {
 			ci.parent.Delete([]byte(key))
+			ci.notify([]byte(key), nil, true)
 		} else if cacheValue.value == nil {
 			// Skip, it already doesn't exist in parent.
 		} else {
 			ci.parent.Set([]byte(key), cacheValue.value)
+			ci.notify([]byte(key), cacheValue.value, false)
 		}
 	}
 
@@ -123,6 +138,12 @@ func (ci *cacheKVStore) Write() {
 	ci.cache = make(map[string]cValue)
 }
 
+func (ci *cacheKVStore) notify(key []byte, value []byte, delete bool) {
+	for _, listener := range ci.listeners {
+		listener.OnWrite(ci.storeKey, key, value, delete)
+	}
+}
+
 //----------------------------------------
 // To cache-wrap this cacheKVStore further.
 
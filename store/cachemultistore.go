@@ -18,6 +18,9 @@ type cacheMultiStore struct {
 
 	traceWriter  io.Writer
 	traceContext TraceContext
+
+	// metrics of the accesses to the stores, may be nil
+	metrics *Metrics
 }
 
 var _ CacheMultiStore = cacheMultiStore{}
@@ -29,10 +32,17 @@ func newCacheMultiStoreFromRMS(rms *rootMultiStore) cacheMultiStore {
 		keysByName:   rms.keysByName,
 		traceWriter:  rms.traceWriter,
 		traceContext: rms.traceContext,
+		metrics:      rms.metrics,
 	}
 
 	for key, store := range rms.stores {
-		if cms.TracingEnabled() {
+		if listeners := rms.listeners[key.Name()]; len(listeners) > 0 {
+			var parent KVStore = store.(KVStore)
+			if cms.TracingEnabled() {
+				parent = NewTraceKVStore(parent, cms.traceWriter, cms.traceContext)
+			}
+			cms.stores[key] = newListenCacheKVStore(parent, key, listeners)
+		} else if cms.TracingEnabled() {
 			cms.stores[key] = store.CacheWrapWithTrace(cms.traceWriter, cms.traceContext)
 		} else {
 			cms.stores[key] = store.CacheWrap()
@@ -48,6 +58,7 @@ func newCacheMultiStoreFromCMS(cms cacheMultiStore) cacheMultiStore {
 		stores:       make(map[StoreKey]CacheWrap, len(cms.stores)),
 		traceWriter:  cms.traceWriter,
 		traceContext: cms.traceContext,
+		metrics:      cms.metrics,
 	}
 
 	for key, store := range cms.stores {
@@ -128,9 +139,14 @@ func (cms cacheMultiStore) GetStore(key StoreKey) Store {
 	return cms.stores[key].(Store)
 }
 
-// Implements MultiStore.
+// GetKVStore implements the MultiStore interface. If metrics are enabled, the
+// gas stores of the returned store count their accesses under its name.
 func (cms cacheMultiStore) GetKVStore(key StoreKey) KVStore {
-	return cms.stores[key].(KVStore)
+	store := cms.stores[key].(KVStore)
+	if cms.metrics != nil {
+		return metricsKVStore{store, key.Name(), cms.metrics}
+	}
+	return store
 }
 
 // Implements MultiStore.
@@ -8,6 +8,8 @@ import (
 // nolint
 type (
 	PruningStrategy  = types.PruningStrategy
+	StoreUpgrades    = types.StoreUpgrades
+	StoreRename      = types.StoreRename
 	Store            = types.Store
 	Committer        = types.Committer
 	CommitStore      = types.CommitStore
@@ -3,6 +3,8 @@ package store
 import (
 	"io"
 
+	"github.com/go-kit/kit/metrics"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -14,6 +16,10 @@ type gasKVStore struct {
 	gasMeter  sdk.GasMeter
 	gasConfig sdk.GasConfig
 	parent    sdk.KVStore
+
+	// metrics counting the accesses under the store name, may be nil
+	storeName string
+	metrics   *Metrics
 }
 
 // NewGasKVStore returns a reference to a new GasKVStore.
@@ -34,6 +40,7 @@ func (gs *gasKVStore) GetStoreType() sdk.StoreType {
 
 // Implements KVStore.
 func (gs *gasKVStore) Get(key []byte) (value []byte) {
+	gs.countAccess("read")
 	gs.gasMeter.ConsumeGas(gs.gasConfig.ReadCostFlat, sdk.GasReadCostFlatDesc)
 	value = gs.parent.Get(key)
 
@@ -45,6 +52,7 @@ func (gs *gasKVStore) Get(key []byte) (value []byte) {
 
 // Implements KVStore.
 func (gs *gasKVStore) Set(key []byte, value []byte) {
+	gs.countAccess("write")
 	gs.gasMeter.ConsumeGas(gs.gasConfig.WriteCostFlat, sdk.GasWriteCostFlatDesc)
 	// TODO overflow-safe math?
 	gs.gasMeter.ConsumeGas(gs.gasConfig.WriteCostPerByte*sdk.Gas(len(value)), sdk.GasWritePerByteDesc)
@@ -53,12 +61,14 @@ func (gs *gasKVStore) Set(key []byte, value []byte) {
 
 // Implements KVStore.
 func (gs *gasKVStore) Has(key []byte) bool {
+	gs.countAccess("read")
 	gs.gasMeter.ConsumeGas(gs.gasConfig.HasCost, sdk.GasHasDesc)
 	return gs.parent.Has(key)
 }
 
 // Implements KVStore.
 func (gs *gasKVStore) Delete(key []byte) {
+	gs.countAccess("write")
 	// charge gas to prevent certain attack vectors even though space is being freed
 	gs.gasMeter.ConsumeGas(gs.gasConfig.DeleteCost, sdk.GasDeleteDesc)
 	gs.parent.Delete(key)
@@ -71,6 +81,8 @@ func (gs *gasKVStore) Prefix(prefix []byte) KVStore {
 		gasMeter:  gs.gasMeter,
 		gasConfig: gs.gasConfig,
 		parent:    prefixStore{gs.parent, prefix},
+		storeName: gs.storeName,
+		metrics:   gs.metrics,
 	}
 }
 
@@ -105,6 +117,7 @@ func (gs *gasKVStore) CacheWrapWithTrace(_ io.Writer, _ TraceContext) CacheWrap
 }
 
 func (gs *gasKVStore) iterator(start, end []byte, ascending bool) sdk.Iterator {
+	gs.countAccess("iterate")
 	var parent sdk.Iterator
 	if ascending {
 		parent = gs.parent.Iterator(start, end)
@@ -120,6 +133,24 @@ func (gs *gasKVStore) iterator(start, end []byte, ascending bool) sdk.Iterator {
 	return gi
 }
 
+// countAccess counts a read, write or iteration of the store in the store
+// metrics, if any.
+func (gs *gasKVStore) countAccess(access string) {
+	if gs.metrics == nil {
+		return
+	}
+	var counter metrics.Counter
+	switch access {
+	case "read":
+		counter = gs.metrics.Reads
+	case "write":
+		counter = gs.metrics.Writes
+	default:
+		counter = gs.metrics.Iterations
+	}
+	counter.With("store", gs.storeName).Add(1)
+}
+
 type gasIterator struct {
 	gasMeter  sdk.GasMeter
 	gasConfig sdk.GasConfig
@@ -3,7 +3,6 @@ package store
 import (
 	"fmt"
 	"io"
-	"sync"
 
 	"github.com/tendermint/go-amino"
 	"github.com/tendermint/iavl"
@@ -42,26 +41,27 @@ type iavlStore struct {
 	// The underlying tree.
 	tree *iavl.MutableTree
 
-	// How many old versions we hold onto.
-	// A value of 0 means keep no recent states.
-	numRecent int64
-
-	// This is the distance between state-sync waypoint states to be stored.
+	// Which old versions we hold onto, and when the others are released.
+	// The KeepEvery-th versions are state-sync waypoint states.
 	// See https://github.com/tendermint/tendermint/issues/828
-	// A value of 1 means store every state.
-	// A value of 0 means store no waypoints. (node cannot assist in state-sync)
 	// By default this value should be set the same across all nodes,
 	// so that nodes can know the waypoints their peers store.
-	storeEvery int64
+	pruning sdk.PruningStrategy
+
+	// The last version released by pruning.
+	lastPruned int64
+
+	// The versions not to release while pinned, may be nil.
+	pins *versionPins
 }
 
 // CONTRACT: tree should be fully loaded.
+// The versions not kept are released on every commit.
 // nolint: unparam
 func newIAVLStore(tree *iavl.MutableTree, numRecent int64, storeEvery int64) *iavlStore {
 	st := &iavlStore{
-		tree:       tree,
-		numRecent:  numRecent,
-		storeEvery: storeEvery,
+		tree:    tree,
+		pruning: sdk.NewPruningStrategy(numRecent, storeEvery, 1),
 	}
 	return st
 }
@@ -75,16 +75,9 @@ func (st *iavlStore) Commit() CommitID {
 		panic(err)
 	}
 
-	// Release an old version of history, if not a sync waypoint.
-	previous := version - 1
-	if st.numRecent < previous {
-		toRelease := previous - st.numRecent
-		if st.storeEvery == 0 || toRelease%st.storeEvery != 0 {
-			err := st.tree.DeleteVersion(toRelease)
-			if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
-				panic(err)
-			}
-		}
+	// Release the old versions of history every pruning interval.
+	if st.pruning.Interval > 0 && version%st.pruning.Interval == 0 {
+		st.prune(version)
 	}
 
 	return CommitID{
@@ -93,6 +86,29 @@ func (st *iavlStore) Commit() CommitID {
 	}
 }
 
+// Releases the versions older than the recent ones kept as of the given
+// version, which aren't sync waypoints and weren't released yet. Pruning
+// stops at a pinned version, and resumes from it once unpinned.
+func (st *iavlStore) prune(version int64) {
+	toRelease := version - 1 - st.pruning.KeepRecent
+	for ver := st.lastPruned + 1; ver <= toRelease; ver++ {
+		if st.pins.isPinned(ver) {
+			toRelease = ver - 1
+			break
+		}
+		if st.pruning.KeepVersion(ver) || !st.tree.VersionExists(ver) {
+			continue
+		}
+		err := st.tree.DeleteVersion(ver)
+		if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
+			panic(err)
+		}
+	}
+	if toRelease > st.lastPruned {
+		st.lastPruned = toRelease
+	}
+}
+
 // Implements Committer.
 func (st *iavlStore) LastCommitID() CommitID {
 	return CommitID{
@@ -103,16 +119,7 @@ func (st *iavlStore) LastCommitID() CommitID {
 
 // Implements Committer.
 func (st *iavlStore) SetPruning(pruning sdk.PruningStrategy) {
-	switch pruning {
-	case sdk.PruneEverything:
-		st.numRecent = 0
-		st.storeEvery = 0
-	case sdk.PruneNothing:
-		st.storeEvery = 1
-	case sdk.PruneSyncable:
-		st.numRecent = 100
-		st.storeEvery = 10000
-	}
+	st.pruning = pruning
 }
 
 // VersionExists returns whether or not a given version is stored.
@@ -253,6 +260,14 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 
 //----------------------------------------
 
+// Bounds of the number of pairs an iavlIterator loads at once. Iterators are
+// often used to read a single pair, so the batches start small and double as
+// the iteration goes on.
+const (
+	iavlIteratorMinBatch = 8
+	iavlIteratorMaxBatch = 1024
+)
+
 // Implements Iterator.
 type iavlIterator struct {
 	// Underlying store
@@ -264,66 +279,36 @@ type iavlIterator struct {
 	// Iteration order
 	ascending bool
 
-	// Channel to push iteration values.
-	iterCh chan cmn.KVPair
-
-	// Close this to release goroutine.
-	quitCh chan struct{}
+	// Range of the tree not loaded yet, empty once exhausted.
+	nextStart, nextEnd []byte
+	exhausted          bool
 
-	// Close this to signal that state is initialized.
-	initCh chan struct{}
-
-	//----------------------------------------
-	// What follows are mutable state.
-	mtx sync.Mutex
-
-	invalid bool   // True once, true forever
-	key     []byte // The current key
-	value   []byte // The current value
+	// Pairs loaded from the tree, the current one at index.
+	batch     []cmn.KVPair
+	batchSize int
+	index     int
 }
 
 var _ Iterator = (*iavlIterator)(nil)
 
-// newIAVLIterator will create a new iavlIterator.
-// CONTRACT: Caller must release the iavlIterator, as each one creates a new
-// goroutine.
+// newIAVLIterator will create a new iavlIterator. The pairs are loaded from
+// the tree in batches as the iterator moves, walking the tree as of its
+// creation.
 func newIAVLIterator(tree *iavl.ImmutableTree, start, end []byte, ascending bool) *iavlIterator {
+	snapshot := *tree
 	iter := &iavlIterator{
-		tree:      tree,
+		tree:      &snapshot,
 		start:     cp(start),
 		end:       cp(end),
 		ascending: ascending,
-		iterCh:    make(chan cmn.KVPair, 0), // Set capacity > 0?
-		quitCh:    make(chan struct{}),
-		initCh:    make(chan struct{}),
+		nextStart: cp(start),
+		nextEnd:   cp(end),
+		batchSize: iavlIteratorMinBatch,
 	}
-	go iter.iterateRoutine()
-	go iter.initRoutine()
+	iter.loadBatch()
 	return iter
 }
 
-// Run this to funnel items from the tree to iterCh.
-func (iter *iavlIterator) iterateRoutine() {
-	iter.tree.IterateRange(
-		iter.start, iter.end, iter.ascending,
-		func(key, value []byte) bool {
-			select {
-			case <-iter.quitCh:
-				return true // done with iteration.
-			case iter.iterCh <- cmn.KVPair{Key: key, Value: value}:
-				return false // yay.
-			}
-		},
-	)
-	close(iter.iterCh) // done.
-}
-
-// Run this to fetch the first item.
-func (iter *iavlIterator) initRoutine() {
-	iter.receiveNext()
-	close(iter.initCh)
-}
-
 // Implements Iterator.
 func (iter *iavlIterator) Domain() (start, end []byte) {
 	return iter.start, iter.end
@@ -331,87 +316,78 @@ func (iter *iavlIterator) Domain() (start, end []byte) {
 
 // Implements Iterator.
 func (iter *iavlIterator) Valid() bool {
-	iter.waitInit()
-	iter.mtx.Lock()
-
-	validity := !iter.invalid
-	iter.mtx.Unlock()
-	return validity
+	return iter.index < len(iter.batch)
 }
 
 // Implements Iterator.
 func (iter *iavlIterator) Next() {
-	iter.waitInit()
-	iter.mtx.Lock()
-	iter.assertIsValid(true)
+	iter.assertIsValid()
 
-	iter.receiveNext()
-	iter.mtx.Unlock()
+	iter.index++
+	if iter.index == len(iter.batch) {
+		iter.loadBatch()
+	}
 }
 
 // Implements Iterator.
 func (iter *iavlIterator) Key() []byte {
-	iter.waitInit()
-	iter.mtx.Lock()
-	iter.assertIsValid(true)
+	iter.assertIsValid()
 
-	key := iter.key
-	iter.mtx.Unlock()
-	return key
+	return iter.batch[iter.index].Key
 }
 
 // Implements Iterator.
 func (iter *iavlIterator) Value() []byte {
-	iter.waitInit()
-	iter.mtx.Lock()
-	iter.assertIsValid(true)
+	iter.assertIsValid()
 
-	val := iter.value
-	iter.mtx.Unlock()
-	return val
+	return iter.batch[iter.index].Value
 }
 
 // Implements Iterator.
 func (iter *iavlIterator) Close() {
-	close(iter.quitCh)
+	iter.batch, iter.index = nil, 0
+	iter.exhausted = true
 }
 
 //----------------------------------------
 
-func (iter *iavlIterator) setNext(key, value []byte) {
-	iter.assertIsValid(false)
-
-	iter.key = key
-	iter.value = value
-}
-
-func (iter *iavlIterator) setInvalid() {
-	iter.assertIsValid(false)
-
-	iter.invalid = true
-}
+// loadBatch replaces the batch with the next pairs of the tree, and narrows
+// the range left to load past the last one.
+func (iter *iavlIterator) loadBatch() {
+	iter.batch, iter.index = iter.batch[:0], 0
+	if iter.exhausted {
+		return
+	}
 
-func (iter *iavlIterator) waitInit() {
-	<-iter.initCh
-}
+	limit := iter.batchSize
+	iter.tree.IterateRange(
+		iter.nextStart, iter.nextEnd, iter.ascending,
+		func(key, value []byte) bool {
+			iter.batch = append(iter.batch, cmn.KVPair{Key: key, Value: value})
+			return len(iter.batch) == limit
+		},
+	)
 
-func (iter *iavlIterator) receiveNext() {
-	kvPair, ok := <-iter.iterCh
-	if ok {
-		iter.setNext(kvPair.Key, kvPair.Value)
+	if len(iter.batch) < limit {
+		iter.exhausted = true
+		return
+	}
+	last := iter.batch[len(iter.batch)-1].Key
+	if iter.ascending {
+		// the smallest key greater than the last one
+		iter.nextStart = append(cp(last), 0)
 	} else {
-		iter.setInvalid()
+		// the end of the range is exclusive
+		iter.nextEnd = cp(last)
+	}
+	if iter.batchSize < iavlIteratorMaxBatch {
+		iter.batchSize *= 2
 	}
 }
 
-// assertIsValid panics if the iterator is invalid. If unlockMutex is true,
-// it also unlocks the mutex before panicing, to prevent deadlocks in code that
-// recovers from panics
-func (iter *iavlIterator) assertIsValid(unlockMutex bool) {
-	if iter.invalid {
-		if unlockMutex {
-			iter.mtx.Unlock()
-		}
+// assertIsValid panics if the iterator is invalid.
+func (iter *iavlIterator) assertIsValid() {
+	if !iter.Valid() {
 		panic("invalid iterator")
 	}
 }
@@ -2,6 +2,7 @@ package store
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -375,6 +376,33 @@ func TestIAVLPruneEverything(t *testing.T) {
 	}
 }
 
+func TestIAVLPruningInterval(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+	// keep the last 2 versions and every 3rd, pruning every 4 commits
+	iavlStore.SetPruning(sdk.NewPruningStrategy(2, 3, 4))
+
+	for i := 0; i < 7; i++ {
+		nextVersion(iavlStore)
+	}
+	// versions released at 4 were pruned, those released since weren't yet
+	for _, ver := range []int64{3, 4, 5, 6, 7} {
+		require.True(t, iavlStore.VersionExists(ver), "missing version %d", ver)
+	}
+	for _, ver := range []int64{1} {
+		require.False(t, iavlStore.VersionExists(ver), "unpruned version %d", ver)
+	}
+
+	nextVersion(iavlStore)
+	for _, ver := range []int64{3, 6, 7, 8} {
+		require.True(t, iavlStore.VersionExists(ver), "missing version %d", ver)
+	}
+	for _, ver := range []int64{1, 2, 4, 5} {
+		require.False(t, iavlStore.VersionExists(ver), "unpruned version %d", ver)
+	}
+}
+
 func TestIAVLStoreQuery(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree := iavl.NewMutableTree(db, cacheSize)
@@ -465,25 +493,197 @@ func TestIAVLStoreQuery(t *testing.T) {
 	require.Equal(t, v1, qres.Value)
 }
 
-func BenchmarkIAVLIteratorNext(b *testing.B) {
+func TestIAVLIteratorBatches(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	for i := 0; i < 3000; i++ {
+		tree.Set([]byte(fmt.Sprintf("key%05d", i)), []byte(fmt.Sprintf("value%d", i)))
+	}
+	_, _, err := tree.SaveVersion()
+	require.Nil(t, err)
+
+	domains := [][2][]byte{
+		{nil, nil},
+		{[]byte("key00100"), []byte("key02900")},
+		{[]byte("key001"), nil},
+		{nil, []byte("key00042")},
+		{[]byte("key1"), []byte("key1")},
+	}
+	for _, domain := range domains {
+		for _, ascending := range []bool{true, false} {
+			var expected []cmn.KVPair
+			tree.IterateRange(domain[0], domain[1], ascending, func(key, value []byte) bool {
+				expected = append(expected, cmn.KVPair{Key: key, Value: value})
+				return false
+			})
+
+			iter := newIAVLIterator(tree.ImmutableTree, domain[0], domain[1], ascending)
+			start, end := iter.Domain()
+			require.Equal(t, domain[0], start)
+			require.Equal(t, domain[1], end)
+			var got []cmn.KVPair
+			for ; iter.Valid(); iter.Next() {
+				got = append(got, cmn.KVPair{Key: iter.Key(), Value: iter.Value()})
+			}
+			require.Equal(t, expected, got, "domain %q ascending %v", domain, ascending)
+			require.Panics(t, func() { iter.Next() })
+			require.Panics(t, func() { iter.Key() })
+			iter.Close()
+		}
+	}
+
+	// the iterator walks the tree as of its creation
+	iter := newIAVLIterator(tree.ImmutableTree, nil, nil, true)
+	tree.Set([]byte("key00000a"), []byte("new"))
+	tree.Remove([]byte("key00001"))
+	iter.Next()
+	require.Equal(t, []byte("key00001"), iter.Key())
+	iter.Close()
+	require.False(t, iter.Valid())
+}
+
+func newBenchmarkIAVLStore(treeSize int) *iavlStore {
 	db := dbm.NewMemDB()
-	treeSize := 1000
 	tree := iavl.NewMutableTree(db, cacheSize)
 	for i := 0; i < treeSize; i++ {
 		key := cmn.RandBytes(4)
 		value := cmn.RandBytes(50)
 		tree.Set(key, value)
 	}
-	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
-	iterators := make([]Iterator, b.N/treeSize)
-	for i := 0; i < len(iterators); i++ {
-		iterators[i] = iavlStore.Iterator([]byte{0}, []byte{255, 255, 255, 255, 255})
-	}
-	b.ResetTimer()
-	for i := 0; i < len(iterators); i++ {
-		iter := iterators[i]
-		for j := 0; j < treeSize; j++ {
-			iter.Next()
-		}
+	return newIAVLStore(tree, numRecent, storeEvery)
+}
+
+// The iterator constructors benchmarked against each other.
+var benchmarkIAVLIterators = []struct {
+	name string
+	new  func(tree *iavl.ImmutableTree, start, end []byte) Iterator
+}{
+	{"sync", func(tree *iavl.ImmutableTree, start, end []byte) Iterator {
+		return newIAVLIterator(tree, start, end, true)
+	}},
+	{"channel", func(tree *iavl.ImmutableTree, start, end []byte) Iterator {
+		return newChannelIAVLIterator(tree, start, end)
+	}},
+}
+
+func BenchmarkIAVLIteratorNext(b *testing.B) {
+	treeSize := 1000
+	iavlStore := newBenchmarkIAVLStore(treeSize)
+	for _, bench := range benchmarkIAVLIterators {
+		b.Run(bench.name, func(b *testing.B) {
+			iterators := make([]Iterator, b.N/treeSize)
+			for i := 0; i < len(iterators); i++ {
+				iterators[i] = bench.new(iavlStore.tree.ImmutableTree, []byte{0}, []byte{255, 255, 255, 255, 255})
+			}
+			b.ResetTimer()
+			for i := 0; i < len(iterators); i++ {
+				iter := iterators[i]
+				for j := 0; j < treeSize; j++ {
+					iter.Next()
+				}
+				iter.Close()
+			}
+		})
+	}
+}
+
+// Creating an iterator to read a few pairs, as when reading a prefix.
+func BenchmarkIAVLIteratorFirst(b *testing.B) {
+	iavlStore := newBenchmarkIAVLStore(1000)
+	for _, bench := range benchmarkIAVLIterators {
+		b.Run(bench.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				start := []byte{byte(i)}
+				iter := bench.new(iavlStore.tree.ImmutableTree, start, []byte{byte(i), 255})
+				for j := 0; j < 3 && iter.Valid(); j++ {
+					iter.Next()
+				}
+				iter.Close()
+			}
+		})
+	}
+}
+
+// channelIAVLIterator is the former iavlIterator, streaming the pairs of the
+// tree from a goroutine over a channel, kept to benchmark iavlIterator.
+type channelIAVLIterator struct {
+	start, end []byte
+	iterCh     chan cmn.KVPair
+	quitCh     chan struct{}
+	initCh     chan struct{}
+
+	mtx     sync.Mutex
+	invalid bool
+	key     []byte
+	value   []byte
+}
+
+func newChannelIAVLIterator(tree *iavl.ImmutableTree, start, end []byte) *channelIAVLIterator {
+	iter := &channelIAVLIterator{
+		start:  cp(start),
+		end:    cp(end),
+		iterCh: make(chan cmn.KVPair),
+		quitCh: make(chan struct{}),
+		initCh: make(chan struct{}),
+	}
+	go func() {
+		tree.IterateRange(iter.start, iter.end, true, func(key, value []byte) bool {
+			select {
+			case <-iter.quitCh:
+				return true
+			case iter.iterCh <- cmn.KVPair{Key: key, Value: value}:
+				return false
+			}
+		})
+		close(iter.iterCh)
+	}()
+	go func() {
+		iter.receiveNext()
+		close(iter.initCh)
+	}()
+	return iter
+}
+
+func (iter *channelIAVLIterator) Domain() (start, end []byte) { return iter.start, iter.end }
+
+func (iter *channelIAVLIterator) Valid() bool {
+	<-iter.initCh
+	iter.mtx.Lock()
+	defer iter.mtx.Unlock()
+	return !iter.invalid
+}
+
+func (iter *channelIAVLIterator) Next() {
+	<-iter.initCh
+	iter.mtx.Lock()
+	defer iter.mtx.Unlock()
+	if iter.invalid {
+		panic("invalid iterator")
+	}
+	iter.receiveNext()
+}
+
+func (iter *channelIAVLIterator) Key() []byte {
+	<-iter.initCh
+	iter.mtx.Lock()
+	defer iter.mtx.Unlock()
+	return iter.key
+}
+
+func (iter *channelIAVLIterator) Value() []byte {
+	<-iter.initCh
+	iter.mtx.Lock()
+	defer iter.mtx.Unlock()
+	return iter.value
+}
+
+func (iter *channelIAVLIterator) Close() { close(iter.quitCh) }
+
+func (iter *channelIAVLIterator) receiveNext() {
+	kvPair, ok := <-iter.iterCh
+	if ok {
+		iter.key, iter.value = kvPair.Key, kvPair.Value
+	} else {
+		iter.invalid = true
 	}
 }
@@ -0,0 +1,189 @@
+package store
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Index is a secondary index of an IndexedMap, from index keys computed
+// from the values to the keys of the values.
+type Index struct {
+	name     string
+	keys     KeyEncoder
+	indexKey func(value interface{}) (indexKey interface{}, ok bool)
+}
+
+// NewIndex returns an index of the given name whose index keys, encoded by
+// keys, are computed from the values by indexKey. The values for which
+// indexKey returns false are left out of the index.
+func NewIndex(name string, keys KeyEncoder, indexKey func(value interface{}) (interface{}, bool)) Index {
+	return Index{
+		name:     name,
+		keys:     keys,
+		indexKey: indexKey,
+	}
+}
+
+// IndexedMap is a Map kept along with secondary indexes, which are updated
+// on Set and Delete. The values are stored under the 0x00 prefix, and the
+// entries of the n-th index under the 0x01+n prefix, keyed by the index key
+// followed by the key of the value.
+// It panics when a value cannot be (un/)marshalled by the codec.
+type IndexedMap struct {
+	primary   Map
+	store     sdk.KVStore
+	valueType reflect.Type
+	indexes   []Index
+}
+
+// NewIndexedMap constructs a new IndexedMap of the values under prefix in
+// the store, of the type of proto, with the given indexes.
+func NewIndexedMap(cdc *codec.Codec, store sdk.KVStore, prefix []byte, keys KeyEncoder,
+	proto interface{}, indexes ...Index) IndexedMap {
+
+	if len(indexes) > 0xFE {
+		panic("too many indexes")
+	}
+	names := make(map[string]bool)
+	for _, index := range indexes {
+		if names[index.name] {
+			panic(fmt.Sprintf("duplicate index %s", index.name))
+		}
+		names[index.name] = true
+	}
+	store = store.Prefix(prefix)
+	return IndexedMap{
+		primary:   NewMap(cdc, store, []byte{0x00}, keys),
+		store:     store,
+		valueType: reflect.TypeOf(proto),
+		indexes:   indexes,
+	}
+}
+
+// Has returns whether a value is set for the key.
+func (m IndexedMap) Has(key interface{}) bool {
+	return m.primary.Has(key)
+}
+
+// Get unmarshals the value of the key into ptr, and returns false if no
+// value is set for the key.
+func (m IndexedMap) Get(key interface{}, ptr interface{}) bool {
+	return m.primary.Get(key, ptr)
+}
+
+// Set sets the value of the key, replacing the index entries of its
+// previous value.
+func (m IndexedMap) Set(key interface{}, value interface{}) {
+	keyBz := m.primary.keys.Encode(key)
+	if old, ok := m.get(key); ok {
+		m.deleteIndexEntries(keyBz, old)
+	}
+	m.primary.Set(key, value)
+	for i, index := range m.indexes {
+		if indexKey, ok := index.indexKey(value); ok {
+			m.store.Set(m.indexEntryKey(i, indexKey, keyBz), []byte{})
+		}
+	}
+}
+
+// Delete deletes the value of the key and its index entries.
+func (m IndexedMap) Delete(key interface{}) {
+	old, ok := m.get(key)
+	if !ok {
+		return
+	}
+	m.deleteIndexEntries(m.primary.keys.Encode(key), old)
+	m.primary.Delete(key)
+}
+
+// Iterate calls fn with each key, in order, after unmarshalling its value
+// into ptr. Return true in fn to stop the iteration.
+//
+// CONTRACT: No writes may happen within a domain while iterating over it.
+func (m IndexedMap) Iterate(ptr interface{}, fn func(key interface{}) (stop bool)) {
+	m.primary.Iterate(ptr, fn)
+}
+
+// IterateRange is Map.IterateRange over the values.
+func (m IndexedMap) IterateRange(start, end interface{}, reverse bool, ptr interface{}, fn func(key interface{}) (stop bool)) {
+	m.primary.IterateRange(start, end, reverse, ptr, fn)
+}
+
+// IteratePrefix is Map.IteratePrefix over the values.
+func (m IndexedMap) IteratePrefix(prefix []byte, ptr interface{}, fn func(key interface{}) (stop bool)) {
+	m.primary.IteratePrefix(prefix, ptr, fn)
+}
+
+// Paginate is Map.Paginate over the values.
+func (m IndexedMap) Paginate(start interface{}, limit int, ptr interface{}, fn func(key interface{})) interface{} {
+	return m.primary.Paginate(start, limit, ptr, fn)
+}
+
+// IterateIndex calls fn with the keys of the values whose index key in the
+// named index is indexKey, in order. Return true in fn to stop the
+// iteration.
+func (m IndexedMap) IterateIndex(name string, indexKey interface{}, fn func(key interface{}) (stop bool)) {
+	i := m.indexPosition(name)
+	m.IterateIndexPrefix(name, m.indexes[i].keys.Encode(indexKey), func(_, key interface{}) bool {
+		return fn(key)
+	})
+}
+
+// IterateIndexPrefix calls fn with the index keys of the named index whose
+// encoding starts with prefix, e.g. the first parts of a composite key, and
+// the keys of their values, in order. Return true in fn to stop the
+// iteration.
+func (m IndexedMap) IterateIndexPrefix(name string, prefix []byte, fn func(indexKey, key interface{}) (stop bool)) {
+	i := m.indexPosition(name)
+	indexPrefix := []byte{byte(i + 1)}
+	iter := sdk.KVStorePrefixIterator(m.store, append(indexPrefix, prefix...))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		bz := iter.Key()[len(indexPrefix):]
+		indexKey, n, err := m.indexes[i].keys.Decode(bz)
+		if err != nil {
+			panic(err)
+		}
+		key, err := decodeKey(m.primary.keys, bz[n:])
+		if err != nil {
+			panic(err)
+		}
+		if fn(indexKey, key) {
+			break
+		}
+	}
+}
+
+// Returns the value of the key, of the type of the values.
+func (m IndexedMap) get(key interface{}) (interface{}, bool) {
+	ptr := reflect.New(m.valueType)
+	if !m.primary.Get(key, ptr.Interface()) {
+		return nil, false
+	}
+	return ptr.Elem().Interface(), true
+}
+
+func (m IndexedMap) deleteIndexEntries(keyBz []byte, value interface{}) {
+	for i, index := range m.indexes {
+		if indexKey, ok := index.indexKey(value); ok {
+			m.store.Delete(m.indexEntryKey(i, indexKey, keyBz))
+		}
+	}
+}
+
+func (m IndexedMap) indexEntryKey(i int, indexKey interface{}, keyBz []byte) []byte {
+	bz := append([]byte{byte(i + 1)}, m.indexes[i].keys.Encode(indexKey)...)
+	return append(bz, keyBz...)
+}
+
+func (m IndexedMap) indexPosition(name string) int {
+	for i, index := range m.indexes {
+		if index.name == name {
+			return i
+		}
+	}
+	panic(fmt.Sprintf("no index %s", name))
+}
@@ -0,0 +1,171 @@
+package store
+
+import (
+	"container/list"
+	"io"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultInterBlockCacheSize is a suggested number of values kept in the
+// inter-block cache of a store.
+const DefaultInterBlockCacheSize = 10000
+
+// InterBlockCacheMultiStore is a multistore which can keep the values read
+// from its stores cached across blocks.
+type InterBlockCacheMultiStore interface {
+	SetInterBlockCache(size int, storeNames ...string)
+}
+
+var _ CommitKVStore = (*interBlockCacheStore)(nil)
+var _ Queryable = (*interBlockCacheStore)(nil)
+
+// interBlockCacheStore is a write-through cache of the values of a
+// CommitKVStore. Unlike the cacheKVStores wrapping it for a block, it lives
+// as long as the store is loaded, so that the values read every block are
+// not loaded from the tree again. It keeps at most size values, evicting
+// the least recently used ones.
+//
+// Every write goes through the cache, so it always holds the values of the
+// working tree of the parent, which is what a commit saves: committing
+// leaves the cache valid. Loading a version again, e.g. to roll back,
+// loads new stores with empty caches.
+type interBlockCacheStore struct {
+	mtx    sync.Mutex
+	parent CommitKVStore
+
+	size    int
+	entries map[string]*list.Element
+	lru     *list.List // of interBlockCacheEntry, most recently used first
+}
+
+type interBlockCacheEntry struct {
+	key   string
+	value []byte // nil if the key doesn't exist in parent
+}
+
+func newInterBlockCacheStore(parent CommitKVStore, size int) *interBlockCacheStore {
+	return &interBlockCacheStore{
+		parent:  parent,
+		size:    size,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Implements Store.
+func (ibc *interBlockCacheStore) GetStoreType() StoreType {
+	return ibc.parent.GetStoreType()
+}
+
+// Implements Store.
+func (ibc *interBlockCacheStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(ibc)
+}
+
+// CacheWrapWithTrace implements the Store interface.
+func (ibc *interBlockCacheStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(ibc, w, tc))
+}
+
+// Implements Committer.
+func (ibc *interBlockCacheStore) Commit() CommitID {
+	return ibc.parent.Commit()
+}
+
+// Implements Committer.
+func (ibc *interBlockCacheStore) LastCommitID() CommitID {
+	return ibc.parent.LastCommitID()
+}
+
+// Implements Committer.
+func (ibc *interBlockCacheStore) SetPruning(pruning sdk.PruningStrategy) {
+	ibc.parent.SetPruning(pruning)
+}
+
+// Implements KVStore.
+func (ibc *interBlockCacheStore) Get(key []byte) []byte {
+	ibc.mtx.Lock()
+	defer ibc.mtx.Unlock()
+
+	if elem, ok := ibc.entries[string(key)]; ok {
+		ibc.lru.MoveToFront(elem)
+		return elem.Value.(*interBlockCacheEntry).value
+	}
+	value := ibc.parent.Get(key)
+	ibc.add(key, value)
+	return value
+}
+
+// Implements KVStore.
+func (ibc *interBlockCacheStore) Has(key []byte) bool {
+	return ibc.Get(key) != nil
+}
+
+// Implements KVStore.
+func (ibc *interBlockCacheStore) Set(key, value []byte) {
+	ibc.mtx.Lock()
+	defer ibc.mtx.Unlock()
+
+	ibc.parent.Set(key, value)
+	ibc.add(key, value)
+}
+
+// Implements KVStore.
+func (ibc *interBlockCacheStore) Delete(key []byte) {
+	ibc.mtx.Lock()
+	defer ibc.mtx.Unlock()
+
+	ibc.parent.Delete(key)
+	ibc.add(key, nil)
+}
+
+// Implements KVStore.
+func (ibc *interBlockCacheStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{ibc, prefix}
+}
+
+// Implements KVStore.
+func (ibc *interBlockCacheStore) Gas(meter GasMeter, config GasConfig) KVStore {
+	return NewGasKVStore(meter, config, ibc)
+}
+
+// Implements KVStore. Iterators read the parent, which holds every write.
+func (ibc *interBlockCacheStore) Iterator(start, end []byte) Iterator {
+	return ibc.parent.Iterator(start, end)
+}
+
+// Implements KVStore.
+func (ibc *interBlockCacheStore) ReverseIterator(start, end []byte) Iterator {
+	return ibc.parent.ReverseIterator(start, end)
+}
+
+// Implements Queryable. Queries are served by the parent, as they may be
+// for past versions.
+func (ibc *interBlockCacheStore) Query(req abci.RequestQuery) abci.ResponseQuery {
+	queryable, ok := ibc.parent.(Queryable)
+	if !ok {
+		return sdk.ErrUnknownRequest("store doesn't support queries").QueryResult()
+	}
+	return queryable.Query(req)
+}
+
+// Caches the value of a key as the most recently used, evicting the least
+// recently used value if the cache is full.
+func (ibc *interBlockCacheStore) add(key, value []byte) {
+	if elem, ok := ibc.entries[string(key)]; ok {
+		elem.Value.(*interBlockCacheEntry).value = value
+		ibc.lru.MoveToFront(elem)
+		return
+	}
+	if ibc.lru.Len() >= ibc.size {
+		oldest := ibc.lru.Back()
+		ibc.lru.Remove(oldest)
+		delete(ibc.entries, oldest.Value.(*interBlockCacheEntry).key)
+	}
+	entry := &interBlockCacheEntry{key: string(key), value: value}
+	ibc.entries[entry.key] = ibc.lru.PushFront(entry)
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+func TestInterBlockCacheLRU(t *testing.T) {
+	tree, _ := newTree(t, dbm.NewMemDB())
+	parent := newIAVLStore(tree, numRecent, storeEvery)
+	store := newInterBlockCacheStore(parent, 2)
+
+	// absent keys are cached too
+	require.Nil(t, store.Get([]byte("foo")))
+	require.Equal(t, []byte("goodbye"), store.Get([]byte("hello")))
+	require.Len(t, store.entries, 2)
+
+	// the least recently used value is evicted
+	require.Nil(t, store.Get([]byte("foo")))
+	require.False(t, store.Has([]byte("baz")))
+	require.Len(t, store.entries, 2)
+	require.Contains(t, store.entries, "foo")
+	require.NotContains(t, store.entries, "hello")
+
+	// writes go through the cache
+	store.Set([]byte("foo"), []byte("bar"))
+	store.Delete([]byte("hello"))
+	require.Equal(t, []byte("bar"), parent.Get([]byte("foo")))
+	require.Nil(t, parent.Get([]byte("hello")))
+	require.Equal(t, []byte("bar"), store.Get([]byte("foo")))
+	require.False(t, store.Has([]byte("hello")))
+}
+
+func TestInterBlockCacheMultiStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	newStore := func() *rootMultiStore {
+		store := newMultiStoreWithMounts(db)
+		store.SetInterBlockCache(10, "store1")
+		return store
+	}
+	store := newStore()
+	require.Nil(t, store.LoadLatestVersion())
+	_, ok := store.getStoreByName("store1").(*interBlockCacheStore)
+	require.True(t, ok)
+	_, ok = store.getStoreByName("store2").(*iavlStore)
+	require.True(t, ok)
+
+	key := []byte("key")
+	for _, value := range []string{"value1", "value2"} {
+		cms := store.CacheMultiStore()
+		cms.GetKVStore(store.keysByName["store1"]).Set(key, []byte(value))
+		cms.Write()
+		store.Commit()
+
+		// committed values are read from the cache by the next blocks
+		require.Contains(t, store.getStoreByName("store1").(*interBlockCacheStore).entries, string(key))
+		require.Equal(t, []byte(value), store.CacheMultiStore().GetKVStore(store.keysByName["store1"]).Get(key))
+	}
+
+	// rolling back to an earlier version loads an empty cache
+	store = newStore()
+	require.Nil(t, store.LoadVersion(1))
+	cached := store.getStoreByName("store1").(*interBlockCacheStore)
+	require.Empty(t, cached.entries)
+	require.Equal(t, []byte("value1"), cached.Get(key))
+
+	// queries are served by the IAVL store
+	query := abci.RequestQuery{Path: "/key", Data: key, Height: 1}
+	require.Equal(t, []byte("value1"), cached.Query(query).Value)
+}
@@ -0,0 +1,199 @@
+package store
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// KeyEncoder encodes the keys of a Map into bytes whose order defines the
+// order in which the keys are iterated. Encoded keys must be self-delimiting
+// to be parts of a composite key.
+type KeyEncoder interface {
+	// Encode returns the bytes of the key. It panics on a key of another
+	// type than the one of the encoder.
+	Encode(key interface{}) []byte
+
+	// Decode returns the key encoded at the start of bz, and the number of
+	// bytes it takes.
+	Decode(bz []byte) (key interface{}, n int, err error)
+}
+
+var (
+	// AccAddressKey encodes sdk.AccAddress keys, prefixed by their length.
+	AccAddressKey KeyEncoder = accAddressKeyEncoder{}
+
+	// ValAddressKey encodes sdk.ValAddress keys, prefixed by their length.
+	ValAddressKey KeyEncoder = valAddressKeyEncoder{}
+
+	// StringKey encodes string keys, prefixed by their length: shorter
+	// strings sort first.
+	StringKey KeyEncoder = stringKeyEncoder{}
+
+	// Int64Key encodes int64 keys in big-endian, with the sign bit flipped
+	// for the negative keys to sort first.
+	Int64Key KeyEncoder = int64KeyEncoder{}
+
+	// TimeKey encodes time.Time keys as sdk.FormatTimeBytes does.
+	TimeKey KeyEncoder = timeKeyEncoder{}
+)
+
+// Encodes bytes prefixed by their length, at most 255 bytes.
+func encodeLengthPrefixed(bz []byte) []byte {
+	if len(bz) > 255 {
+		panic(fmt.Sprintf("key of %d bytes is too long", len(bz)))
+	}
+	return append([]byte{byte(len(bz))}, bz...)
+}
+
+func decodeLengthPrefixed(bz []byte) ([]byte, int, error) {
+	if len(bz) == 0 || len(bz) < 1+int(bz[0]) {
+		return nil, 0, errors.New("invalid key: truncated length-prefixed bytes")
+	}
+	n := 1 + int(bz[0])
+	return bz[1:n], n, nil
+}
+
+type accAddressKeyEncoder struct{}
+
+func (accAddressKeyEncoder) Encode(key interface{}) []byte {
+	return encodeLengthPrefixed(key.(sdk.AccAddress))
+}
+
+func (accAddressKeyEncoder) Decode(bz []byte) (interface{}, int, error) {
+	addr, n, err := decodeLengthPrefixed(bz)
+	if err != nil {
+		return nil, 0, err
+	}
+	return sdk.AccAddress(append([]byte{}, addr...)), n, nil
+}
+
+type valAddressKeyEncoder struct{}
+
+func (valAddressKeyEncoder) Encode(key interface{}) []byte {
+	return encodeLengthPrefixed(key.(sdk.ValAddress))
+}
+
+func (valAddressKeyEncoder) Decode(bz []byte) (interface{}, int, error) {
+	addr, n, err := decodeLengthPrefixed(bz)
+	if err != nil {
+		return nil, 0, err
+	}
+	return sdk.ValAddress(append([]byte{}, addr...)), n, nil
+}
+
+type stringKeyEncoder struct{}
+
+func (stringKeyEncoder) Encode(key interface{}) []byte {
+	return encodeLengthPrefixed([]byte(key.(string)))
+}
+
+func (stringKeyEncoder) Decode(bz []byte) (interface{}, int, error) {
+	str, n, err := decodeLengthPrefixed(bz)
+	if err != nil {
+		return nil, 0, err
+	}
+	return string(str), n, nil
+}
+
+type int64KeyEncoder struct{}
+
+func (int64KeyEncoder) Encode(key interface{}) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(key.(int64))^(1<<63))
+	return bz
+}
+
+func (int64KeyEncoder) Decode(bz []byte) (interface{}, int, error) {
+	if len(bz) < 8 {
+		return nil, 0, errors.New("invalid int64 key: truncated")
+	}
+	return int64(binary.BigEndian.Uint64(bz) ^ (1 << 63)), 8, nil
+}
+
+// length of the times formatted by sdk.FormatTimeBytes
+var timeKeyLen = len(sdk.FormatTimeBytes(time.Time{}))
+
+type timeKeyEncoder struct{}
+
+func (timeKeyEncoder) Encode(key interface{}) []byte {
+	return sdk.FormatTimeBytes(key.(time.Time))
+}
+
+func (timeKeyEncoder) Decode(bz []byte) (interface{}, int, error) {
+	if len(bz) < timeKeyLen {
+		return nil, 0, errors.New("invalid time key: truncated")
+	}
+	t, err := sdk.ParseTimeBytes(bz[:timeKeyLen])
+	if err != nil {
+		return nil, 0, err
+	}
+	return t, timeKeyLen, nil
+}
+
+// CompositeKeyEncoder encodes keys made of several parts, as []interface{}
+// holding a key of each of its encoders in order. The keys are sorted by
+// their first part, then by the next ones.
+type CompositeKeyEncoder struct {
+	parts []KeyEncoder
+}
+
+var _ KeyEncoder = CompositeKeyEncoder{}
+
+// NewCompositeKeyEncoder returns an encoder of the keys made of parts encoded
+// by the given encoders.
+func NewCompositeKeyEncoder(parts ...KeyEncoder) CompositeKeyEncoder {
+	return CompositeKeyEncoder{parts: parts}
+}
+
+// Implements KeyEncoder.
+func (enc CompositeKeyEncoder) Encode(key interface{}) []byte {
+	parts := key.([]interface{})
+	if len(parts) != len(enc.parts) {
+		panic(fmt.Sprintf("composite key of %d parts instead of %d", len(parts), len(enc.parts)))
+	}
+	return enc.Prefix(parts...)
+}
+
+// Prefix returns the encoding of the first parts of a key, which the keys
+// starting with these parts are prefixed with.
+func (enc CompositeKeyEncoder) Prefix(parts ...interface{}) []byte {
+	if len(parts) > len(enc.parts) {
+		panic(fmt.Sprintf("composite key prefix of %d parts out of %d", len(parts), len(enc.parts)))
+	}
+	var bz []byte
+	for i, part := range parts {
+		bz = append(bz, enc.parts[i].Encode(part)...)
+	}
+	return bz
+}
+
+// Implements KeyEncoder.
+func (enc CompositeKeyEncoder) Decode(bz []byte) (interface{}, int, error) {
+	parts := make([]interface{}, len(enc.parts))
+	read := 0
+	for i, partEnc := range enc.parts {
+		part, n, err := partEnc.Decode(bz[read:])
+		if err != nil {
+			return nil, 0, err
+		}
+		parts[i] = part
+		read += n
+	}
+	return parts, read, nil
+}
+
+// Decodes a whole key, failing on trailing bytes.
+func decodeKey(enc KeyEncoder, bz []byte) (interface{}, error) {
+	key, n, err := enc.Decode(bz)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(bz) {
+		return nil, fmt.Errorf("invalid key %X: trailing bytes", bz)
+	}
+	return key, nil
+}
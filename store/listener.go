@@ -0,0 +1,102 @@
+package store
+
+import (
+	"io"
+	"sort"
+)
+
+// maxChangeSetSize bounds the size of a ChangeSet read back from a stream.
+const maxChangeSetSize = 1 << 30
+
+// WriteListener is notified of the changes written to the stores of a
+// rootMultiStore it listens to, and of the commits of the multistore.
+type WriteListener interface {
+	// OnWrite is called for every key set or deleted in a store when the
+	// changes of a cache are written to it. The value is nil on delete.
+	OnWrite(storeKey StoreKey, key []byte, value []byte, delete bool)
+
+	// OnCommit is called once the multistore is committed. An error halts
+	// the commit, as the changes of the block could not be delivered.
+	OnCommit(commitID CommitID) error
+}
+
+// ListenableMultiStore is a multistore notifying listeners of the changes
+// written to its stores.
+type ListenableMultiStore interface {
+	AddListener(listener WriteListener, storeNames ...string)
+}
+
+// StoreKVPair is a key set or deleted in a store.
+type StoreKVPair struct {
+	Key    []byte `json:"key"`
+	Value  []byte `json:"value"`
+	Delete bool   `json:"delete"`
+}
+
+// StoreChangeSet holds the changes written to a store. The pairs are sorted by
+// key, as the cache of the block writes them, not in the order of the txs.
+type StoreChangeSet struct {
+	StoreKey string        `json:"store_key"`
+	Pairs    []StoreKVPair `json:"pairs"`
+}
+
+// ChangeSet holds the changes committed at a height, grouped by store and
+// sorted by store name. Stores without changes are left out.
+type ChangeSet struct {
+	Height int64            `json:"height"`
+	Stores []StoreChangeSet `json:"stores"`
+}
+
+// FileListener is a WriteListener streaming the changes of every commit to
+// a writer, as length-prefixed amino-encoded ChangeSets. A ChangeSet is
+// written at every commit, even without changes.
+type FileListener struct {
+	w       io.Writer
+	changes map[string][]StoreKVPair
+}
+
+var _ WriteListener = (*FileListener)(nil)
+
+// NewFileListener returns a FileListener writing the ChangeSets to w.
+func NewFileListener(w io.Writer) *FileListener {
+	return &FileListener{
+		w:       w,
+		changes: make(map[string][]StoreKVPair),
+	}
+}
+
+// Implements WriteListener.
+func (fl *FileListener) OnWrite(storeKey StoreKey, key []byte, value []byte, delete bool) {
+	name := storeKey.Name()
+	fl.changes[name] = append(fl.changes[name], StoreKVPair{
+		Key:    key,
+		Value:  value,
+		Delete: delete,
+	})
+}
+
+// Implements WriteListener.
+func (fl *FileListener) OnCommit(commitID CommitID) error {
+	changeSet := ChangeSet{Height: commitID.Version}
+	for name, pairs := range fl.changes {
+		changeSet.Stores = append(changeSet.Stores, StoreChangeSet{StoreKey: name, Pairs: pairs})
+	}
+	sort.Slice(changeSet.Stores, func(i, j int) bool {
+		return changeSet.Stores[i].StoreKey < changeSet.Stores[j].StoreKey
+	})
+	fl.changes = make(map[string][]StoreKVPair)
+
+	bz, err := cdc.MarshalBinary(changeSet)
+	if err != nil {
+		return err
+	}
+	_, err = fl.w.Write(bz)
+	return err
+}
+
+// ReadChangeSet reads the next ChangeSet written by a FileListener. It
+// returns io.EOF at the end of the stream.
+func ReadChangeSet(r io.Reader) (changeSet ChangeSet, err error) {
+	_, err = cdc.UnmarshalBinaryReader(r, &changeSet, maxChangeSetSize)
+	return changeSet, err
+}
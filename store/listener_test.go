@@ -0,0 +1,78 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestFileListener(t *testing.T) {
+	var buf bytes.Buffer
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	store.AddListener(NewFileListener(&buf), "store1", "store2")
+	require.NoError(t, store.LoadLatestVersion())
+
+	cms := store.CacheMultiStore()
+	store1 := cms.GetKVStore(store.keysByName["store1"])
+	store2 := cms.GetKVStore(store.keysByName["store2"])
+	store3 := cms.GetKVStore(store.keysByName["store3"])
+	store2.Set([]byte("b"), []byte("2"))
+	store1.Set([]byte("b"), []byte("2"))
+	store1.Set([]byte("a"), []byte("1"))
+	store3.Set([]byte("c"), []byte("3"))
+
+	// writes of nested caches are only streamed once written to the stores
+	nested := cms.CacheMultiStore()
+	nested.GetKVStore(store.keysByName["store1"]).Set([]byte("c"), []byte("3"))
+	nested.Write()
+	require.Zero(t, buf.Len())
+	cms.Write()
+	store.Commit()
+
+	cms = store.CacheMultiStore()
+	cms.GetKVStore(store.keysByName["store1"]).Delete([]byte("a"))
+	cms.Write()
+	store.Commit()
+	store.Commit()
+
+	expected := []ChangeSet{
+		{Height: 1, Stores: []StoreChangeSet{
+			{StoreKey: "store1", Pairs: []StoreKVPair{
+				{Key: []byte("a"), Value: []byte("1")},
+				{Key: []byte("b"), Value: []byte("2")},
+				{Key: []byte("c"), Value: []byte("3")},
+			}},
+			{StoreKey: "store2", Pairs: []StoreKVPair{
+				{Key: []byte("b"), Value: []byte("2")},
+			}},
+		}},
+		{Height: 2, Stores: []StoreChangeSet{
+			{StoreKey: "store1", Pairs: []StoreKVPair{
+				{Key: []byte("a"), Delete: true},
+			}},
+		}},
+		{Height: 3},
+	}
+	for _, changeSet := range expected {
+		got, err := ReadChangeSet(&buf)
+		require.NoError(t, err)
+		require.Equal(t, changeSet, got)
+	}
+	_, err := ReadChangeSet(&buf)
+	require.Equal(t, io.EOF, err)
+}
+
+func TestFileListenerError(t *testing.T) {
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	store.AddListener(NewFileListener(failingWriter{}), "store1")
+	require.NoError(t, store.LoadLatestVersion())
+	require.Panics(t, func() { store.Commit() })
+}
@@ -0,0 +1,115 @@
+package store
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Map is a typed map stored under a prefix of a KVStore, its keys encoded by
+// a KeyEncoder and its values by the codec.
+// It panics when a value cannot be (un/)marshalled by the codec, or a key
+// stored under the prefix cannot be decoded.
+type Map struct {
+	cdc   *codec.Codec
+	store sdk.KVStore
+	keys  KeyEncoder
+}
+
+// NewMap constructs a new Map of the values under prefix in the store.
+func NewMap(cdc *codec.Codec, store sdk.KVStore, prefix []byte, keys KeyEncoder) Map {
+	return Map{
+		cdc:   cdc,
+		store: store.Prefix(prefix),
+		keys:  keys,
+	}
+}
+
+// Has returns whether a value is set for the key.
+func (m Map) Has(key interface{}) bool {
+	return m.store.Has(m.keys.Encode(key))
+}
+
+// Get unmarshals the value of the key into ptr, and returns false if no
+// value is set for the key.
+func (m Map) Get(key interface{}, ptr interface{}) bool {
+	bz := m.store.Get(m.keys.Encode(key))
+	if bz == nil {
+		return false
+	}
+	m.cdc.MustUnmarshalBinary(bz, ptr)
+	return true
+}
+
+// Set sets the value of the key.
+func (m Map) Set(key interface{}, value interface{}) {
+	m.store.Set(m.keys.Encode(key), m.cdc.MustMarshalBinary(value))
+}
+
+// Delete deletes the value of the key.
+func (m Map) Delete(key interface{}) {
+	m.store.Delete(m.keys.Encode(key))
+}
+
+// Iterate calls fn with each key, in order, after unmarshalling its value
+// into ptr. Return true in fn to stop the iteration.
+//
+// CONTRACT: No writes may happen within a domain while iterating over it.
+func (m Map) Iterate(ptr interface{}, fn func(key interface{}) (stop bool)) {
+	m.iterate(m.store.Iterator(nil, nil), ptr, fn)
+}
+
+// IterateRange is Iterate over the keys from start, inclusive, to end,
+// exclusive, in reverse order if reverse is set. A nil start or end leaves
+// the range open on that side.
+func (m Map) IterateRange(start, end interface{}, reverse bool, ptr interface{}, fn func(key interface{}) (stop bool)) {
+	var startBz, endBz []byte
+	if start != nil {
+		startBz = m.keys.Encode(start)
+	}
+	if end != nil {
+		endBz = m.keys.Encode(end)
+	}
+	if reverse {
+		m.iterate(m.store.ReverseIterator(startBz, endBz), ptr, fn)
+	} else {
+		m.iterate(m.store.Iterator(startBz, endBz), ptr, fn)
+	}
+}
+
+// IteratePrefix is Iterate over the keys whose encoding starts with prefix,
+// e.g. the first parts of a composite key.
+func (m Map) IteratePrefix(prefix []byte, ptr interface{}, fn func(key interface{}) (stop bool)) {
+	m.iterate(sdk.KVStorePrefixIterator(m.store, prefix), ptr, fn)
+}
+
+// Paginate calls fn with at most limit keys in order, starting from the
+// key start or from the first one if nil, after unmarshalling their value
+// into ptr. It returns the key the next page starts from, nil if there are
+// no more keys.
+func (m Map) Paginate(start interface{}, limit int, ptr interface{}, fn func(key interface{})) (next interface{}) {
+	count := 0
+	m.IterateRange(start, nil, false, ptr, func(key interface{}) bool {
+		if count == limit {
+			next = key
+			return true
+		}
+		fn(key)
+		count++
+		return false
+	})
+	return next
+}
+
+func (m Map) iterate(iter sdk.Iterator, ptr interface{}, fn func(key interface{}) (stop bool)) {
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		key, err := decodeKey(m.keys, iter.Key())
+		if err != nil {
+			panic(err)
+		}
+		m.cdc.MustUnmarshalBinary(iter.Value(), ptr)
+		if fn(key) {
+			break
+		}
+	}
+}
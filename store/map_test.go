@@ -0,0 +1,159 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestKeyEncoders(t *testing.T) {
+	now := time.Unix(1540000000, 123).UTC()
+	composite := NewCompositeKeyEncoder(AccAddressKey, Int64Key, TimeKey, StringKey)
+	key := []interface{}{sdk.AccAddress("addr"), int64(-7), now, "denom"}
+	bz := composite.Encode(key)
+	decoded, err := decodeKey(composite, bz)
+	require.Nil(t, err)
+	require.Equal(t, key, decoded)
+	require.Equal(t, composite.Prefix(sdk.AccAddress("addr"), int64(-7)), bz[:1+4+8])
+
+	_, err = decodeKey(composite, bz[:len(bz)-1])
+	require.NotNil(t, err)
+	_, err = decodeKey(ValAddressKey, append(ValAddressKey.Encode(sdk.ValAddress("val")), 0x00))
+	require.NotNil(t, err)
+
+	// signed integers and times keep their order
+	require.True(t, string(Int64Key.Encode(int64(-1))) < string(Int64Key.Encode(int64(0))))
+	require.True(t, string(TimeKey.Encode(now)) < string(TimeKey.Encode(now.Add(time.Nanosecond))))
+}
+
+func TestMap(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx, cdc := defaultComponents(key)
+	m := NewMap(cdc, ctx.KVStore(key), []byte{0x01}, Int64Key)
+
+	var res S
+	require.False(t, m.Get(int64(1), &res))
+	for _, i := range []int64{10, -5, 3, 7} {
+		m.Set(i, S{uint64(i * i), i > 0})
+	}
+	require.True(t, m.Has(int64(-5)))
+	require.True(t, m.Get(int64(-5), &res))
+	require.Equal(t, S{25, false}, res)
+	m.Delete(int64(7))
+	require.False(t, m.Has(int64(7)))
+
+	collect := func(iterate func(fn func(key interface{}) bool)) (keys []interface{}) {
+		iterate(func(key interface{}) bool {
+			keys = append(keys, key)
+			return false
+		})
+		return keys
+	}
+	require.Equal(t, []interface{}{int64(-5), int64(3), int64(10)}, collect(func(fn func(interface{}) bool) {
+		m.Iterate(&res, fn)
+	}))
+	require.Equal(t, []interface{}{int64(10), int64(3)}, collect(func(fn func(interface{}) bool) {
+		m.IterateRange(int64(0), nil, true, &res, fn)
+	}))
+
+	// pages of two values
+	var page []interface{}
+	next := m.Paginate(nil, 2, &res, func(key interface{}) { page = append(page, key) })
+	require.Equal(t, []interface{}{int64(-5), int64(3)}, page)
+	require.Equal(t, int64(10), next)
+	page = nil
+	next = m.Paginate(next, 2, &res, func(key interface{}) { page = append(page, key) })
+	require.Equal(t, []interface{}{int64(10)}, page)
+	require.Equal(t, S{100, true}, res)
+	require.Nil(t, next)
+
+	// values under other prefixes are left out
+	ctx.KVStore(key).Set([]byte{0x02}, []byte("foo"))
+	require.Len(t, collect(func(fn func(interface{}) bool) { m.Iterate(&res, fn) }), 3)
+}
+
+type testRedelegation struct {
+	Delegator sdk.AccAddress
+	ValSrc    sdk.ValAddress
+	ValDst    sdk.ValAddress
+	Amount    int64
+}
+
+func TestIndexedMap(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx, cdc := defaultComponents(key)
+	keys := NewCompositeKeyEncoder(AccAddressKey, ValAddressKey, ValAddressKey)
+	m := NewIndexedMap(cdc, ctx.KVStore(key), []byte{0x01}, keys, testRedelegation{},
+		NewIndex("src", ValAddressKey, func(value interface{}) (interface{}, bool) {
+			return value.(testRedelegation).ValSrc, true
+		}),
+		NewIndex("dst", ValAddressKey, func(value interface{}) (interface{}, bool) {
+			return value.(testRedelegation).ValDst, true
+		}),
+		// only the large redelegations are indexed by amount
+		NewIndex("amount", Int64Key, func(value interface{}) (interface{}, bool) {
+			amount := value.(testRedelegation).Amount
+			return amount, amount >= 100
+		}),
+	)
+
+	del1, del2 := sdk.AccAddress("del1"), sdk.AccAddress("del2")
+	val1, val2, val3 := sdk.ValAddress("val1"), sdk.ValAddress("val2"), sdk.ValAddress("val3")
+	reds := []testRedelegation{
+		{del1, val1, val2, 10},
+		{del2, val1, val3, 200},
+		{del1, val2, val3, 100},
+	}
+	redKey := func(red testRedelegation) []interface{} {
+		return []interface{}{red.Delegator, red.ValSrc, red.ValDst}
+	}
+	for _, red := range reds {
+		m.Set(redKey(red), red)
+	}
+
+	byIndex := func(name string, indexKey interface{}) (res []interface{}) {
+		m.IterateIndex(name, indexKey, func(key interface{}) bool {
+			res = append(res, key)
+			return false
+		})
+		return res
+	}
+	require.Equal(t, []interface{}{redKey(reds[0]), redKey(reds[1])}, byIndex("src", val1))
+	require.Equal(t, []interface{}{redKey(reds[2]), redKey(reds[1])}, byIndex("dst", val3))
+	require.Nil(t, byIndex("amount", int64(10)))
+
+	var amounts []interface{}
+	m.IterateIndexPrefix("amount", nil, func(indexKey, key interface{}) bool {
+		amounts = append(amounts, indexKey)
+		return false
+	})
+	require.Equal(t, []interface{}{int64(100), int64(200)}, amounts)
+
+	// updating a value moves its index entries
+	reds[1].Amount = 50
+	m.Set(redKey(reds[1]), reds[1])
+	require.Equal(t, []interface{}{redKey(reds[2])}, byIndex("amount", int64(100)))
+	require.Nil(t, byIndex("amount", int64(200)))
+	var red testRedelegation
+	require.True(t, m.Get(redKey(reds[1]), &red))
+	require.Equal(t, reds[1], red)
+
+	// deleting a value deletes its index entries
+	m.Delete(redKey(reds[0]))
+	require.False(t, m.Has(redKey(reds[0])))
+	require.Equal(t, []interface{}{redKey(reds[1])}, byIndex("src", val1))
+	require.Nil(t, byIndex("dst", val2))
+
+	// the values of a delegator
+	var dels []interface{}
+	m.IteratePrefix(keys.Prefix(del1), &red, func(key interface{}) bool {
+		dels = append(dels, key)
+		return false
+	})
+	require.Equal(t, []interface{}{redKey(reds[2])}, dels)
+
+	require.Panics(t, func() { byIndex("foo", val1) })
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// MetricsSubsystem is the subsystem of the store metrics.
+const MetricsSubsystem = "store"
+
+// InstrumentedMultiStore is a multistore which can record metrics of the
+// accesses to its stores and of its commits.
+type InstrumentedMultiStore interface {
+	SetMetrics(metrics *Metrics)
+}
+
+// Metrics contains the metrics of the stores.
+type Metrics struct {
+	// Number of reads, writes and iterators of the gas stores, by store name
+	Reads      metrics.Counter
+	Writes     metrics.Counter
+	Iterations metrics.Counter
+
+	// Duration of the commits of the multistore, in seconds
+	CommitDuration metrics.Histogram
+}
+
+// PrometheusMetrics returns the store metrics registered to the Prometheus
+// registerer under the namespace.
+func PrometheusMetrics(namespace string, registerer stdprometheus.Registerer) *Metrics {
+	return &Metrics{
+		Reads: prometheus.NewCounter(registerCounterVec(registerer, stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reads",
+			Help:      "Number of values read from a store, including the keys checked.",
+		}, []string{"store"})),
+		Writes: prometheus.NewCounter(registerCounterVec(registerer, stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "writes",
+			Help:      "Number of values written to or deleted from a store.",
+		}, []string{"store"})),
+		Iterations: prometheus.NewCounter(registerCounterVec(registerer, stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "iterations",
+			Help:      "Number of iterators opened on a store.",
+		}, []string{"store"})),
+		CommitDuration: prometheus.NewHistogram(registerHistogramVec(registerer, stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "commit_duration_seconds",
+			Help:      "Duration of the commits of the multistore.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.001, 2, 14),
+		}, []string{})),
+	}
+}
+
+// registerCounterVec returns a new counter vector registered to the registerer.
+func registerCounterVec(registerer stdprometheus.Registerer, opts stdprometheus.CounterOpts, labels []string) *stdprometheus.CounterVec {
+	vec := stdprometheus.NewCounterVec(opts, labels)
+	registerer.MustRegister(vec)
+	return vec
+}
+
+// registerHistogramVec returns a new histogram vector registered to the
+// registerer.
+func registerHistogramVec(registerer stdprometheus.Registerer, opts stdprometheus.HistogramOpts, labels []string) *stdprometheus.HistogramVec {
+	vec := stdprometheus.NewHistogramVec(opts, labels)
+	registerer.MustRegister(vec)
+	return vec
+}
+
+// NopMetrics returns store metrics which record nothing.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Reads:          discard.NewCounter(),
+		Writes:         discard.NewCounter(),
+		Iterations:     discard.NewCounter(),
+		CommitDuration: discard.NewHistogram(),
+	}
+}
+
+// metricsKVStore wraps a store of a cacheMultiStore for the gas stores it
+// returns to count their accesses under the name of the store.
+type metricsKVStore struct {
+	KVStore
+	storeName string
+	metrics   *Metrics
+}
+
+// Implements KVStore.
+func (ms metricsKVStore) Gas(meter GasMeter, config GasConfig) KVStore {
+	gs := NewGasKVStore(meter, config, ms.KVStore)
+	gs.storeName = ms.storeName
+	gs.metrics = ms.metrics
+	return gs
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// countersByStore counts the additions to a counter by store label.
+type countersByStore map[string]float64
+
+type storeCounter struct {
+	counts    countersByStore
+	storeName string
+}
+
+func (c storeCounter) With(labelValues ...string) metrics.Counter {
+	for i := 0; i+1 < len(labelValues); i += 2 {
+		if labelValues[i] == "store" {
+			c.storeName = labelValues[i+1]
+		}
+	}
+	return c
+}
+
+func (c storeCounter) Add(delta float64) { c.counts[c.storeName] += delta }
+
+func TestGasKVStoreMetrics(t *testing.T) {
+	db := dbm.NewMemDB()
+	ms := newMultiStoreWithMounts(db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	reads, writes, iterations := countersByStore{}, countersByStore{}, countersByStore{}
+	ms.SetMetrics(&Metrics{
+		Reads:          storeCounter{counts: reads},
+		Writes:         storeCounter{counts: writes},
+		Iterations:     storeCounter{counts: iterations},
+		CommitDuration: discard.NewHistogram(),
+	})
+
+	key1 := ms.keysByName["store1"]
+	key2 := ms.keysByName["store2"]
+	meter := sdk.NewInfiniteGasMeter()
+	cms := ms.CacheMultiStore()
+	store1 := cms.GetKVStore(key1).Gas(meter, sdk.KVGasConfig())
+	store2 := cms.GetKVStore(key2).Gas(meter, sdk.KVGasConfig()).Prefix([]byte("prefix"))
+
+	store1.Set(keyFmt(1), valFmt(1))
+	store1.Get(keyFmt(1))
+	store1.Has(keyFmt(2))
+	store2.Set(keyFmt(1), valFmt(1))
+	store2.Delete(keyFmt(1))
+	store2.Iterator(nil, nil).Close()
+
+	require.Equal(t, countersByStore{"store1": 2}, reads)
+	require.Equal(t, countersByStore{"store1": 1, "store2": 2}, writes)
+	require.Equal(t, countersByStore{"store2": 1}, iterations)
+
+	// the stores of nested cache multistores are counted too
+	store1 = cms.CacheMultiStore().GetKVStore(key1).Gas(meter, sdk.KVGasConfig())
+	store1.Get(keyFmt(1))
+	require.Equal(t, countersByStore{"store1": 3}, reads)
+
+	// stores aren't wrapped without metrics
+	ms.SetMetrics(nil)
+	_, ok := ms.CacheMultiStore().GetKVStore(key1).(metricsKVStore)
+	require.False(t, ok)
+}
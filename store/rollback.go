@@ -0,0 +1,96 @@
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Rollback reverts the rootMultiStore committed to db to an earlier version,
+// or to the previous one if version is zero, so that it resumes committing
+// from there. The later versions of the IAVL stores are deleted and the
+// latest version is rewritten. It refuses to if a store has pruned the
+// version, and returns the CommitID of the version rolled back to.
+func Rollback(db dbm.DB, version int64) (CommitID, error) {
+	latest := getLatestVersion(db)
+	if version == 0 {
+		version = latest - 1
+	}
+	if version <= 0 || version >= latest {
+		return CommitID{}, fmt.Errorf("cannot roll back to version %d from latest version %d", version, latest)
+	}
+	cInfo, err := getCommitInfo(db, latest)
+	if err != nil {
+		return CommitID{}, err
+	}
+
+	// all the stores must be loadable at the version rolled back to
+	rs := NewCommitMultiStore(db)
+	rs.SetPruning(sdk.PruneNothing)
+	for _, info := range cInfo.StoreInfos {
+		rs.MountStoreWithDB(sdk.NewKVStoreKey(info.Name), sdk.StoreTypeIAVL, nil)
+	}
+	if err := rs.LoadVersion(version); err != nil {
+		return CommitID{}, fmt.Errorf("cannot roll back to version %d: %v", version, err)
+	}
+
+	batch := db.NewBatch()
+	for _, info := range cInfo.StoreInfos {
+		if err := rollbackIAVLVersions(db, batch, storePrefix(info.Name), version); err != nil {
+			return CommitID{}, fmt.Errorf("failed to roll back store %s: %v", info.Name, err)
+		}
+	}
+	for ver := version + 1; ver <= latest; ver++ {
+		batch.Delete([]byte(fmt.Sprintf(commitInfoKeyFmt, ver)))
+	}
+	setLatestVersion(batch, version)
+	batch.Write()
+
+	return rs.LastCommitID(), nil
+}
+
+// Deletes the versions after version of the IAVL tree stored under prefix:
+// their roots, the nodes they created, and the orphan records of the nodes
+// they replaced, which are part of the latest version again.
+func rollbackIAVLVersions(db dbm.DB, batch dbm.Batch, prefix []byte, version int64) error {
+	roots := dbm.IteratePrefix(db, prefixKey(prefix, iavlRootPrefix))
+	defer roots.Close()
+	for ; roots.Valid(); roots.Next() {
+		key := roots.Key()[len(prefix)+len(iavlRootPrefix):]
+		if len(key) != 8 {
+			return fmt.Errorf("invalid IAVL root key %X", roots.Key())
+		}
+		if int64(binary.BigEndian.Uint64(key)) > version {
+			batch.Delete(roots.Key())
+		}
+	}
+
+	nodes := dbm.IteratePrefix(db, prefixKey(prefix, iavlNodePrefix))
+	defer nodes.Close()
+	for ; nodes.Valid(); nodes.Next() {
+		node, err := decodeIAVLNode(nodes.Value())
+		if err != nil {
+			return err
+		}
+		if node.version > version {
+			batch.Delete(nodes.Key())
+		}
+	}
+
+	// orphans are keyed by the last version they are part of
+	orphans := dbm.IteratePrefix(db, prefixKey(prefix, iavlOrphanPrefix))
+	defer orphans.Close()
+	for ; orphans.Valid(); orphans.Next() {
+		key := orphans.Key()[len(prefix)+len(iavlOrphanPrefix):]
+		if len(key) < 16 {
+			return fmt.Errorf("invalid IAVL orphan key %X", orphans.Key())
+		}
+		if int64(binary.BigEndian.Uint64(key[:8])) >= version {
+			batch.Delete(orphans.Key())
+		}
+	}
+	return nil
+}
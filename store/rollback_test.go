@@ -0,0 +1,89 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Commits version ver, overwriting and deleting some keys of the previous
+// versions.
+func commitRollbackVersion(store *rootMultiStore, ver int) CommitID {
+	store1 := store.getStoreByName("store1").(KVStore)
+	for i := 0; i < 20; i++ {
+		store1.Set([]byte(fmt.Sprintf("key%02d", (ver*7+i)%50)), []byte(fmt.Sprintf("value%d", ver)))
+	}
+	store1.Delete([]byte(fmt.Sprintf("key%02d", ver*3)))
+	store.getStoreByName("store2").(KVStore).Set([]byte{byte(ver)}, []byte{byte(ver)})
+	return store.Commit()
+}
+
+func TestRollback(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	store.SetPruning(sdk.PruneNothing)
+	require.Nil(t, store.LoadLatestVersion())
+	commitIDs := make(map[int]CommitID)
+	for ver := 1; ver <= 5; ver++ {
+		commitIDs[ver] = commitRollbackVersion(store, ver)
+	}
+	var expected []KVPair
+	it := store.getStoreByName("store1").(*iavlStore).Iterator(nil, nil)
+	for ; it.Valid(); it.Next() {
+		expected = append(expected, KVPair{Key: it.Key(), Value: it.Value()})
+	}
+	it.Close()
+
+	_, err := Rollback(db, 5)
+	require.NotNil(t, err)
+
+	commitID, err := Rollback(db, 3)
+	require.Nil(t, err)
+	require.Equal(t, commitIDs[3], commitID)
+	store = newMultiStoreWithMounts(db)
+	store.SetPruning(sdk.PruneNothing)
+	require.Nil(t, store.LoadLatestVersion())
+	require.Equal(t, commitIDs[3], store.LastCommitID())
+	require.False(t, store.getStoreByName("store1").(*iavlStore).VersionExists(4))
+
+	// committing the same changes again leads to the same versions
+	for ver := 4; ver <= 5; ver++ {
+		require.Equal(t, commitIDs[ver], commitRollbackVersion(store, ver))
+	}
+	var got []KVPair
+	it = store.getStoreByName("store1").(*iavlStore).Iterator(nil, nil)
+	for ; it.Valid(); it.Next() {
+		got = append(got, KVPair{Key: it.Key(), Value: it.Value()})
+	}
+	it.Close()
+	require.Equal(t, expected, got)
+
+	// the previous version by default
+	commitID, err = Rollback(db, 0)
+	require.Nil(t, err)
+	require.Equal(t, commitIDs[4], commitID)
+}
+
+func TestRollbackPruned(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	store.SetPruning(sdk.NewPruningStrategy(1, 0, 1))
+	require.Nil(t, store.LoadLatestVersion())
+	for ver := 1; ver <= 4; ver++ {
+		commitRollbackVersion(store, ver)
+	}
+	commitID := store.LastCommitID()
+
+	_, err := Rollback(db, 2)
+	require.NotNil(t, err)
+	store = newMultiStoreWithMounts(db)
+	require.Nil(t, store.LoadLatestVersion())
+	require.Equal(t, commitID, store.LastCommitID())
+
+	_, err = Rollback(db, 3)
+	require.Nil(t, err)
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
+	"github.com/tendermint/iavl"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/crypto/tmhash"
@@ -31,18 +33,36 @@ type rootMultiStore struct {
 
 	traceWriter  io.Writer
 	traceContext TraceContext
+
+	// listeners of the changes written to the stores, by store name
+	listeners    map[string][]WriteListener
+	allListeners []WriteListener
+
+	// sizes of the inter-block caches of the IAVL stores, by store name
+	interBlockCaches map[string]int
+
+	// metrics of the accesses to the stores and of the commits, may be nil
+	metrics *Metrics
+
+	// versions the IAVL stores must not prune, e.g. while exported
+	pins *versionPins
 }
 
 var _ CommitMultiStore = (*rootMultiStore)(nil)
 var _ Queryable = (*rootMultiStore)(nil)
+var _ ListenableMultiStore = (*rootMultiStore)(nil)
+var _ InterBlockCacheMultiStore = (*rootMultiStore)(nil)
+var _ InstrumentedMultiStore = (*rootMultiStore)(nil)
 
 // nolint
 func NewCommitMultiStore(db dbm.DB) *rootMultiStore {
 	return &rootMultiStore{
 		db:           db,
+		pruning:      sdk.PruneSyncable,
 		storesParams: make(map[StoreKey]storeParams),
 		stores:       make(map[StoreKey]CommitStore),
 		keysByName:   make(map[string]StoreKey),
+		pins:         newVersionPins(),
 	}
 }
 
@@ -90,12 +110,22 @@ func (rs *rootMultiStore) GetCommitKVStore(key StoreKey) CommitKVStore {
 
 // Implements CommitMultiStore.
 func (rs *rootMultiStore) LoadLatestVersion() error {
-	ver := getLatestVersion(rs.db)
-	return rs.LoadVersion(ver)
+	return rs.LoadLatestVersionAndUpgrade(nil)
 }
 
 // Implements CommitMultiStore.
 func (rs *rootMultiStore) LoadVersion(ver int64) error {
+	return rs.LoadVersionAndUpgrade(ver, nil)
+}
+
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) LoadLatestVersionAndUpgrade(upgrades *StoreUpgrades) error {
+	ver := getLatestVersion(rs.db)
+	return rs.LoadVersionAndUpgrade(ver, upgrades)
+}
+
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) LoadVersionAndUpgrade(ver int64, upgrades *StoreUpgrades) error {
 
 	// Special logic for version 0
 	if ver == 0 {
@@ -120,18 +150,39 @@ func (rs *rootMultiStore) LoadVersion(ver int64) error {
 	}
 
 	// Convert StoreInfos slice to map
-	infos := make(map[StoreKey]storeInfo)
+	infos := make(map[string]storeInfo)
 	for _, storeInfo := range cInfo.StoreInfos {
-		infos[rs.nameToKey(storeInfo.Name)] = storeInfo
+		infos[storeInfo.Name] = storeInfo
+	}
+
+	// The upgrades are applied to the version the chain halted at for them,
+	// and were committed with the versions past it
+	if upgrades != nil {
+		switch {
+		case ver < upgrades.Height-1:
+			return fmt.Errorf("cannot load version %d with the store upgrades of height %d, which must be loaded at version %d",
+				ver, upgrades.Height, upgrades.Height-1)
+		case ver == upgrades.Height-1:
+			if err := rs.upgradeStores(ver, infos, *upgrades); err != nil {
+				return fmt.Errorf("failed to upgrade rootMultiStore: %v", err)
+			}
+		}
+	}
+	for name := range infos {
+		if _, ok := rs.keysByName[name]; !ok {
+			return fmt.Errorf("store %s of version %d is not mounted, it must be deleted by a store upgrade", name, ver)
+		}
 	}
 
 	// Load each Store
 	var newStores = make(map[StoreKey]CommitStore)
 	for key, storeParams := range rs.storesParams {
 		var id CommitID
-		info, ok := infos[key]
+		info, ok := infos[key.Name()]
 		if ok {
 			id = info.Core.CommitID
+		} else if storeParams.typ != sdk.StoreTypeTransient {
+			return fmt.Errorf("store %s is not in version %d, it must be added by a store upgrade", key.Name(), ver)
 		}
 
 		store, err := rs.loadCommitStoreFromParams(key, id, storeParams)
@@ -147,6 +198,70 @@ func (rs *rootMultiStore) LoadVersion(ver int64) error {
 	return nil
 }
 
+// upgradeStores applies the upgrades to the stores of version ver, whose
+// store infos are updated to match. The data of the renamed and deleted
+// stores is moved or deleted, and the added stores start empty at version
+// ver so that they commit their first version along with the others.
+//
+// The commit info of version ver is left as is, since its hash was agreed
+// on, and the upgraded stores are only recorded under their new names by
+// the next commit. Until then the upgrades can be applied again, and they
+// are skipped once committed.
+func (rs *rootMultiStore) upgradeStores(ver int64, infos map[string]storeInfo, upgrades StoreUpgrades) error {
+	if err := upgrades.Validate(); err != nil {
+		return err
+	}
+
+	batch := rs.db.NewBatch()
+	for _, name := range upgrades.Deleted {
+		if _, ok := infos[name]; !ok {
+			continue
+		}
+		rs.movePrefix(batch, storePrefix(name), nil)
+		delete(infos, name)
+	}
+	for _, rename := range upgrades.Renamed {
+		info, ok := infos[rename.OldName]
+		if !ok {
+			continue
+		}
+		if _, ok := infos[rename.NewName]; ok {
+			return fmt.Errorf("cannot rename store %s to existing store %s", rename.OldName, rename.NewName)
+		}
+		rs.movePrefix(batch, storePrefix(rename.OldName), storePrefix(rename.NewName))
+		delete(infos, rename.OldName)
+		info.Name = rename.NewName
+		infos[rename.NewName] = info
+	}
+	for _, name := range upgrades.Added {
+		if _, ok := infos[name]; ok {
+			continue
+		}
+		// an empty IAVL root at version ver
+		rs.movePrefix(batch, storePrefix(name), nil)
+		batch.Set(prefixKey(storePrefix(name), iavlRootKey(ver)), []byte{})
+		infos[name] = storeInfo{
+			Name: name,
+			Core: storeCore{CommitID: CommitID{Version: ver}},
+		}
+	}
+	batch.Write()
+	return nil
+}
+
+// movePrefix moves the keys of the DB under prefix from to prefix to, or
+// deletes them if to is nil.
+func (rs *rootMultiStore) movePrefix(batch dbm.Batch, from, to []byte) {
+	it := dbm.IteratePrefix(rs.db, from)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		if to != nil {
+			batch.Set(prefixKey(to, it.Key()[len(from):]), it.Value())
+		}
+		batch.Delete(it.Key())
+	}
+}
+
 // WithTracer sets the tracer for the MultiStore that the underlying
 // stores will utilize to trace operations. A MultiStore is returned.
 func (rs *rootMultiStore) WithTracer(w io.Writer) MultiStore {
@@ -191,6 +306,11 @@ func (rs *rootMultiStore) LastCommitID() CommitID {
 
 // Implements Committer/CommitStore.
 func (rs *rootMultiStore) Commit() CommitID {
+	if rs.metrics != nil {
+		defer func(start time.Time) {
+			rs.metrics.CommitDuration.Observe(time.Since(start).Seconds())
+		}(time.Now())
+	}
 
 	// Commit stores.
 	version := rs.lastCommitID.Version + 1
@@ -208,9 +328,50 @@ func (rs *rootMultiStore) Commit() CommitID {
 		Hash:    commitInfo.Hash(),
 	}
 	rs.lastCommitID = commitID
+
+	for _, listener := range rs.allListeners {
+		if err := listener.OnCommit(commitID); err != nil {
+			panic(fmt.Sprintf("failed to stream the changes at height %d: %v", version, err))
+		}
+	}
 	return commitID
 }
 
+// SetInterBlockCache keeps up to size of the values read from the IAVL
+// stores of the given names cached across blocks, from the next time a
+// version is loaded. A non-positive size disables their caches.
+func (rs *rootMultiStore) SetInterBlockCache(size int, storeNames ...string) {
+	if rs.interBlockCaches == nil {
+		rs.interBlockCaches = make(map[string]int)
+	}
+	for _, name := range storeNames {
+		if size > 0 {
+			rs.interBlockCaches[name] = size
+		} else {
+			delete(rs.interBlockCaches, name)
+		}
+	}
+}
+
+// SetMetrics records the accesses to the stores through the cache
+// multistores of rs, and the commits of rs, in the metrics.
+func (rs *rootMultiStore) SetMetrics(metrics *Metrics) {
+	rs.metrics = metrics
+}
+
+// AddListener registers a listener notified of the changes written to the
+// stores of the given names by the cache multistores of rs, and of the
+// commits of rs. Stores can be listened to before they are mounted.
+func (rs *rootMultiStore) AddListener(listener WriteListener, storeNames ...string) {
+	if rs.listeners == nil {
+		rs.listeners = make(map[string][]WriteListener)
+	}
+	for _, name := range storeNames {
+		rs.listeners[name] = append(rs.listeners[name], listener)
+	}
+	rs.allListeners = append(rs.allListeners, listener)
+}
+
 // Implements CacheWrapper/Store/CommitStore.
 func (rs *rootMultiStore) CacheWrap() CacheWrap {
 	return rs.CacheMultiStore().(CacheWrap)
@@ -328,7 +489,7 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitI
 	if params.db != nil {
 		db = dbm.NewPrefixDB(params.db, []byte("s/_/"))
 	} else {
-		db = dbm.NewPrefixDB(rs.db, []byte("s/k:"+params.key.Name()+"/"))
+		db = dbm.NewPrefixDB(rs.db, storePrefix(params.key.Name()))
 	}
 	switch params.typ {
 	case sdk.StoreTypeMulti:
@@ -337,6 +498,12 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitI
 		// return NewCommitMultiStore(db, id)
 	case sdk.StoreTypeIAVL:
 		store, err = LoadIAVLStore(db, id, rs.pruning)
+		if err == nil {
+			store.(*iavlStore).pins = rs.pins
+		}
+		if size, ok := rs.interBlockCaches[key.Name()]; ok && err == nil {
+			store = newInterBlockCacheStore(store.(CommitKVStore), size)
+		}
 		return
 	case sdk.StoreTypeDB:
 		panic("dbm.DB is not a CommitStore")
@@ -353,13 +520,9 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitI
 	}
 }
 
-func (rs *rootMultiStore) nameToKey(name string) StoreKey {
-	for key := range rs.storesParams {
-		if key.Name() == name {
-			return key
-		}
-	}
-	panic("Unknown name " + name)
+// Returns the prefix of the keys of a store in the DB of the rootMultiStore.
+func storePrefix(name string) []byte {
+	return []byte("s/k:" + name + "/")
 }
 
 //----------------------------------------
@@ -435,6 +598,29 @@ func (si storeInfo) Hash() []byte {
 //----------------------------------------
 // Misc.
 
+// PruneVersions releases the versions of the IAVL stores committed to db
+// which the pruning strategy doesn't keep as of the latest version, e.g. to
+// compact the data of a stopped node.
+func PruneVersions(db dbm.DB, pruning sdk.PruningStrategy) error {
+	latest := getLatestVersion(db)
+	if latest == 0 {
+		return nil
+	}
+	cInfo, err := getCommitInfo(db, latest)
+	if err != nil {
+		return err
+	}
+	for _, info := range cInfo.StoreInfos {
+		tree := iavl.NewMutableTree(dbm.NewPrefixDB(db, storePrefix(info.Name)), defaultIAVLCacheSize)
+		if _, err := tree.LoadVersion(latest); err != nil {
+			return fmt.Errorf("failed to load store %s: %v", info.Name, err)
+		}
+		st := &iavlStore{tree: tree, pruning: pruning}
+		st.prune(latest)
+	}
+	return nil
+}
+
 func getLatestVersion(db dbm.DB) int64 {
 	var latest int64
 	latestBytes := db.Get([]byte(latestVersionKey))
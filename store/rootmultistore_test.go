@@ -99,6 +99,114 @@ func TestMultistoreCommitLoad(t *testing.T) {
 	checkStore(t, store, commitID, commitID)
 }
 
+func TestPruneVersions(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	store.SetPruning(sdk.PruneNothing)
+	require.Nil(t, store.LoadLatestVersion())
+	for i := 0; i < 10; i++ {
+		store.getStoreByName("store1").(KVStore).Set([]byte{byte(i)}, []byte{byte(i)})
+		store.Commit()
+	}
+	commitID := store.LastCommitID()
+
+	// keep the last 3 versions and every 4th
+	err := PruneVersions(db, sdk.NewPruningStrategy(3, 4, 1))
+	require.Nil(t, err)
+
+	store = newMultiStoreWithMounts(db)
+	require.Nil(t, store.LoadLatestVersion())
+	require.Equal(t, commitID, store.LastCommitID())
+	iavlStore := store.getStoreByName("store1").(*iavlStore)
+	for ver := int64(1); ver <= 10; ver++ {
+		kept := ver >= 7 || ver%4 == 0
+		require.Equal(t, kept, iavlStore.VersionExists(ver), "version %d", ver)
+	}
+}
+
+var testStoreUpgrades = StoreUpgrades{
+	Height:  3,
+	Added:   []string{"store4"},
+	Renamed: []StoreRename{{OldName: "store2", NewName: "renamed"}},
+	Deleted: []string{"store3"},
+}
+
+func newUpgradedMultiStore(db dbm.DB) *rootMultiStore {
+	store := NewCommitMultiStore(db)
+	store.MountStoreWithDB(sdk.NewKVStoreKey("store1"), sdk.StoreTypeIAVL, nil)
+	store.MountStoreWithDB(sdk.NewKVStoreKey("renamed"), sdk.StoreTypeIAVL, nil)
+	store.MountStoreWithDB(sdk.NewKVStoreKey("store4"), sdk.StoreTypeIAVL, nil)
+	return store
+}
+
+func TestMultistoreUpgrades(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	require.Nil(t, store.LoadLatestVersion())
+	for _, name := range []string{"store1", "store2", "store3"} {
+		store.getStoreByName(name).(KVStore).Set([]byte("key"), []byte(name))
+	}
+	store.Commit()
+	store.getStoreByName("store2").(KVStore).Set([]byte("key2"), []byte("value2"))
+	commitID := store.Commit()
+	copied := dbm.NewMemDB()
+	it := db.Iterator(nil, nil)
+	for ; it.Valid(); it.Next() {
+		copied.Set(it.Key(), it.Value())
+	}
+	it.Close()
+
+	// the added and removed stores must be upgraded
+	store = newUpgradedMultiStore(db)
+	require.NotNil(t, store.LoadLatestVersion())
+	store = newMultiStoreWithMounts(db)
+	store.MountStoreWithDB(sdk.NewKVStoreKey("store4"), sdk.StoreTypeIAVL, nil)
+	require.NotNil(t, store.LoadLatestVersion())
+
+	// the upgrades only apply to the version preceding their height
+	early := testStoreUpgrades
+	early.Height = commitID.Version + 2
+	store = newUpgradedMultiStore(db)
+	require.NotNil(t, store.LoadLatestVersionAndUpgrade(&early))
+
+	store = newUpgradedMultiStore(db)
+	require.Nil(t, store.LoadLatestVersionAndUpgrade(&testStoreUpgrades))
+	require.Equal(t, commitID, store.LastCommitID())
+	require.Equal(t, []byte("store1"), store.getStoreByName("store1").(KVStore).Get([]byte("key")))
+	renamed := store.getStoreByName("renamed").(KVStore)
+	require.Equal(t, []byte("store2"), renamed.Get([]byte("key")))
+	require.Equal(t, []byte("value2"), renamed.Get([]byte("key2")))
+	require.Nil(t, store.getStoreByName("store4").(KVStore).Get([]byte("key")))
+	for _, name := range []string{"store2", "store3"} {
+		it := dbm.IteratePrefix(db, storePrefix(name))
+		require.False(t, it.Valid(), "data of %s", name)
+		it.Close()
+	}
+
+	// the upgraded stores commit along with the others
+	store.getStoreByName("store4").(KVStore).Set([]byte("key"), []byte("value4"))
+	upgradedID := store.Commit()
+	require.Equal(t, commitID.Version+1, upgradedID.Version)
+	require.Equal(t, upgradedID.Version, store.getStoreByName("store4").(*iavlStore).LastCommitID().Version)
+
+	// committed upgrades are skipped
+	store = newUpgradedMultiStore(db)
+	require.Nil(t, store.LoadLatestVersionAndUpgrade(&testStoreUpgrades))
+	require.Equal(t, upgradedID, store.LastCommitID())
+	require.Equal(t, []byte("value4"), store.getStoreByName("store4").(KVStore).Get([]byte("key")))
+	store = newUpgradedMultiStore(db)
+	require.Nil(t, store.LoadLatestVersion())
+	require.Equal(t, upgradedID, store.LastCommitID())
+
+	// the upgrade commits the same hash when applied again before its commit
+	for i := 0; i < 2; i++ {
+		store = newUpgradedMultiStore(copied)
+		require.Nil(t, store.LoadLatestVersionAndUpgrade(&testStoreUpgrades))
+	}
+	store.getStoreByName("store4").(KVStore).Set([]byte("key"), []byte("value4"))
+	require.Equal(t, upgradedID, store.Commit())
+}
+
 func TestParsePath(t *testing.T) {
 	_, _, err := parsePath("foo")
 	require.Error(t, err)
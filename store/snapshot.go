@@ -0,0 +1,495 @@
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+const (
+	// SnapshotFormat is the version of the encoding of the snapshot chunks.
+	SnapshotFormat uint32 = 1
+
+	// DefaultSnapshotChunkSize is the size in bytes above which a snapshot
+	// chunk is closed.
+	DefaultSnapshotChunkSize = 10 << 20
+)
+
+// Prefixes of the keys of the IAVL nodes, by hash, of the roots, by version,
+// and of the orphaned nodes, by last and first version, in the DB of an IAVL
+// tree.
+var (
+	iavlNodePrefix   = []byte("n")
+	iavlRootPrefix   = []byte("r")
+	iavlOrphanPrefix = []byte("o")
+)
+
+// Snapshotter exports and restores the state of a multistore at a committed
+// height. PinVersion keeps pruning from releasing the version at a height
+// until the returned function is called, e.g. while it is exported in the
+// background.
+type Snapshotter interface {
+	ExportSnapshot(height int64, chunkSize int, writeChunk func(chunk []byte) error) (Snapshot, error)
+	Restore(snapshot Snapshot, loadChunk func(index int) ([]byte, error)) error
+	PinVersion(height int64) (unpin func())
+}
+
+var _ Snapshotter = (*rootMultiStore)(nil)
+
+// Snapshot describes the state of a rootMultiStore at a committed height,
+// exported as a list of chunks.
+type Snapshot struct {
+	Height int64               `json:"height"`
+	Format uint32              `json:"format"`
+	Hash   []byte              `json:"hash"`   // hash of the CommitID
+	Stores []SnapshotStoreInfo `json:"stores"` // in the order of the chunks
+	Chunks [][]byte            `json:"chunks"` // SHA256 hash of each chunk
+}
+
+// SnapshotStoreInfo is the CommitID of a store in a snapshot.
+type SnapshotStoreInfo struct {
+	Name     string   `json:"name"`
+	CommitID CommitID `json:"commit_id"`
+}
+
+// CommitID returns the CommitID the snapshot was taken at.
+func (s Snapshot) CommitID() CommitID {
+	return CommitID{
+		Version: s.Height,
+		Hash:    s.Hash,
+	}
+}
+
+func (s Snapshot) commitInfo() commitInfo {
+	infos := make([]storeInfo, len(s.Stores))
+	for i, store := range s.Stores {
+		infos[i] = storeInfo{Name: store.Name, Core: storeCore{CommitID: store.CommitID}}
+	}
+	return commitInfo{Version: s.Height, StoreInfos: infos}
+}
+
+// snapshotItem is an entry of a snapshot chunk: either the header of a store,
+// followed by its nodes, or a node of the IAVL tree of the current store as
+// persisted in its DB.
+type snapshotItem struct {
+	Store string
+	Node  []byte
+}
+
+//----------------------------------------
+// Export
+
+// ExportSnapshot implements Snapshotter. It exports the stores committed at
+// the given height, or at the latest one if zero. The nodes of each IAVL tree
+// are written in pre-order to chunks of about chunkSize bytes, each passed to
+// writeChunk once closed. The stores must not have been pruned at this
+// height. Only the immutable nodes of the version are read, so new versions
+// can be committed during the export, and the version is pinned until the
+// export is done.
+func (rs *rootMultiStore) ExportSnapshot(height int64, chunkSize int, writeChunk func(chunk []byte) error) (Snapshot, error) {
+	for _, params := range rs.storesParams {
+		if params.db != nil {
+			return Snapshot{}, fmt.Errorf("cannot snapshot store %s mounted with its own DB", params.key.Name())
+		}
+	}
+	if height == 0 {
+		height = getLatestVersion(rs.db)
+	}
+	defer rs.PinVersion(height)()
+
+	cInfo, err := getCommitInfo(rs.db, height)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	snapshot := Snapshot{
+		Height: height,
+		Format: SnapshotFormat,
+		Hash:   cInfo.Hash(),
+	}
+	w := &snapshotChunkWriter{chunkSize: chunkSize, write: func(chunk []byte) error {
+		hash := sha256.Sum256(chunk)
+		snapshot.Chunks = append(snapshot.Chunks, hash[:])
+		return writeChunk(chunk)
+	}}
+
+	for _, info := range cInfo.StoreInfos {
+		snapshot.Stores = append(snapshot.Stores, SnapshotStoreInfo{Name: info.Name, CommitID: info.Core.CommitID})
+
+		db := dbm.NewPrefixDB(rs.db, storePrefix(info.Name))
+		rootKey := iavlRootKey(height)
+		if !db.Has(rootKey) {
+			return Snapshot{}, fmt.Errorf("store %s has no version %d, it may have been pruned", info.Name, height)
+		}
+		if err := w.writeItem(snapshotItem{Store: info.Name}); err != nil {
+			return Snapshot{}, err
+		}
+		if err := exportIAVLNodes(db, db.Get(rootKey), w.writeItem); err != nil {
+			return Snapshot{}, fmt.Errorf("failed to export store %s: %v", info.Name, err)
+		}
+	}
+	if err := w.flush(); err != nil {
+		return Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+// PinVersion implements Snapshotter.
+func (rs *rootMultiStore) PinVersion(height int64) (unpin func()) {
+	rs.pins.pin(height)
+	return func() { rs.pins.unpin(height) }
+}
+
+// versionPins counts the users of each pinned version, which the IAVL stores
+// sharing it don't prune.
+type versionPins struct {
+	mtx  sync.Mutex
+	pins map[int64]int
+}
+
+func newVersionPins() *versionPins {
+	return &versionPins{pins: make(map[int64]int)}
+}
+
+func (vp *versionPins) pin(version int64) {
+	vp.mtx.Lock()
+	defer vp.mtx.Unlock()
+	vp.pins[version]++
+}
+
+func (vp *versionPins) unpin(version int64) {
+	vp.mtx.Lock()
+	defer vp.mtx.Unlock()
+	vp.pins[version]--
+	if vp.pins[version] <= 0 {
+		delete(vp.pins, version)
+	}
+}
+
+// Returns whether the version is pinned. A nil versionPins pins nothing.
+func (vp *versionPins) isPinned(version int64) bool {
+	if vp == nil {
+		return false
+	}
+	vp.mtx.Lock()
+	defer vp.mtx.Unlock()
+	return vp.pins[version] > 0
+}
+
+// Writes the node of the given hash followed by its children.
+func exportIAVLNodes(db dbm.DB, hash []byte, write func(snapshotItem) error) error {
+	if len(hash) == 0 {
+		return nil
+	}
+	bz := db.Get(iavlNodeKey(hash))
+	if bz == nil {
+		return fmt.Errorf("missing IAVL node %X", hash)
+	}
+	node, err := decodeIAVLNode(bz)
+	if err != nil {
+		return err
+	}
+	if err := write(snapshotItem{Node: bz}); err != nil {
+		return err
+	}
+	if node.isLeaf() {
+		return nil
+	}
+	if err := exportIAVLNodes(db, node.leftHash, write); err != nil {
+		return err
+	}
+	return exportIAVLNodes(db, node.rightHash, write)
+}
+
+// snapshotChunkWriter buffers length prefixed items until a chunk is full.
+type snapshotChunkWriter struct {
+	chunkSize int
+	buf       bytes.Buffer
+	write     func(chunk []byte) error
+}
+
+func (w *snapshotChunkWriter) writeItem(item snapshotItem) error {
+	bz, err := cdc.MarshalBinaryBare(item)
+	if err != nil {
+		return err
+	}
+	var prefix [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(prefix[:], uint64(len(bz)))
+	w.buf.Write(prefix[:n])
+	w.buf.Write(bz)
+	if w.buf.Len() >= w.chunkSize {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *snapshotChunkWriter) flush() error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	chunk := make([]byte, w.buf.Len())
+	copy(chunk, w.buf.Bytes())
+	w.buf.Reset()
+	return w.write(chunk)
+}
+
+func decodeSnapshotChunk(chunk []byte) ([]snapshotItem, error) {
+	var items []snapshotItem
+	for len(chunk) > 0 {
+		size, n := binary.Uvarint(chunk)
+		if n <= 0 || uint64(len(chunk)-n) < size {
+			return nil, errors.New("invalid snapshot chunk: truncated item")
+		}
+		var item snapshotItem
+		if err := cdc.UnmarshalBinaryBare(chunk[n:n+int(size)], &item); err != nil {
+			return nil, fmt.Errorf("invalid snapshot chunk: %v", err)
+		}
+		items = append(items, item)
+		chunk = chunk[n+int(size):]
+	}
+	return items, nil
+}
+
+//----------------------------------------
+// Restore
+
+// Restore implements Snapshotter. The rootMultiStore must not have committed
+// any version. Every chunk is checked against its hash, every node against
+// the hash referencing it, and the stores against the snapshot CommitID, so
+// that a restored store holds exactly the state committed at the snapshot
+// height. The mounted stores are then loaded at that height.
+func (rs *rootMultiStore) Restore(snapshot Snapshot, loadChunk func(index int) ([]byte, error)) error {
+	if snapshot.Format != SnapshotFormat {
+		return fmt.Errorf("unsupported snapshot format %d", snapshot.Format)
+	}
+	if getLatestVersion(rs.db) != 0 {
+		return errors.New("cannot restore a snapshot over a store with committed versions")
+	}
+	cInfo := snapshot.commitInfo()
+	if !bytes.Equal(cInfo.Hash(), snapshot.Hash) {
+		return errors.New("snapshot stores don't match its hash")
+	}
+	for _, info := range cInfo.StoreInfos {
+		if _, ok := rs.keysByName[info.Name]; !ok && len(rs.storesParams) > 0 {
+			return fmt.Errorf("snapshot store %s is not mounted", info.Name)
+		}
+	}
+
+	restorer := &iavlRestorer{height: snapshot.Height, stores: cInfo.StoreInfos}
+	for i, hash := range snapshot.Chunks {
+		chunk, err := loadChunk(i)
+		if err != nil {
+			return err
+		}
+		if sum := sha256.Sum256(chunk); !bytes.Equal(sum[:], hash) {
+			return fmt.Errorf("snapshot chunk %d doesn't match its hash", i)
+		}
+		items, err := decodeSnapshotChunk(chunk)
+		if err != nil {
+			return err
+		}
+		batch := rs.db.NewBatch()
+		for _, item := range items {
+			if err := restorer.restore(batch, item); err != nil {
+				return err
+			}
+		}
+		batch.Write()
+	}
+	if err := restorer.finish(); err != nil {
+		return err
+	}
+
+	batch := rs.db.NewBatch()
+	setCommitInfo(batch, snapshot.Height, cInfo)
+	setLatestVersion(batch, snapshot.Height)
+	batch.Write()
+
+	// Offline restores don't mount any store.
+	if len(rs.storesParams) == 0 {
+		rs.lastCommitID = cInfo.CommitID()
+		return nil
+	}
+	if err := rs.LoadVersion(snapshot.Height); err != nil {
+		return err
+	}
+	for _, info := range cInfo.StoreInfos {
+		id := rs.getStoreByName(info.Name).(CommitStore).LastCommitID()
+		if id.Version != snapshot.Height || !bytes.Equal(id.Hash, info.Core.CommitID.Hash) {
+			return fmt.Errorf("restored store %s doesn't match the snapshot: got %v, expected %v",
+				info.Name, id, info.Core.CommitID)
+		}
+	}
+	return nil
+}
+
+// iavlRestorer writes the items of a snapshot to the DB of a rootMultiStore,
+// tracking the nodes referenced by the restored ones.
+type iavlRestorer struct {
+	height int64
+	stores []storeInfo
+
+	// the store being restored and the index of the next one
+	name   string
+	prefix []byte
+	next   int
+
+	// hashes of the nodes referenced but not restored yet
+	pending map[string]bool
+}
+
+func (r *iavlRestorer) restore(batch dbm.Batch, item snapshotItem) error {
+	if item.Store != "" {
+		if err := r.finishStore(); err != nil {
+			return err
+		}
+		if r.next >= len(r.stores) || r.stores[r.next].Name != item.Store {
+			return fmt.Errorf("unexpected store %s in snapshot", item.Store)
+		}
+		root := r.stores[r.next].Core.CommitID.Hash
+		r.name, r.prefix, r.next = item.Store, storePrefix(item.Store), r.next+1
+		r.pending = make(map[string]bool)
+		if len(root) > 0 {
+			r.pending[string(root)] = true
+		}
+		// an empty tree is saved with an empty root
+		batch.Set(prefixKey(r.prefix, iavlRootKey(r.height)), append([]byte{}, root...))
+		return nil
+	}
+
+	if r.prefix == nil {
+		return errors.New("snapshot node without a store")
+	}
+	node, err := decodeIAVLNode(item.Node)
+	if err != nil {
+		return err
+	}
+	hash := node.hash()
+	if !r.pending[string(hash)] {
+		return fmt.Errorf("unexpected IAVL node %X in store %s", hash, r.name)
+	}
+	delete(r.pending, string(hash))
+	if node.version > r.height {
+		return fmt.Errorf("IAVL node %X of store %s has version %d above the snapshot height", hash, r.name, node.version)
+	}
+	if !node.isLeaf() {
+		r.pending[string(node.leftHash)] = true
+		r.pending[string(node.rightHash)] = true
+	}
+	batch.Set(prefixKey(r.prefix, iavlNodeKey(hash)), item.Node)
+	return nil
+}
+
+func (r *iavlRestorer) finishStore() error {
+	if len(r.pending) > 0 {
+		return fmt.Errorf("snapshot is missing %d IAVL nodes of store %s", len(r.pending), r.name)
+	}
+	return nil
+}
+
+func (r *iavlRestorer) finish() error {
+	if err := r.finishStore(); err != nil {
+		return err
+	}
+	if r.next != len(r.stores) {
+		return fmt.Errorf("snapshot is missing %d stores", len(r.stores)-r.next)
+	}
+	return nil
+}
+
+//----------------------------------------
+// IAVL nodes
+
+// iavlNode mirrors a node of an IAVL tree as persisted by iavl, to walk and
+// check the trees without loading them.
+type iavlNode struct {
+	height    int8
+	size      int64
+	version   int64
+	key       []byte
+	value     []byte
+	leftHash  []byte
+	rightHash []byte
+}
+
+func (node iavlNode) isLeaf() bool {
+	return node.height == 0
+}
+
+// Decodes a node from its persisted form: height, size, version, key, then
+// the value of a leaf or the hashes of the children of an inner node.
+func decodeIAVLNode(bz []byte) (node iavlNode, err error) {
+	var n int
+	if node.height, n, err = amino.DecodeInt8(bz); err != nil {
+		return node, fmt.Errorf("invalid IAVL node: %v", err)
+	}
+	bz = bz[n:]
+	if node.size, n, err = amino.DecodeVarint(bz); err != nil {
+		return node, fmt.Errorf("invalid IAVL node: %v", err)
+	}
+	bz = bz[n:]
+	if node.version, n, err = amino.DecodeVarint(bz); err != nil {
+		return node, fmt.Errorf("invalid IAVL node: %v", err)
+	}
+	bz = bz[n:]
+	if node.key, n, err = amino.DecodeByteSlice(bz); err != nil {
+		return node, fmt.Errorf("invalid IAVL node: %v", err)
+	}
+	bz = bz[n:]
+
+	if node.isLeaf() {
+		node.value, n, err = amino.DecodeByteSlice(bz)
+	} else {
+		if node.leftHash, n, err = amino.DecodeByteSlice(bz); err == nil {
+			bz = bz[n:]
+			node.rightHash, n, err = amino.DecodeByteSlice(bz)
+		}
+	}
+	if err != nil {
+		return node, fmt.Errorf("invalid IAVL node: %v", err)
+	}
+	if len(bz) != n {
+		return node, errors.New("invalid IAVL node: trailing bytes")
+	}
+	return node, nil
+}
+
+// Computes the hash of a node as iavl does: the key is only hashed for
+// leaves, with the hash of their value.
+func (node iavlNode) hash() []byte {
+	var buf bytes.Buffer
+	// writing to a buffer doesn't fail
+	_ = amino.EncodeInt8(&buf, node.height)
+	_ = amino.EncodeVarint(&buf, node.size)
+	_ = amino.EncodeVarint(&buf, node.version)
+	if node.isLeaf() {
+		_ = amino.EncodeByteSlice(&buf, node.key)
+		_ = amino.EncodeByteSlice(&buf, tmhash.Sum(node.value))
+	} else {
+		_ = amino.EncodeByteSlice(&buf, node.leftHash)
+		_ = amino.EncodeByteSlice(&buf, node.rightHash)
+	}
+	return tmhash.Sum(buf.Bytes())
+}
+
+func iavlNodeKey(hash []byte) []byte {
+	return prefixKey(iavlNodePrefix, hash)
+}
+
+func iavlRootKey(version int64) []byte {
+	var bz [8]byte
+	binary.BigEndian.PutUint64(bz[:], uint64(version))
+	return prefixKey(iavlRootPrefix, bz[:])
+}
+
+func prefixKey(prefix, key []byte) []byte {
+	res := make([]byte, 0, len(prefix)+len(key))
+	return append(append(res, prefix...), key...)
+}
@@ -0,0 +1,170 @@
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func newSnapshotMultiStore(t *testing.T, db dbm.DB) *rootMultiStore {
+	store := newMultiStoreWithMounts(db)
+	store.MountStoreWithDB(sdk.NewTransientStoreKey("transient"), sdk.StoreTypeTransient, nil)
+	require.NoError(t, store.LoadLatestVersion())
+	return store
+}
+
+func commitSnapshotVersions(store *rootMultiStore, versions int) {
+	for v := 0; v < versions; v++ {
+		for i := 0; i < 100; i++ {
+			key := []byte(fmt.Sprintf("key%03d", i))
+			store.getStoreByName("store1").(KVStore).Set(key, []byte(fmt.Sprintf("value%d-%d", v, i)))
+			if i%3 == 0 {
+				store.getStoreByName("store2").(KVStore).Set(key, key)
+			}
+		}
+		store.getStoreByName("store2").(KVStore).Delete([]byte("key000"))
+		// store3 is left empty
+		store.Commit()
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	snapshots := NewSnapshotStore(dir)
+
+	source := newSnapshotMultiStore(t, dbm.NewMemDB())
+	source.SetPruning(sdk.PruneNothing)
+	commitSnapshotVersions(source, 3)
+	commitID := source.LastCommitID()
+
+	// small chunks split the trees across several of them
+	snapshot, err := snapshots.Create(source, 0, 512)
+	require.NoError(t, err)
+	require.Equal(t, commitID, snapshot.CommitID())
+	require.Len(t, snapshot.Stores, 3)
+	require.True(t, len(snapshot.Chunks) > 1)
+
+	// older versions can be exported as long as they are not pruned
+	_, err = snapshots.Create(source, 2, DefaultSnapshotChunkSize)
+	require.NoError(t, err)
+	_, err = snapshots.Create(source, 2, DefaultSnapshotChunkSize)
+	require.Error(t, err)
+
+	listed, err := snapshots.List()
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	require.Equal(t, int64(3), listed[0].Height)
+	require.Equal(t, int64(2), listed[1].Height)
+
+	target := newSnapshotMultiStore(t, dbm.NewMemDB())
+	require.NoError(t, snapshots.Restore(target, 3))
+	require.Equal(t, commitID, target.LastCommitID())
+	for _, name := range []string{"store1", "store2"} {
+		expected := source.getStoreByName(name).(KVStore).Iterator(nil, nil)
+		got := target.getStoreByName(name).(KVStore).Iterator(nil, nil)
+		for ; expected.Valid(); expected.Next() {
+			require.True(t, got.Valid())
+			require.Equal(t, expected.Key(), got.Key())
+			require.Equal(t, expected.Value(), got.Value())
+			got.Next()
+		}
+		require.False(t, got.Valid())
+		expected.Close()
+		got.Close()
+	}
+
+	// the restored store keeps committing from the snapshot height
+	commitSnapshotVersions(source, 1)
+	commitSnapshotVersions(target, 1)
+	require.Equal(t, source.LastCommitID(), target.LastCommitID())
+
+	// a store can only be restored once
+	require.Error(t, snapshots.Restore(target, 3))
+
+	// stores don't need to be mounted to be restored offline
+	offline := NewCommitMultiStore(dbm.NewMemDB())
+	require.NoError(t, snapshots.Restore(offline, 3))
+	require.Equal(t, commitID, offline.LastCommitID())
+
+	require.NoError(t, snapshots.Prune(1))
+	listed, err = snapshots.List()
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.Equal(t, int64(3), listed[0].Height)
+}
+
+func TestSnapshotRestoreInvalid(t *testing.T) {
+	source := newSnapshotMultiStore(t, dbm.NewMemDB())
+	source.SetPruning(sdk.NewPruningStrategy(0, 0, 1))
+	commitSnapshotVersions(source, 2)
+
+	var chunks [][]byte
+	snapshot, err := source.ExportSnapshot(0, 256, func(chunk []byte) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	require.NoError(t, err)
+	require.True(t, len(chunks) > 2)
+
+	restore := func(snapshot Snapshot, chunks [][]byte) error {
+		target := newSnapshotMultiStore(t, dbm.NewMemDB())
+		return target.Restore(snapshot, func(index int) ([]byte, error) {
+			return chunks[index], nil
+		})
+	}
+	require.NoError(t, restore(snapshot, chunks))
+
+	// a tampered chunk doesn't match its hash
+	tampered := make([][]byte, len(chunks))
+	copy(tampered, chunks)
+	tampered[1] = append([]byte{}, chunks[1]...)
+	tampered[1][len(tampered[1])-1] ^= 0xFF
+	require.Error(t, restore(snapshot, tampered))
+
+	// the trees must be complete
+	forged := snapshot
+	forged.Chunks = snapshot.Chunks[:len(snapshot.Chunks)-1]
+	require.Error(t, restore(forged, chunks))
+
+	// the stores must match the snapshot hash
+	forged = snapshot
+	forged.Hash = []byte("foo")
+	require.Error(t, restore(forged, chunks))
+
+	// pruned versions can't be exported
+	_, err = source.ExportSnapshot(1, 256, func(chunk []byte) error { return nil })
+	require.Error(t, err)
+}
+
+func TestSnapshotPinVersion(t *testing.T) {
+	source := newSnapshotMultiStore(t, dbm.NewMemDB())
+	source.SetPruning(sdk.NewPruningStrategy(0, 0, 1))
+	commitSnapshotVersions(source, 2)
+	iavl := source.getStoreByName("store1").(*iavlStore)
+	require.False(t, iavl.VersionExists(1))
+
+	// neither the pinned version nor the later ones are pruned
+	unpin := source.PinVersion(2)
+	commitSnapshotVersions(source, 3)
+	for v := int64(2); v <= 5; v++ {
+		require.True(t, iavl.VersionExists(v), "version %d", v)
+	}
+	_, err := source.ExportSnapshot(2, 256, func(chunk []byte) error { return nil })
+	require.NoError(t, err)
+
+	// pruning resumes once unpinned
+	unpin()
+	commitSnapshotVersions(source, 1)
+	for v := int64(2); v <= 5; v++ {
+		require.False(t, iavl.VersionExists(v), "version %d", v)
+	}
+	require.True(t, iavl.VersionExists(6))
+}
@@ -0,0 +1,156 @@
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+const snapshotManifestFile = "manifest"
+
+// SnapshotStore keeps the snapshots of a multistore as files under a
+// directory, with a sub-directory per height holding the manifest of the
+// snapshot and its chunks.
+type SnapshotStore struct {
+	dir string
+}
+
+// NewSnapshotStore returns a SnapshotStore keeping its snapshots under dir.
+func NewSnapshotStore(dir string) *SnapshotStore {
+	return &SnapshotStore{dir: dir}
+}
+
+// Create exports a snapshot of the multistore at the given height, or at the
+// latest one if zero. The snapshot is only listed once fully written.
+func (s *SnapshotStore) Create(ms Snapshotter, height int64, chunkSize int) (Snapshot, error) {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return Snapshot{}, err
+	}
+	tmp, err := ioutil.TempDir(s.dir, ".export")
+	if err != nil {
+		return Snapshot{}, err
+	}
+	defer os.RemoveAll(tmp) // nolint: errcheck
+
+	index := 0
+	snapshot, err := ms.ExportSnapshot(height, chunkSize, func(chunk []byte) error {
+		path := filepath.Join(tmp, strconv.Itoa(index))
+		index++
+		return ioutil.WriteFile(path, chunk, 0644)
+	})
+	if err != nil {
+		return Snapshot{}, err
+	}
+	manifest, err := cdc.MarshalBinaryBare(snapshot)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmp, snapshotManifestFile), manifest, 0644); err != nil {
+		return Snapshot{}, err
+	}
+
+	dir := s.path(snapshot.Height)
+	if _, err := os.Stat(dir); err == nil {
+		return Snapshot{}, fmt.Errorf("snapshot at height %d already exists", snapshot.Height)
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+// Get returns the snapshot taken at the given height.
+func (s *SnapshotStore) Get(height int64) (Snapshot, error) {
+	bz, err := ioutil.ReadFile(filepath.Join(s.path(height), snapshotManifestFile))
+	if os.IsNotExist(err) {
+		return Snapshot{}, fmt.Errorf("no snapshot at height %d", height)
+	}
+	if err != nil {
+		return Snapshot{}, err
+	}
+	var snapshot Snapshot
+	if err := cdc.UnmarshalBinaryBare(bz, &snapshot); err != nil {
+		return Snapshot{}, fmt.Errorf("invalid manifest of snapshot at height %d: %v", height, err)
+	}
+	return snapshot, nil
+}
+
+// LoadChunk returns a chunk of the snapshot taken at the given height.
+func (s *SnapshotStore) LoadChunk(height int64, index int) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(s.path(height), strconv.Itoa(index)))
+}
+
+// List returns the snapshots, the most recent first.
+func (s *SnapshotStore) List() ([]Snapshot, error) {
+	heights, err := s.heights()
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]Snapshot, 0, len(heights))
+	for _, height := range heights {
+		snapshot, err := s.Get(height)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// Restore restores the snapshot taken at the given height into the
+// multistore.
+func (s *SnapshotStore) Restore(ms Snapshotter, height int64) error {
+	snapshot, err := s.Get(height)
+	if err != nil {
+		return err
+	}
+	return ms.Restore(snapshot, func(index int) ([]byte, error) {
+		return s.LoadChunk(height, index)
+	})
+}
+
+// Prune deletes all but the keepRecent most recent snapshots. A non-positive
+// keepRecent keeps them all.
+func (s *SnapshotStore) Prune(keepRecent int) error {
+	if keepRecent <= 0 {
+		return nil
+	}
+	heights, err := s.heights()
+	if err != nil {
+		return err
+	}
+	for i := keepRecent; i < len(heights); i++ {
+		if err := os.RemoveAll(s.path(heights[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the heights of the snapshots in decreasing order.
+func (s *SnapshotStore) heights() ([]int64, error) {
+	files, err := ioutil.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var heights []int64
+	for _, file := range files {
+		height, err := strconv.ParseInt(file.Name(), 10, 64)
+		if err != nil || !file.IsDir() {
+			continue
+		}
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+	return heights, nil
+}
+
+func (s *SnapshotStore) path(height int64) string {
+	return filepath.Join(s.dir, strconv.FormatInt(height, 10))
+}
@@ -0,0 +1,139 @@
+package store
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/tendermint/iavl"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VersionedMultiStore is a multistore which can be read at a committed
+// version, e.g. to answer the queries at a past height.
+type VersionedMultiStore interface {
+	CacheMultiStoreWithVersion(version int64) (CacheMultiStore, error)
+}
+
+var _ VersionedMultiStore = (*rootMultiStore)(nil)
+
+// a store which can be read at a committed version
+type versionedStore interface {
+	GetImmutable(version int64) (KVStore, error)
+}
+
+// CacheMultiStoreWithVersion returns a cache multistore of the stores as
+// committed at the given version. Writing to the IAVL stores of the cache
+// multistore panics, and its transient stores are empty. It fails if an
+// IAVL store doesn't have the version, e.g. once it was pruned.
+func (rs *rootMultiStore) CacheMultiStoreWithVersion(version int64) (CacheMultiStore, error) {
+	cms := cacheMultiStore{
+		db:         NewCacheKVStore(dbStoreAdapter{rs.db}),
+		stores:     make(map[StoreKey]CacheWrap, len(rs.stores)),
+		keysByName: rs.keysByName,
+		metrics:    rs.metrics,
+	}
+
+	for key, store := range rs.stores {
+		switch store := store.(type) {
+		case versionedStore:
+			immutable, err := store.GetImmutable(version)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load store %s at version %d: %v", key.Name(), version, err)
+			}
+			cms.stores[key] = immutable.CacheWrap()
+		case *transientStore:
+			cms.stores[key] = newTransientStore().CacheWrap()
+		default:
+			return nil, fmt.Errorf("store %s can't be loaded at version %d", key.Name(), version)
+		}
+	}
+
+	return cms, nil
+}
+
+// GetImmutable returns a read-only store of the tree committed at the given
+// version.
+func (st *iavlStore) GetImmutable(version int64) (KVStore, error) {
+	tree, err := st.tree.GetImmutable(version)
+	if err != nil {
+		return nil, err
+	}
+	return immutableIAVLStore{tree}, nil
+}
+
+// GetImmutable returns a read-only store of the parent committed at the given
+// version. Past versions aren't cached.
+func (ibc *interBlockCacheStore) GetImmutable(version int64) (KVStore, error) {
+	parent, ok := ibc.parent.(versionedStore)
+	if !ok {
+		return nil, fmt.Errorf("store can't be loaded at version %d", version)
+	}
+	return parent.GetImmutable(version)
+}
+
+//----------------------------------------
+
+var _ KVStore = immutableIAVLStore{}
+
+// immutableIAVLStore is a read-only KVStore of an IAVL tree at a committed
+// version. Writing to it panics.
+type immutableIAVLStore struct {
+	tree *iavl.ImmutableTree
+}
+
+// Implements Store.
+func (st immutableIAVLStore) GetStoreType() StoreType {
+	return sdk.StoreTypeIAVL
+}
+
+// Implements Store.
+func (st immutableIAVLStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(st)
+}
+
+// CacheWrapWithTrace implements the Store interface.
+func (st immutableIAVLStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(st, w, tc))
+}
+
+// Implements KVStore.
+func (st immutableIAVLStore) Get(key []byte) (value []byte) {
+	_, v := st.tree.Get(key)
+	return v
+}
+
+// Implements KVStore.
+func (st immutableIAVLStore) Has(key []byte) (exists bool) {
+	return st.tree.Has(key)
+}
+
+// Implements KVStore.
+func (st immutableIAVLStore) Set(key, value []byte) {
+	panic("cannot write to a committed version of an IAVL store")
+}
+
+// Implements KVStore.
+func (st immutableIAVLStore) Delete(key []byte) {
+	panic("cannot write to a committed version of an IAVL store")
+}
+
+// Implements KVStore
+func (st immutableIAVLStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{st, prefix}
+}
+
+// Implements KVStore
+func (st immutableIAVLStore) Gas(meter GasMeter, config GasConfig) KVStore {
+	return NewGasKVStore(meter, config, st)
+}
+
+// Implements KVStore.
+func (st immutableIAVLStore) Iterator(start, end []byte) Iterator {
+	return newIAVLIterator(st.tree, start, end, true)
+}
+
+// Implements KVStore.
+func (st immutableIAVLStore) ReverseIterator(start, end []byte) Iterator {
+	return newIAVLIterator(st.tree, start, end, false)
+}
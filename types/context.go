@@ -47,7 +47,10 @@ func NewContext(ms MultiStore, header abci.Header, isCheckTx bool, logger log.Lo
 	c = c.WithLogger(logger)
 	c = c.WithVoteInfos(nil)
 	c = c.WithGasMeter(NewInfiniteGasMeter())
-	c = c.WithMinimumFees(Coins{})
+	c = c.WithBlockGasMeter(NewInfiniteGasMeter())
+	c = c.WithMinGasPrices(DecCoins{})
+	c = c.WithFeeWeights(FeeWeights{})
+	c = c.WithEventManager(NewEventManager())
 	return c
 }
 
@@ -140,7 +143,10 @@ const (
 	contextKeyLogger
 	contextKeyVoteInfos
 	contextKeyGasMeter
-	contextKeyMinimumFees
+	contextKeyBlockGasMeter
+	contextKeyMinGasPrices
+	contextKeyFeeWeights
+	contextKeyEventManager
 )
 
 // NOTE: Do not expose MultiStore.
@@ -170,9 +176,20 @@ func (c Context) VoteInfos() []abci.VoteInfo {
 
 func (c Context) GasMeter() GasMeter { return c.Value(contextKeyGasMeter).(GasMeter) }
 
+// BlockGasMeter returns the meter of the gas used by the txs of the block so far.
+func (c Context) BlockGasMeter() GasMeter { return c.Value(contextKeyBlockGasMeter).(GasMeter) }
+
 func (c Context) IsCheckTx() bool { return c.Value(contextKeyIsCheckTx).(bool) }
 
-func (c Context) MinimumFees() Coins { return c.Value(contextKeyMinimumFees).(Coins) }
+func (c Context) MinGasPrices() DecCoins { return c.Value(contextKeyMinGasPrices).(DecCoins) }
+
+func (c Context) FeeWeights() FeeWeights { return c.Value(contextKeyFeeWeights).(FeeWeights) }
+
+// EventManager returns the manager collecting the events emitted in the
+// context.
+func (c Context) EventManager() *EventManager {
+	return c.Value(contextKeyEventManager).(*EventManager)
+}
 
 func (c Context) WithMultiStore(ms MultiStore) Context { return c.withValue(contextKeyMultiStore, ms) }
 
@@ -219,12 +236,24 @@ func (c Context) WithVoteInfos(VoteInfos []abci.VoteInfo) Context {
 
 func (c Context) WithGasMeter(meter GasMeter) Context { return c.withValue(contextKeyGasMeter, meter) }
 
+func (c Context) WithBlockGasMeter(meter GasMeter) Context {
+	return c.withValue(contextKeyBlockGasMeter, meter)
+}
+
 func (c Context) WithIsCheckTx(isCheckTx bool) Context {
 	return c.withValue(contextKeyIsCheckTx, isCheckTx)
 }
 
-func (c Context) WithMinimumFees(minFees Coins) Context {
-	return c.withValue(contextKeyMinimumFees, minFees)
+func (c Context) WithMinGasPrices(gasPrices DecCoins) Context {
+	return c.withValue(contextKeyMinGasPrices, gasPrices)
+}
+
+func (c Context) WithFeeWeights(weights FeeWeights) Context {
+	return c.withValue(contextKeyFeeWeights, weights)
+}
+
+func (c Context) WithEventManager(em *EventManager) Context {
+	return c.withValue(contextKeyEventManager, em)
 }
 
 // Cache the multistore and return a new cached context. The cached context is
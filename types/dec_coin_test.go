@@ -0,0 +1,85 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlusDecCoin(t *testing.T) {
+	decCoinA1 := DecCoin{"A", NewDecWithPrec(11, 1)}
+	decCoinA2 := DecCoin{"A", NewDecWithPrec(22, 1)}
+	decCoinB1 := DecCoin{"B", NewDecWithPrec(11, 1)}
+
+	// regular add
+	res := decCoinA1.Plus(decCoinA1)
+	require.Equal(t, decCoinA2, res, "sum of coins is incorrect")
+
+	// bad denom add
+	assert.Panics(t, func() {
+		decCoinA1.Plus(decCoinB1)
+	}, "expected panic on sum of different denoms")
+
+}
+
+func TestPlusDecCoins(t *testing.T) {
+	one := NewDec(1)
+	zero := NewDec(0)
+	negone := NewDec(-1)
+	two := NewDec(2)
+
+	cases := []struct {
+		inputOne DecCoins
+		inputTwo DecCoins
+		expected DecCoins
+	}{
+		{DecCoins{{"A", one}, {"B", one}}, DecCoins{{"A", one}, {"B", one}}, DecCoins{{"A", two}, {"B", two}}},
+		{DecCoins{{"A", zero}, {"B", one}}, DecCoins{{"A", zero}, {"B", zero}}, DecCoins{{"B", one}}},
+		{DecCoins{{"A", zero}, {"B", zero}}, DecCoins{{"A", zero}, {"B", zero}}, DecCoins(nil)},
+		{DecCoins{{"A", one}, {"B", zero}}, DecCoins{{"A", negone}, {"B", zero}}, DecCoins(nil)},
+		{DecCoins{{"A", negone}, {"B", zero}}, DecCoins{{"A", zero}, {"B", zero}}, DecCoins{{"A", negone}}},
+	}
+
+	for tcIndex, tc := range cases {
+		res := tc.inputOne.Plus(tc.inputTwo)
+		require.Equal(t, tc.expected, res, "sum of coins is incorrect, tc #%d", tcIndex)
+	}
+}
+
+func TestParseDecCoins(t *testing.T) {
+	cases := []struct {
+		input    string
+		valid    bool
+		expected DecCoins
+	}{
+		{"", true, nil},
+		{"1steak", true, DecCoins{{"steak", NewDec(1)}}},
+		{"0.025steak", true, DecCoins{{"steak", NewDecWithPrec(25, 3)}}},
+		{"0.025steak, 0.0001 photino", true, DecCoins{{"photino", NewDecWithPrec(1, 4)}, {"steak", NewDecWithPrec(25, 3)}}},
+		{"0steak", false, nil},
+		{".5steak", false, nil},
+		{"0.5", false, nil},
+		{"0.5steak,1steak", false, nil},
+		{"0.00000000001steak", false, nil},
+	}
+
+	for tcIndex, tc := range cases {
+		res, err := ParseDecCoins(tc.input)
+		if !tc.valid {
+			require.NotNil(t, err, "%s: %#v. tc #%d", tc.input, res, tcIndex)
+		} else if assert.Nil(t, err, "%s: %+v", tc.input, err) {
+			require.Equal(t, tc.expected, res, "coin parsing was incorrect, tc #%d", tcIndex)
+		}
+	}
+}
+
+func TestDecCoinsAmountOf(t *testing.T) {
+	coins := DecCoins{{"photino", NewDecWithPrec(1, 4)}, {"steak", NewDecWithPrec(25, 3)}}
+
+	require.True(t, NewDecWithPrec(25, 3).Equal(coins.AmountOf("steak")))
+	require.True(t, ZeroDec().Equal(coins.AmountOf("atom")))
+	require.False(t, coins.IsZero())
+	require.True(t, DecCoins{}.IsZero())
+	require.Equal(t, "0.0001000000photino,0.0250000000steak", coins.String())
+}
@@ -338,6 +338,13 @@ func (d Dec) RoundInt64() int64 {
 	return chopped.Int64()
 }
 
+// Float64 returns the nearest float64 to the decimal, e.g. for metrics, as
+// it loses precision.
+func (d Dec) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(d.Int, precisionReuse).Float64()
+	return f
+}
+
 // RoundInt round the decimal using bankers rounding
 func (d Dec) RoundInt() Int {
 	return NewIntFromBigInt(chopPrecisionAndRoundNonMutative(d.Int))
@@ -369,6 +376,17 @@ func (d Dec) TruncateInt() Int {
 	return NewIntFromBigInt(chopPrecisionAndTruncateNonMutative(d.Int))
 }
 
+// Ceil returns the smallest integer value, as a decimal, that is greater
+// than or equal to the decimal
+func (d Dec) Ceil() Dec {
+	tmp := new(big.Int).Set(d.Int)
+	quo, rem := tmp.QuoRem(tmp, precisionReuse, big.NewInt(0))
+	if rem.Sign() > 0 {
+		quo.Add(quo, oneInt)
+	}
+	return NewDecFromBigInt(quo)
+}
+
 //___________________________________________________________________________________
 
 // reuse nil values
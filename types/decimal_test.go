@@ -230,6 +230,26 @@ func TestTruncate(t *testing.T) {
 	}
 }
 
+func TestCeil(t *testing.T) {
+	tests := []struct {
+		d1  Dec
+		exp Dec
+	}{
+		{mustNewDecFromStr(t, "0"), mustNewDecFromStr(t, "0")},
+		{mustNewDecFromStr(t, "0.0000000001"), mustNewDecFromStr(t, "1")},
+		{mustNewDecFromStr(t, "0.25"), mustNewDecFromStr(t, "1")},
+		{mustNewDecFromStr(t, "1"), mustNewDecFromStr(t, "1")},
+		{mustNewDecFromStr(t, "7.5"), mustNewDecFromStr(t, "8")},
+		{mustNewDecFromStr(t, "-0.25"), mustNewDecFromStr(t, "0")},
+		{mustNewDecFromStr(t, "-7.5"), mustNewDecFromStr(t, "-7")},
+	}
+
+	for tcIndex, tc := range tests {
+		res := tc.d1.Ceil()
+		require.True(t, tc.exp.Equal(res), "tc %d, expected %v got %v", tcIndex, tc.exp, res)
+	}
+}
+
 var cdc = codec.New()
 
 func TestDecMarshalJSON(t *testing.T) {
@@ -364,3 +384,19 @@ func TestDecMulInt(t *testing.T) {
 		require.Equal(t, tc.want, got, "Incorrect result on test case %d", i)
 	}
 }
+
+func TestDecFloat64(t *testing.T) {
+	tests := []struct {
+		d    Dec
+		want float64
+	}{
+		{ZeroDec(), 0},
+		{NewDec(3), 3},
+		{NewDecWithPrec(13, 2), 0.13},
+		{NewDecWithPrec(-25, 1), -2.5},
+		{NewDecWithPrec(1, Precision), 1e-10},
+	}
+	for i, tc := range tests {
+		require.Equal(t, tc.want, tc.d.Float64(), "Incorrect result on test case %d", i)
+	}
+}
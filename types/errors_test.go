@@ -21,6 +21,8 @@ var codeTypes = []CodeType{
 	CodeInvalidCoins,
 	CodeOutOfGas,
 	CodeMemoTooLarge,
+	CodeInsufficientFee,
+	CodeTooManySignatures,
 }
 
 type errFn func(msg string) Error
@@ -39,6 +41,8 @@ var errFns = []errFn{
 	ErrInvalidCoins,
 	ErrOutOfGas,
 	ErrMemoTooLarge,
+	ErrInsufficientFee,
+	ErrTooManySignatures,
 }
 
 func TestCodeType(t *testing.T) {
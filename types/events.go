@@ -0,0 +1,150 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// EventManager collects the events emitted while running a msg or a block
+// hook. It is shared by the contexts derived from the one it was set on.
+type EventManager struct {
+	events Events
+}
+
+// NewEventManager returns an EventManager without any event.
+func NewEventManager() *EventManager {
+	return &EventManager{EmptyEvents()}
+}
+
+// Events returns the events emitted so far, in emission order.
+func (em *EventManager) Events() Events { return em.events }
+
+// EmitEvent emits an event.
+func (em *EventManager) EmitEvent(event Event) {
+	em.events = em.events.AppendEvent(event)
+}
+
+// EmitEvents emits events, in order.
+func (em *EventManager) EmitEvents(events Events) {
+	em.events = em.events.AppendEvents(events)
+}
+
+//__________________________________________________
+
+// Attribute is a key-value pair describing an event.
+type Attribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// NewAttribute returns an attribute of the given key and value.
+func NewAttribute(k, v string) Attribute {
+	return Attribute{Key: k, Value: v}
+}
+
+// String implements the Stringer interface.
+func (a Attribute) String() string {
+	return fmt.Sprintf("%s: %s", a.Key, a.Value)
+}
+
+// Event is a typed occurrence, e.g. a transfer or a vote, described by its
+// attributes.
+type Event struct {
+	Type       string      `json:"type"`
+	Attributes []Attribute `json:"attributes"`
+}
+
+// NewEvent returns an event of the given type and attributes.
+func NewEvent(ty string, attrs ...Attribute) Event {
+	return Event{Type: ty, Attributes: attrs}
+}
+
+// AppendAttributes returns the event with the attributes appended.
+func (e Event) AppendAttributes(attrs ...Attribute) Event {
+	e.Attributes = append(e.Attributes, attrs...)
+	return e
+}
+
+// Events is a list of events, in emission order.
+type Events []Event
+
+// EmptyEvents returns an empty list of events.
+func EmptyEvents() Events {
+	return make(Events, 0)
+}
+
+// AppendEvent returns the events with the event appended.
+func (e Events) AppendEvent(event Event) Events {
+	return append(e, event)
+}
+
+// AppendEvents returns the events with the other events appended.
+func (e Events) AppendEvents(events Events) Events {
+	return append(e, events...)
+}
+
+// ToTags flattens the events into the tags indexed by Tendermint, one for
+// each attribute, keyed by the event type and the attribute key joined by a
+// dot, e.g. "transfer.recipient", so that txs can be searched by them.
+func (e Events) ToTags() Tags {
+	tags := EmptyTags()
+	for _, event := range e {
+		for _, attr := range event.Attributes {
+			tags = tags.AppendTag(fmt.Sprintf("%s.%s", event.Type, attr.Key), []byte(attr.Value))
+		}
+	}
+	return tags
+}
+
+// String implements the Stringer interface.
+func (e Events) String() string {
+	var b strings.Builder
+	for _, event := range e {
+		b.WriteString(fmt.Sprintf("%s\n", event.Type))
+		for _, attr := range event.Attributes {
+			b.WriteString(fmt.Sprintf("  - %s\n", attr))
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// common event types and attribute keys
+var (
+	// EventTypeMessage is emitted by BaseApp for every msg it runs, with its
+	// action and the attributes of the msg added by its handler
+	EventTypeMessage = "message"
+
+	AttributeKeyAction = "action"
+	AttributeKeyModule = "module"
+	AttributeKeySender = "sender"
+)
+
+//__________________________________________________
+
+// ABCIMessageLog is the log of a msg of a tx, with the events it emitted.
+type ABCIMessageLog struct {
+	MsgIndex int    `json:"msg_index"`
+	Success  bool   `json:"success"`
+	Log      string `json:"log"`
+	Events   Events `json:"events"`
+}
+
+// ABCIMessageLogs are the logs of the msgs of a tx, in order.
+type ABCIMessageLogs []ABCIMessageLog
+
+// String returns the logs as JSON, which BaseApp sets as the log of the tx.
+func (logs ABCIMessageLogs) String() string {
+	bz, err := json.Marshal(logs)
+	if err != nil {
+		panic(err)
+	}
+	return string(bz)
+}
+
+// ParseABCIMessageLogs parses the JSON log of a tx set by BaseApp into the
+// logs of its msgs.
+func ParseABCIMessageLogs(log string) (logs ABCIMessageLogs, err error) {
+	err = json.Unmarshal([]byte(log), &logs)
+	return logs, err
+}
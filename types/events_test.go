@@ -0,0 +1,51 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestEventManager(t *testing.T) {
+	em := NewEventManager()
+	require.Equal(t, Events{}, em.Events())
+
+	transfer := NewEvent("transfer", NewAttribute("recipient", "foo"))
+	vote := NewEvent("vote", NewAttribute("proposal-id", "1"), NewAttribute("option", "yes"))
+	em.EmitEvent(transfer)
+	em.EmitEvents(Events{vote})
+	require.Equal(t, Events{transfer, vote}, em.Events())
+
+	// the contexts derived from one share its event manager
+	ctx := NewContext(nil, abci.Header{}, false, nil).WithEventManager(em)
+	ctx.WithChainID("test").EventManager().EmitEvent(transfer)
+	require.Equal(t, Events{transfer, vote, transfer}, ctx.EventManager().Events())
+}
+
+func TestEventsToTags(t *testing.T) {
+	events := Events{
+		NewEvent("transfer", NewAttribute("sender", "foo"), NewAttribute("recipient", "bar")),
+		NewEvent("message").AppendAttributes(NewAttribute("action", "send")),
+		NewEvent("empty"),
+	}
+	require.Equal(t, Tags{
+		MakeTag("transfer.sender", []byte("foo")),
+		MakeTag("transfer.recipient", []byte("bar")),
+		MakeTag("message.action", []byte("send")),
+	}, events.ToTags())
+	require.Equal(t, Tags{}, EmptyEvents().ToTags())
+}
+
+func TestABCIMessageLogs(t *testing.T) {
+	logs := ABCIMessageLogs{
+		{MsgIndex: 0, Success: true, Log: "", Events: Events{NewEvent("transfer", NewAttribute("sender", "foo"))}},
+		{MsgIndex: 1, Success: false, Log: "insufficient funds", Events: EmptyEvents()},
+	}
+	parsed, err := ParseABCIMessageLogs(logs.String())
+	require.Nil(t, err)
+	require.Equal(t, logs, parsed)
+
+	_, err = ParseABCIMessageLogs("Msg 0: not JSON")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"fmt"
+)
+
+// FeeWeights maps fee denominations to the value of one unit of them when
+// computing the effective gas price of a tx. When weights are set, fees paid
+// in other denominations do not count towards the price; when none are set,
+// every denomination has a weight of one.
+type FeeWeights map[string]Dec
+
+// ParseFeeWeights parses a list of weighted denominations separated by commas,
+// eg. "1steak,0.5photino". If nothing is provided, it returns empty weights.
+func ParseFeeWeights(weightsStr string) (FeeWeights, error) {
+	coins, err := ParseDecCoins(weightsStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fee weights: %s", err.Error())
+	}
+	weights := make(FeeWeights, len(coins))
+	for _, coin := range coins {
+		weights[coin.Denom] = coin.Amount
+	}
+	return weights, nil
+}
+
+// EffectiveGasPrice returns the weighted amount of the fee paid per unit of
+// gas. A fee paying for no gas has a zero price.
+func (weights FeeWeights) EffectiveGasPrice(fee Coins, gas int64) Dec {
+	price := ZeroDec()
+	if gas <= 0 {
+		return price
+	}
+	for _, coin := range fee {
+		weight, ok := weights[coin.Denom]
+		if !ok {
+			if len(weights) > 0 {
+				continue
+			}
+			weight = OneDec()
+		}
+		price = price.Add(weight.MulInt(coin.Amount))
+	}
+	return price.QuoInt(NewInt(gas))
+}
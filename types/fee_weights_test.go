@@ -0,0 +1,61 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFeeWeights(t *testing.T) {
+	cases := []struct {
+		input    string
+		valid    bool
+		expected FeeWeights
+	}{
+		{"", true, FeeWeights{}},
+		{"1steak", true, FeeWeights{"steak": NewDec(1)}},
+		{"1steak, 0.5photino", true, FeeWeights{"steak": NewDec(1), "photino": NewDecWithPrec(5, 1)}},
+		{"0.25 steak", true, FeeWeights{"steak": NewDecWithPrec(25, 2)}},
+		{"steak", false, nil},
+		{"1.steak", false, nil},
+		{"-1steak", false, nil},
+		{"1steak,2steak", false, nil},
+		{"1st", false, nil},
+	}
+
+	for i, tc := range cases {
+		weights, err := ParseFeeWeights(tc.input)
+		if !tc.valid {
+			require.NotNil(t, err, "%s: %#v. tc #%d", tc.input, weights, i)
+			continue
+		}
+		require.Nil(t, err, "%s: %+v. tc #%d", tc.input, err, i)
+		require.Equal(t, len(tc.expected), len(weights), "tc #%d", i)
+		for denom, weight := range tc.expected {
+			require.True(t, weight.Equal(weights[denom]), "tc #%d, denom %s", i, denom)
+		}
+	}
+}
+
+func TestEffectiveGasPrice(t *testing.T) {
+	fee := Coins{NewInt64Coin("photino", 100), NewInt64Coin("steak", 50)}
+
+	cases := []struct {
+		weights  FeeWeights
+		fee      Coins
+		gas      int64
+		expected Dec
+	}{
+		{FeeWeights{}, fee, 100, NewDecWithPrec(15, 1)},
+		{FeeWeights{"steak": NewDec(1)}, fee, 100, NewDecWithPrec(5, 1)},
+		{FeeWeights{"steak": NewDec(2), "photino": NewDecWithPrec(5, 1)}, fee, 100, NewDecWithPrec(15, 1)},
+		{FeeWeights{"atom": NewDec(1)}, fee, 100, ZeroDec()},
+		{FeeWeights{}, Coins{}, 100, ZeroDec()},
+		{FeeWeights{}, fee, 0, ZeroDec()},
+	}
+
+	for i, tc := range cases {
+		price := tc.weights.EffectiveGasPrice(tc.fee, tc.gas)
+		require.True(t, tc.expected.Equal(price), "tc #%d, expected %v got %v", i, tc.expected, price)
+	}
+}
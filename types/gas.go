@@ -29,6 +29,8 @@ type ErrorOutOfGas struct {
 type GasMeter interface {
 	GasConsumed() Gas
 	ConsumeGas(amount Gas, descriptor string)
+	Limit() Gas
+	IsOutOfGas() bool
 }
 
 type basicGasMeter struct {
@@ -55,6 +57,15 @@ func (g *basicGasMeter) ConsumeGas(amount Gas, descriptor string) {
 	}
 }
 
+func (g *basicGasMeter) Limit() Gas {
+	return g.limit
+}
+
+// IsOutOfGas returns whether all the gas up to the limit was consumed.
+func (g *basicGasMeter) IsOutOfGas() bool {
+	return g.consumed >= g.limit
+}
+
 type infiniteGasMeter struct {
 	consumed Gas
 }
@@ -74,6 +85,15 @@ func (g *infiniteGasMeter) ConsumeGas(amount Gas, descriptor string) {
 	g.consumed += amount
 }
 
+// Limit returns 0, as the meter has no limit.
+func (g *infiniteGasMeter) Limit() Gas {
+	return 0
+}
+
+func (g *infiniteGasMeter) IsOutOfGas() bool {
+	return false
+}
+
 // GasConfig defines gas cost for each operation on KVStores
 type GasConfig struct {
 	HasCost          Gas
@@ -29,6 +29,8 @@ func TestGasMeter(t *testing.T) {
 			require.Equal(t, used, meter.GasConsumed(), "Gas consumption not match. tc #%d, usage #%d", tcnum, unum)
 		}
 
+		require.Equal(t, tc.limit, meter.Limit(), "Limit not match. tc #%d", tcnum)
+		require.True(t, meter.IsOutOfGas(), "Limit reached but not out of gas. tc #%d", tcnum)
 		require.Panics(t, func() { meter.ConsumeGas(1, "") }, "Exceeded but not panicked. tc #%d", tcnum)
 		break
 
@@ -6,3 +6,26 @@ type Handler func(ctx Context, msg Msg) Result
 // AnteHandler authenticates transactions, before their internal messages are handled.
 // If newCtx.IsZero(), ctx is used instead.
 type AnteHandler func(ctx Context, tx Tx, simulate bool) (newCtx Context, result Result, abort bool)
+
+// AnteDecorator is a step of an AnteHandler. It may check or update the
+// context and the tx before calling the next step, stop the chain by
+// aborting instead, and update the result of the next steps.
+type AnteDecorator interface {
+	AnteHandle(ctx Context, tx Tx, simulate bool, next AnteHandler) (newCtx Context, result Result, abort bool)
+}
+
+// ChainAnteDecorators returns an AnteHandler running the decorators in the
+// given order, each one being passed the rest of the chain as the next
+// AnteHandler. The end of the chain returns its context and an empty result.
+func ChainAnteDecorators(chain ...AnteDecorator) AnteHandler {
+	handler := AnteHandler(func(ctx Context, tx Tx, simulate bool) (Context, Result, bool) {
+		return ctx, Result{}, false
+	})
+	for i := len(chain) - 1; i >= 0; i-- {
+		decorator, next := chain[i], handler
+		handler = func(ctx Context, tx Tx, simulate bool) (Context, Result, bool) {
+			return decorator.AnteHandle(ctx, tx, simulate, next)
+		}
+	}
+	return handler
+}
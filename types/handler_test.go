@@ -0,0 +1,45 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// appends its name to the data of the result of the next decorators, or
+// aborts the chain
+type testAnteDecorator struct {
+	name  string
+	abort bool
+}
+
+func (d testAnteDecorator) AnteHandle(ctx Context, tx Tx, simulate bool, next AnteHandler) (Context, Result, bool) {
+	if d.abort {
+		return ctx, ErrUnauthorized(d.name).Result(), true
+	}
+	newCtx, res, abort := next(ctx, tx, simulate)
+	res.Data = append(res.Data, d.name...)
+	return newCtx, res, abort
+}
+
+func TestChainAnteDecorators(t *testing.T) {
+	ctx := NewContext(nil, abci.Header{}, false, nil)
+
+	_, res, abort := ChainAnteDecorators()(ctx, nil, false)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+
+	_, res, abort = ChainAnteDecorators(
+		testAnteDecorator{name: "a"}, testAnteDecorator{name: "b"}, testAnteDecorator{name: "c"},
+	)(ctx, nil, false)
+	require.False(t, abort)
+	require.Equal(t, "cba", string(res.Data))
+
+	_, res, abort = ChainAnteDecorators(
+		testAnteDecorator{name: "a"}, testAnteDecorator{name: "b", abort: true}, testAnteDecorator{name: "c"},
+	)(ctx, nil, false)
+	require.True(t, abort)
+	require.Equal(t, ToABCICode(CodespaceRoot, CodeUnauthorized), res.Code)
+	require.Equal(t, "a", string(res.Data))
+}
@@ -1,6 +1,96 @@
 package types
 
-import abci "github.com/tendermint/tendermint/abci/types"
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
 
 // Type for querier functions on keepers to implement to handle custom queries
 type Querier = func(ctx Context, path []string, req abci.RequestQuery) (res []byte, err Error)
+
+const (
+	// DefaultPageLimit is the number of results per page clients ask for by
+	// default, and queriers return for the zero PageRequest.
+	DefaultPageLimit = 100
+
+	// MaxPageLimit is the largest number of results per page queriers return.
+	MaxPageLimit = 1000
+)
+
+// PageRequest asks a querier for a page of the results of a query, counting
+// from page 1. The zero PageRequest asks for all the results.
+type PageRequest struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+}
+
+// NewPageRequest returns a PageRequest for the given page of limit results.
+func NewPageRequest(page, limit int) PageRequest {
+	return PageRequest{Page: page, Limit: limit}
+}
+
+// ValidateBasic checks that the page and the limit are both positive, or
+// both zero.
+func (p PageRequest) ValidateBasic() Error {
+	if p.Page == 0 && p.Limit == 0 {
+		return nil
+	}
+	if p.Page < 1 || p.Limit < 1 {
+		return ErrUnknownRequest("page and limit must be positive")
+	}
+	return nil
+}
+
+// QuerierPage validates a PageRequest received by a querier and returns the
+// page to serve. Unlike the keepers, queriers never return all the results:
+// the zero PageRequest is the first page of DefaultPageLimit results and
+// limits above MaxPageLimit are rejected.
+func (p PageRequest) QuerierPage() (PageRequest, Error) {
+	if err := p.ValidateBasic(); err != nil {
+		return p, err
+	}
+	if p.Page == 0 && p.Limit == 0 {
+		return NewPageRequest(1, DefaultPageLimit), nil
+	}
+	if p.Limit > MaxPageLimit {
+		return p, ErrUnknownRequest(fmt.Sprintf("limit must not be greater than %d", MaxPageLimit))
+	}
+	return p, nil
+}
+
+// Contains returns whether the result of the given index, counting from
+// zero, is on the page.
+func (p PageRequest) Contains(index int) bool {
+	if p.Limit <= 0 {
+		return true
+	}
+	start := (p.Page - 1) * p.Limit
+	return index >= start && index < start+p.Limit
+}
+
+// Done returns whether the result of the given index, counting from zero,
+// comes after the page, so that the results can stop being iterated over.
+func (p PageRequest) Done(index int) bool {
+	if p.Limit <= 0 {
+		return false
+	}
+	return index >= p.Page*p.Limit
+}
+
+// Bounds returns the bounds [start, end) of the page in a list of total
+// results. They are equal if the page is past the results.
+func (p PageRequest) Bounds(total int) (start, end int) {
+	if p.Limit <= 0 {
+		return 0, total
+	}
+	start = (p.Page - 1) * p.Limit
+	if start > total {
+		start = total
+	}
+	end = start + p.Limit
+	if end > total {
+		end = total
+	}
+	return start, end
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageRequest(t *testing.T) {
+	require.Nil(t, PageRequest{}.ValidateBasic())
+	require.Nil(t, NewPageRequest(1, 10).ValidateBasic())
+	require.NotNil(t, NewPageRequest(0, 10).ValidateBasic())
+	require.NotNil(t, NewPageRequest(1, -1).ValidateBasic())
+
+	// queriers serve the first page for the zero request and cap the limit
+	page, err := PageRequest{}.QuerierPage()
+	require.Nil(t, err)
+	require.Equal(t, NewPageRequest(1, DefaultPageLimit), page)
+	page, err = NewPageRequest(3, MaxPageLimit).QuerierPage()
+	require.Nil(t, err)
+	require.Equal(t, NewPageRequest(3, MaxPageLimit), page)
+	_, err = NewPageRequest(1, MaxPageLimit+1).QuerierPage()
+	require.NotNil(t, err)
+	_, err = NewPageRequest(0, 10).QuerierPage()
+	require.NotNil(t, err)
+
+	// the second page of 10 results holds the results 10 to 19
+	page = NewPageRequest(2, 10)
+	require.False(t, page.Contains(9))
+	require.True(t, page.Contains(10))
+	require.True(t, page.Contains(19))
+	require.False(t, page.Contains(20))
+	require.False(t, page.Done(19))
+	require.True(t, page.Done(20))
+
+	start, end := page.Bounds(25)
+	require.Equal(t, []int{10, 20}, []int{start, end})
+	start, end = page.Bounds(15)
+	require.Equal(t, []int{10, 15}, []int{start, end})
+	start, end = page.Bounds(5)
+	require.Equal(t, []int{5, 5}, []int{start, end})
+
+	// all the results are on the zero page
+	require.True(t, PageRequest{}.Contains(1000))
+	require.False(t, PageRequest{}.Done(1000))
+	start, end = PageRequest{}.Bounds(25)
+	require.Equal(t, []int{0, 25}, []int{start, end})
+}
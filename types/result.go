@@ -22,8 +22,16 @@ type Result struct {
 	FeeAmount int64
 	FeeDenom  string
 
+	// Priority orders the tx in the local mempool, it is set by the
+	// AnteHandler on CheckTx from the gas price paid by the tx.
+	Priority int64
+
 	// Tags are used for transaction indexing and pubsub.
 	Tags Tags
+
+	// Events are the typed events emitted by the msg handlers, indexed as
+	// tags too.
+	Events Events
 }
 
 // TODO: In the future, more codes may be OK.
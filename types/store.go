@@ -1,6 +1,7 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 	"io"
 
@@ -11,20 +12,67 @@ import (
 
 // NOTE: These are implemented in cosmos-sdk/store.
 
-// PruningStrategy specfies how old states will be deleted over time
-type PruningStrategy uint8
+// PruningStrategy specifies how old states will be deleted over time. The
+// KeepRecent most recent versions are kept, as well as every KeepEvery-th
+// version, and the other versions are deleted in batches every Interval
+// commits. A KeepEvery of 1 keeps every version and an Interval of 0 never
+// prunes.
+type PruningStrategy struct {
+	KeepRecent int64
+	KeepEvery  int64
+	Interval   int64
+}
 
-const (
+var (
 	// PruneSyncable means only those states not needed for state syncing will be deleted (keeps last 100 + every 10000th)
-	PruneSyncable PruningStrategy = iota
+	PruneSyncable = NewPruningStrategy(100, 10000, 10)
 
 	// PruneEverything means all saved states will be deleted, storing only the current state
-	PruneEverything PruningStrategy = iota
+	PruneEverything = NewPruningStrategy(0, 0, 10)
 
 	// PruneNothing means all historic states will be saved, nothing will be deleted
-	PruneNothing PruningStrategy = iota
+	PruneNothing = NewPruningStrategy(0, 1, 0)
 )
 
+// NewPruningStrategy returns a PruningStrategy keeping the keepRecent most
+// recent versions and every keepEvery-th version, pruning the other ones
+// every interval commits.
+func NewPruningStrategy(keepRecent, keepEvery, interval int64) PruningStrategy {
+	return PruningStrategy{
+		KeepRecent: keepRecent,
+		KeepEvery:  keepEvery,
+		Interval:   interval,
+	}
+}
+
+// NewPruningStrategyFromString returns the pruning strategy of the given name:
+// syncable, nothing or everything.
+func NewPruningStrategyFromString(strategy string) (PruningStrategy, error) {
+	switch strategy {
+	case "syncable":
+		return PruneSyncable, nil
+	case "nothing":
+		return PruneNothing, nil
+	case "everything":
+		return PruneEverything, nil
+	default:
+		return PruningStrategy{}, fmt.Errorf("invalid pruning strategy: %s", strategy)
+	}
+}
+
+// Validate returns an error if any value of the pruning strategy is negative.
+func (ps PruningStrategy) Validate() error {
+	if ps.KeepRecent < 0 || ps.KeepEvery < 0 || ps.Interval < 0 {
+		return fmt.Errorf("invalid pruning strategy: negative value in %+v", ps)
+	}
+	return nil
+}
+
+// KeepVersion returns whether the version is kept regardless of its age.
+func (ps PruningStrategy) KeepVersion(version int64) bool {
+	return ps.KeepEvery != 0 && version%ps.KeepEvery == 0
+}
+
 type Store interface { //nolint
 	GetStoreType() StoreType
 	CacheWrapper
@@ -112,6 +160,72 @@ type CommitMultiStore interface {
 	// the next commit after loading must be idempotent (return the
 	// same commit id).  Otherwise the behavior is undefined.
 	LoadVersion(ver int64) error
+
+	// Load the latest persisted version, applying the store upgrades to
+	// it. Upgrades that were already committed are skipped.
+	LoadLatestVersionAndUpgrade(upgrades *StoreUpgrades) error
+
+	// Load a specific persisted version, applying the store upgrades to it
+	// if it precedes their height. Loading an earlier version fails.
+	LoadVersionAndUpgrade(ver int64, upgrades *StoreUpgrades) error
+}
+
+// StoreUpgrades describes the stores added, renamed and deleted when loading
+// a version, e.g. at the height a chain adds or removes a module. The
+// stores are given by name, and the added and renamed ones must be mounted.
+//
+// Height is the height of the software upgrade planned with x/upgrade
+// which the stores are upgraded at: the upgrades are applied to the version
+// committed just before it, the one the chain halted at.
+type StoreUpgrades struct {
+	Height  int64         `json:"height"`
+	Added   []string      `json:"added"`
+	Renamed []StoreRename `json:"renamed"`
+	Deleted []string      `json:"deleted"`
+}
+
+// StoreRename renames a store, keeping its data.
+type StoreRename struct {
+	OldName string `json:"old_name"`
+	NewName string `json:"new_name"`
+}
+
+// Validate returns an error if the height isn't positive, or if a store
+// name is empty or upgraded twice.
+func (su StoreUpgrades) Validate() error {
+	if su.Height < 1 {
+		return fmt.Errorf("invalid store upgrades: height %d must be positive", su.Height)
+	}
+	names := make(map[string]bool)
+	use := func(name string) error {
+		if name == "" {
+			return errors.New("invalid store upgrades: empty store name")
+		}
+		if names[name] {
+			return fmt.Errorf("invalid store upgrades: store %s is upgraded twice", name)
+		}
+		names[name] = true
+		return nil
+	}
+	for _, name := range su.Added {
+		if err := use(name); err != nil {
+			return err
+		}
+	}
+	for _, rename := range su.Renamed {
+		if err := use(rename.OldName); err != nil {
+			return err
+		}
+		if err := use(rename.NewName); err != nil {
+			return err
+		}
+	}
+	for _, name := range su.Deleted {
+		if err := use(name); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 //---------subsp-------------------------------
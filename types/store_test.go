@@ -37,3 +37,35 @@ func TestCommitID(t *testing.T) {
 	}
 	require.False(t, nonempty.IsZero())
 }
+
+func TestPruningStrategy(t *testing.T) {
+	pruning, err := NewPruningStrategyFromString("nothing")
+	require.Nil(t, err)
+	require.Equal(t, PruneNothing, pruning)
+	_, err = NewPruningStrategyFromString("custom")
+	require.NotNil(t, err)
+
+	require.True(t, PruneNothing.KeepVersion(7))
+	require.False(t, PruneEverything.KeepVersion(7))
+	require.True(t, PruneSyncable.KeepVersion(20000))
+	require.False(t, PruneSyncable.KeepVersion(20001))
+
+	require.Nil(t, NewPruningStrategy(5, 0, 1).Validate())
+	require.NotNil(t, NewPruningStrategy(5, -1, 1).Validate())
+}
+
+func TestStoreUpgradesValidate(t *testing.T) {
+	upgrades := StoreUpgrades{
+		Height:  10,
+		Added:   []string{"gov"},
+		Renamed: []StoreRename{{OldName: "stake", NewName: "staking"}},
+		Deleted: []string{"ibc"},
+	}
+	require.Nil(t, upgrades.Validate())
+	require.Nil(t, StoreUpgrades{Height: 1}.Validate())
+
+	require.NotNil(t, StoreUpgrades{}.Validate())
+	require.NotNil(t, StoreUpgrades{Height: 1, Added: []string{""}}.Validate())
+	require.NotNil(t, StoreUpgrades{Height: 1, Added: []string{"gov"}, Deleted: []string{"gov"}}.Validate())
+	require.NotNil(t, StoreUpgrades{Height: 1, Renamed: []StoreRename{{OldName: "gov", NewName: "gov"}}}.Validate())
+}
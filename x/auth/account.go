@@ -2,6 +2,7 @@ package auth
 
 import (
 	"errors"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -121,6 +122,273 @@ func (acc *BaseAccount) SetSequence(seq int64) error {
 	return nil
 }
 
+//-----------------------------------------------------------
+// Vesting Accounts
+
+// VestingAccount defines an account type that vests coins via a vesting schedule.
+// Locked coins may be delegated but not spent.
+type VestingAccount interface {
+	Account
+
+	// SpendableCoins returns the coins that can be transferred out of the
+	// account at the given block time.
+	SpendableCoins(blockTime time.Time) sdk.Coins
+
+	// Delegation and undelegation accounting which also updates the base coins.
+	TrackDelegation(blockTime time.Time, amount sdk.Coins)
+	TrackUndelegation(amount sdk.Coins)
+
+	GetVestedCoins(blockTime time.Time) sdk.Coins
+	GetVestingCoins(blockTime time.Time) sdk.Coins
+
+	GetStartTime() int64
+	GetEndTime() int64
+
+	GetOriginalVesting() sdk.Coins
+	GetDelegatedFree() sdk.Coins
+	GetDelegatedVesting() sdk.Coins
+}
+
+// BaseVestingAccount implements the bookkeeping shared by all vesting
+// account types: the original vesting amount and the vesting/free split of
+// the delegated coins. Times are expressed in unix seconds.
+type BaseVestingAccount struct {
+	BaseAccount
+
+	OriginalVesting  sdk.Coins `json:"original_vesting"`  // coins in account upon initialization
+	DelegatedFree    sdk.Coins `json:"delegated_free"`    // coins that are vested and delegated
+	DelegatedVesting sdk.Coins `json:"delegated_vesting"` // coins that are vesting and delegated
+
+	EndTime int64 `json:"end_time"` // when the coins become unlocked
+}
+
+// spendableCoins returns the spendable coins of a vesting account given the
+// amount of coins which are still vesting, i.e. for every denomination
+// min((coins + delegated vesting) - vesting, coins).
+func (bva BaseVestingAccount) spendableCoins(vestingCoins sdk.Coins) sdk.Coins {
+	var spendableCoins sdk.Coins
+	for _, coin := range bva.Coins {
+		vestingAmt := vestingCoins.AmountOf(coin.Denom)
+		delVestingAmt := bva.DelegatedVesting.AmountOf(coin.Denom)
+
+		spendable := sdk.MinInt(coin.Amount.Add(delVestingAmt).Sub(vestingAmt), coin.Amount)
+		if spendable.Sign() > 0 {
+			spendableCoins = spendableCoins.Plus(sdk.Coins{sdk.NewCoin(coin.Denom, spendable)})
+		}
+	}
+	return spendableCoins
+}
+
+// trackDelegation removes the delegated amount from the base coins and
+// records which part of it was still vesting, i.e. for every denomination
+// delegated vesting += min(max(vesting - delegated vesting, 0), amount)
+// and delegated free += the remainder.
+func (bva *BaseVestingAccount) trackDelegation(vestingCoins, amount sdk.Coins) {
+	for _, coin := range amount {
+		if !coin.IsPositive() || bva.Coins.AmountOf(coin.Denom).LT(coin.Amount) {
+			panic("delegation attempt with zero coins or insufficient funds")
+		}
+
+		vestingAmt := vestingCoins.AmountOf(coin.Denom)
+		delVestingAmt := bva.DelegatedVesting.AmountOf(coin.Denom)
+
+		x := vestingAmt.Sub(delVestingAmt)
+		if x.Sign() < 0 {
+			x = sdk.ZeroInt()
+		}
+		x = sdk.MinInt(x, coin.Amount)
+		y := coin.Amount.Sub(x)
+
+		if !x.IsZero() {
+			bva.DelegatedVesting = bva.DelegatedVesting.Plus(sdk.Coins{sdk.NewCoin(coin.Denom, x)})
+		}
+		if !y.IsZero() {
+			bva.DelegatedFree = bva.DelegatedFree.Plus(sdk.Coins{sdk.NewCoin(coin.Denom, y)})
+		}
+		bva.Coins = bva.Coins.Minus(sdk.Coins{coin})
+	}
+}
+
+// TrackUndelegation returns the undelegated amount to the base coins,
+// releasing delegated free coins first and delegated vesting coins second.
+// Implements VestingAccount.
+func (bva *BaseVestingAccount) TrackUndelegation(amount sdk.Coins) {
+	for _, coin := range amount {
+		if !coin.IsPositive() {
+			panic("undelegation attempt with zero coins")
+		}
+
+		delegatedFree := bva.DelegatedFree.AmountOf(coin.Denom)
+		delegatedVesting := bva.DelegatedVesting.AmountOf(coin.Denom)
+
+		// slashing may have burned part of the delegation, hence the minimums
+		x := sdk.MinInt(delegatedFree, coin.Amount)
+		y := sdk.MinInt(delegatedVesting, coin.Amount.Sub(x))
+
+		if !x.IsZero() {
+			bva.DelegatedFree = bva.DelegatedFree.Minus(sdk.Coins{sdk.NewCoin(coin.Denom, x)})
+		}
+		if !y.IsZero() {
+			bva.DelegatedVesting = bva.DelegatedVesting.Minus(sdk.Coins{sdk.NewCoin(coin.Denom, y)})
+		}
+		bva.Coins = bva.Coins.Plus(sdk.Coins{coin})
+	}
+}
+
+// Implements VestingAccount.
+func (bva BaseVestingAccount) GetOriginalVesting() sdk.Coins {
+	return bva.OriginalVesting
+}
+
+// Implements VestingAccount.
+func (bva BaseVestingAccount) GetDelegatedFree() sdk.Coins {
+	return bva.DelegatedFree
+}
+
+// Implements VestingAccount.
+func (bva BaseVestingAccount) GetDelegatedVesting() sdk.Coins {
+	return bva.DelegatedVesting
+}
+
+// Implements VestingAccount.
+func (bva BaseVestingAccount) GetEndTime() int64 {
+	return bva.EndTime
+}
+
+var _ VestingAccount = (*ContinuousVestingAccount)(nil)
+
+// ContinuousVestingAccount vests its original coins linearly between
+// StartTime and EndTime.
+type ContinuousVestingAccount struct {
+	BaseVestingAccount
+
+	StartTime int64 `json:"start_time"` // when the coins start to vest
+}
+
+// NewContinuousVestingAccount returns a new ContinuousVestingAccount vesting
+// all the coins of the given base account.
+func NewContinuousVestingAccount(acc *BaseAccount, startTime, endTime int64) *ContinuousVestingAccount {
+	return &ContinuousVestingAccount{
+		BaseVestingAccount: BaseVestingAccount{
+			BaseAccount:     *acc,
+			OriginalVesting: acc.Coins,
+			EndTime:         endTime,
+		},
+		StartTime: startTime,
+	}
+}
+
+// GetVestedCoins returns the total number of vested coins. If no coins are
+// vested, nil is returned.
+func (cva ContinuousVestingAccount) GetVestedCoins(blockTime time.Time) sdk.Coins {
+	var vestedCoins sdk.Coins
+
+	// the start time may be set in the future or be unknown at genesis
+	if blockTime.Unix() <= cva.StartTime {
+		return vestedCoins
+	} else if blockTime.Unix() >= cva.EndTime {
+		return cva.OriginalVesting
+	}
+
+	// calculate the vesting scalar
+	x := blockTime.Unix() - cva.StartTime
+	y := cva.EndTime - cva.StartTime
+	s := sdk.NewDec(x).Quo(sdk.NewDec(y))
+
+	for _, ovc := range cva.OriginalVesting {
+		vestedAmt := sdk.NewDecFromInt(ovc.Amount).Mul(s).TruncateInt()
+		if !vestedAmt.IsZero() {
+			vestedCoins = append(vestedCoins, sdk.NewCoin(ovc.Denom, vestedAmt))
+		}
+	}
+
+	return vestedCoins
+}
+
+// GetVestingCoins returns the total number of vesting coins. If no coins are
+// vesting, nil is returned.
+func (cva ContinuousVestingAccount) GetVestingCoins(blockTime time.Time) sdk.Coins {
+	return cva.OriginalVesting.Minus(cva.GetVestedCoins(blockTime))
+}
+
+// SpendableCoins returns the total number of spendable coins per denom.
+func (cva ContinuousVestingAccount) SpendableCoins(blockTime time.Time) sdk.Coins {
+	return cva.spendableCoins(cva.GetVestingCoins(blockTime))
+}
+
+// TrackDelegation tracks a desired delegation amount by setting the
+// appropriate values for the amount of delegated vesting, delegated free, and
+// reducing the overall amount of base coins.
+func (cva *ContinuousVestingAccount) TrackDelegation(blockTime time.Time, amount sdk.Coins) {
+	cva.trackDelegation(cva.GetVestingCoins(blockTime), amount)
+}
+
+// Implements VestingAccount.
+func (cva ContinuousVestingAccount) GetStartTime() int64 {
+	return cva.StartTime
+}
+
+var _ VestingAccount = (*DelayedVestingAccount)(nil)
+
+// DelayedVestingAccount vests all of its original coins at once at EndTime.
+type DelayedVestingAccount struct {
+	BaseVestingAccount
+}
+
+// NewDelayedVestingAccount returns a new DelayedVestingAccount locking all the
+// coins of the given base account until endTime.
+func NewDelayedVestingAccount(acc *BaseAccount, endTime int64) *DelayedVestingAccount {
+	return &DelayedVestingAccount{
+		BaseVestingAccount: BaseVestingAccount{
+			BaseAccount:     *acc,
+			OriginalVesting: acc.Coins,
+			EndTime:         endTime,
+		},
+	}
+}
+
+// GetVestedCoins returns the total amount of vested coins for a delayed vesting
+// account. All coins are only vested once the schedule has elapsed.
+func (dva DelayedVestingAccount) GetVestedCoins(blockTime time.Time) sdk.Coins {
+	if blockTime.Unix() >= dva.EndTime {
+		return dva.OriginalVesting
+	}
+	return nil
+}
+
+// GetVestingCoins returns the total number of vesting coins for a delayed
+// vesting account.
+func (dva DelayedVestingAccount) GetVestingCoins(blockTime time.Time) sdk.Coins {
+	return dva.OriginalVesting.Minus(dva.GetVestedCoins(blockTime))
+}
+
+// SpendableCoins returns the total number of spendable coins for a delayed
+// vesting account.
+func (dva DelayedVestingAccount) SpendableCoins(blockTime time.Time) sdk.Coins {
+	return dva.spendableCoins(dva.GetVestingCoins(blockTime))
+}
+
+// TrackDelegation tracks a desired delegation amount by setting the
+// appropriate values for the amount of delegated vesting, delegated free, and
+// reducing the overall amount of base coins.
+func (dva *DelayedVestingAccount) TrackDelegation(blockTime time.Time, amount sdk.Coins) {
+	dva.trackDelegation(dva.GetVestingCoins(blockTime), amount)
+}
+
+// GetStartTime returns zero since a delayed vesting account has no start time.
+func (dva DelayedVestingAccount) GetStartTime() int64 {
+	return 0
+}
+
+// SpendableCoins returns the coins of the account which are not locked by a
+// vesting schedule at the given block time.
+func SpendableCoins(acc Account, blockTime time.Time) sdk.Coins {
+	if vacc, ok := acc.(VestingAccount); ok {
+		return vacc.SpendableCoins(blockTime)
+	}
+	return acc.GetCoins()
+}
+
 //----------------------------------------
 // Wire
 
@@ -128,5 +396,7 @@ func (acc *BaseAccount) SetSequence(seq int64) error {
 func RegisterBaseAccount(cdc *codec.Codec) {
 	cdc.RegisterInterface((*Account)(nil), nil)
 	cdc.RegisterConcrete(&BaseAccount{}, "cosmos-sdk/BaseAccount", nil)
+	cdc.RegisterConcrete(&ContinuousVestingAccount{}, "cosmos-sdk/ContinuousVestingAccount", nil)
+	cdc.RegisterConcrete(&DelayedVestingAccount{}, "cosmos-sdk/DelayedVestingAccount", nil)
 	codec.RegisterCrypto(cdc)
 }
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -106,3 +107,99 @@ func TestBaseAccountMarshal(t *testing.T) {
 	err = cdc.UnmarshalBinary(b[:len(b)/2], &acc2)
 	require.NotNil(t, err)
 }
+
+func TestContinuousVestingAccount(t *testing.T) {
+	now := time.Now()
+	endTime := now.Add(24 * time.Hour)
+
+	_, _, addr := keyPubAddr()
+	origCoins := sdk.Coins{sdk.NewInt64Coin("fee", 1000), sdk.NewInt64Coin("stake", 100)}
+	bacc := NewBaseAccountWithAddress(addr)
+	bacc.SetCoins(origCoins)
+	cva := NewContinuousVestingAccount(&bacc, now.Unix(), endTime.Unix())
+
+	// require no coins vested or spendable at the start of the vesting schedule
+	require.Nil(t, cva.GetVestedCoins(now))
+	require.Equal(t, origCoins, cva.GetVestingCoins(now))
+	require.Nil(t, cva.SpendableCoins(now))
+
+	// require half of the coins to be spendable half way through the schedule
+	halfway := now.Add(12 * time.Hour)
+	halfCoins := sdk.Coins{sdk.NewInt64Coin("fee", 500), sdk.NewInt64Coin("stake", 50)}
+	require.Equal(t, halfCoins, cva.GetVestedCoins(halfway))
+	require.Equal(t, halfCoins, cva.SpendableCoins(halfway))
+
+	// require all coins to be spendable at the end of the schedule
+	require.Equal(t, origCoins, cva.GetVestedCoins(endTime))
+	require.Equal(t, origCoins, cva.SpendableCoins(endTime))
+
+	// delegating vesting coins must not unlock anything
+	cva.TrackDelegation(now, sdk.Coins{sdk.NewInt64Coin("stake", 100)})
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("stake", 100)}, cva.GetDelegatedVesting())
+	require.Nil(t, cva.GetDelegatedFree())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 1000)}, cva.GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 500)}, cva.SpendableCoins(halfway))
+
+	// undelegating returns the coins to the account, still locked
+	cva.TrackUndelegation(sdk.Coins{sdk.NewInt64Coin("stake", 100)})
+	require.Nil(t, cva.GetDelegatedVesting())
+	require.Equal(t, origCoins, cva.GetCoins())
+	require.Nil(t, cva.SpendableCoins(now))
+}
+
+func TestDelayedVestingAccount(t *testing.T) {
+	now := time.Now()
+	endTime := now.Add(24 * time.Hour)
+
+	_, _, addr := keyPubAddr()
+	origCoins := sdk.Coins{sdk.NewInt64Coin("fee", 1000), sdk.NewInt64Coin("stake", 100)}
+	bacc := NewBaseAccountWithAddress(addr)
+	bacc.SetCoins(origCoins)
+	dva := NewDelayedVestingAccount(&bacc, endTime.Unix())
+
+	// require no coins to be spendable before the end of the schedule
+	require.Nil(t, dva.SpendableCoins(now))
+	require.Nil(t, dva.SpendableCoins(now.Add(12*time.Hour)))
+	require.Equal(t, origCoins, dva.SpendableCoins(endTime))
+
+	// received coins are spendable right away
+	dva.SetCoins(dva.GetCoins().Plus(sdk.Coins{sdk.NewInt64Coin("fee", 50)}))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 50)}, dva.SpendableCoins(now))
+
+	// delegating more than the vesting amount delegates free coins
+	dva.TrackDelegation(now, sdk.Coins{sdk.NewInt64Coin("fee", 1050)})
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 1000)}, dva.GetDelegatedVesting())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 50)}, dva.GetDelegatedFree())
+
+	// free coins are released first on undelegation
+	dva.TrackUndelegation(sdk.Coins{sdk.NewInt64Coin("fee", 50)})
+	require.Nil(t, dva.GetDelegatedFree())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 1000)}, dva.GetDelegatedVesting())
+}
+
+func TestVestingAccountMarshal(t *testing.T) {
+	_, pub, addr := keyPubAddr()
+	bacc := NewBaseAccountWithAddress(addr)
+	bacc.SetCoins(sdk.Coins{sdk.NewInt64Coin("stake", 100)})
+	bacc.SetPubKey(pub)
+	cva := NewContinuousVestingAccount(&bacc, 100, 200)
+
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+
+	b, err := cdc.MarshalBinaryBare(Account(cva))
+	require.Nil(t, err)
+
+	var acc Account
+	err = cdc.UnmarshalBinaryBare(b, &acc)
+	require.Nil(t, err)
+
+	vacc, ok := acc.(*ContinuousVestingAccount)
+	require.True(t, ok)
+	require.Equal(t, cva.GetAddress(), vacc.GetAddress())
+	require.Equal(t, cva.GetPubKey(), vacc.GetPubKey())
+	require.True(t, cva.GetCoins().IsEqual(vacc.GetCoins()))
+	require.True(t, cva.GetOriginalVesting().IsEqual(vacc.GetOriginalVesting()))
+	require.Equal(t, cva.GetStartTime(), vacc.GetStartTime())
+	require.Equal(t, cva.GetEndTime(), vacc.GetEndTime())
+}
@@ -4,118 +4,424 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"math"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/multisig"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
 const (
-	memoCostPerByte     sdk.Gas = 1
-	ed25519VerifyCost           = 59
-	secp256k1VerifyCost         = 100
-	maxMemoCharacters           = 100
-	// how much gas = 1 atom
-	gasPerUnitCost = 1000
+	memoCostPerByte sdk.Gas = 1
+	// size of a DER encoded secp256k1 signature, the largest of the supported
+	// ones, with its amino prefix; used to estimate the size of the empty
+	// signatures of simulated txs
+	simSignatureSize = 74
+	// scale of the mempool priority, so that gas prices below one unit per
+	// gas still order txs
+	priorityPrecision = 1000000
 )
 
 // NewAnteHandler returns an AnteHandler that checks
 // and increments sequence numbers, checks signatures & account numbers,
 // and deducts fees from the first signer.
 func NewAnteHandler(am AccountKeeper, fck FeeCollectionKeeper) sdk.AnteHandler {
-	return func(
-		ctx sdk.Context, tx sdk.Tx, simulate bool,
-	) (newCtx sdk.Context, res sdk.Result, abort bool) {
-
-		// This AnteHandler requires Txs to be StdTxs
-		stdTx, ok := tx.(StdTx)
-		if !ok {
-			return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
-		}
+	return sdk.ChainAnteDecorators(NewAnteDecorators(am, fck)...)
+}
+
+// NewAnteDecorators returns the steps of the AnteHandler returned by
+// NewAnteHandler, in order. Apps can insert, replace or drop steps before
+// chaining them with sdk.ChainAnteDecorators.
+func NewAnteDecorators(am AccountKeeper, fck FeeCollectionKeeper) []sdk.AnteDecorator {
+	return []sdk.AnteDecorator{
+		NewSetUpGasMeterDecorator(), // must be first to recover from out of gas panics
+		NewValidateBasicDecorator(am),
+		NewMempoolFeeDecorator(),
+		NewConsumeTxSizeGasDecorator(am),
+		NewSetPubKeyDecorator(am),
+		NewSigVerificationDecorator(am),
+		NewIncrementSequenceDecorator(am),
+		NewDeductFeeDecorator(am, fck),
+	}
+}
 
-		// Ensure that the provided fees meet a minimum threshold for the validator, if this is a CheckTx.
-		// This is only for local mempool purposes, and thus is only ran on check tx.
-		if ctx.IsCheckTx() && !simulate {
-			res := ensureSufficientMempoolFees(ctx, stdTx)
-			if !res.IsOK() {
-				return newCtx, res, true
+// SetUpGasMeterDecorator sets the gas meter of the tx, limited by the gas
+// of its fee, and reports out of gas panics of the next steps.
+type SetUpGasMeterDecorator struct{}
+
+func NewSetUpGasMeterDecorator() SetUpGasMeterDecorator {
+	return SetUpGasMeterDecorator{}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (sgmd SetUpGasMeterDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+
+	// This AnteHandler requires Txs to be StdTxs
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	newCtx = setGasMeter(simulate, ctx, stdTx)
+
+	// AnteHandlers must have their own defer/recover in order
+	// for the BaseApp to know how much gas was used!
+	// This is because the GasMeter is created in the AnteHandler,
+	// but if it panics the context won't be set properly in runTx's recover ...
+	defer func() {
+		if r := recover(); r != nil {
+			switch rType := r.(type) {
+			case sdk.ErrorOutOfGas:
+				log := fmt.Sprintf("out of gas in location: %v", rType.Descriptor)
+				res = sdk.ErrOutOfGas(log).Result()
+				res.GasWanted = stdTx.Fee.Gas
+				res.GasUsed = newCtx.GasMeter().GasConsumed()
+				abort = true
+			default:
+				panic(r)
 			}
 		}
+	}()
+
+	newCtx, res, abort = next(newCtx, tx, simulate)
+	if !abort {
+		res.GasWanted = stdTx.Fee.Gas
+	}
+	return newCtx, res, abort
+}
+
+// ValidateBasicDecorator checks that the tx has a signature per signer and
+// that its memo is within the auth params, without looking at the accounts.
+type ValidateBasicDecorator struct {
+	am AccountKeeper
+}
+
+func NewValidateBasicDecorator(am AccountKeeper) ValidateBasicDecorator {
+	return ValidateBasicDecorator{am: am}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (vbd ValidateBasicDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	err := validateBasic(stdTx, getParams(ctx, vbd.am))
+	if err != nil {
+		return ctx, err.Result(), true
+	}
+	return next(ctx, tx, simulate)
+}
+
+// MempoolFeeDecorator ensures that the provided fees meet a minimum threshold
+// for the validator, and sets the mempool priority of the tx. This is only
+// for local mempool purposes, and thus is only ran on check tx.
+type MempoolFeeDecorator struct{}
+
+func NewMempoolFeeDecorator() MempoolFeeDecorator {
+	return MempoolFeeDecorator{}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (mfd MempoolFeeDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	if !ctx.IsCheckTx() || simulate {
+		return next(ctx, tx, simulate)
+	}
+
+	res = ensureSufficientMempoolFees(ctx, stdTx)
+	if !res.IsOK() {
+		return ctx, res, true
+	}
+	priority := mempoolPriority(ctx, stdTx)
+
+	newCtx, res, abort = next(ctx, tx, simulate)
+	if !abort {
+		res.Priority = priority
+	}
+	return newCtx, res, abort
+}
+
+// ConsumeTxSizeGasDecorator charges gas for the size of the tx and of its
+// memo.
+type ConsumeTxSizeGasDecorator struct {
+	am AccountKeeper
+}
 
-		newCtx = setGasMeter(simulate, ctx, stdTx)
-
-		// AnteHandlers must have their own defer/recover in order
-		// for the BaseApp to know how much gas was used!
-		// This is because the GasMeter is created in the AnteHandler,
-		// but if it panics the context won't be set properly in runTx's recover ...
-		defer func() {
-			if r := recover(); r != nil {
-				switch rType := r.(type) {
-				case sdk.ErrorOutOfGas:
-					log := fmt.Sprintf("out of gas in location: %v", rType.Descriptor)
-					res = sdk.ErrOutOfGas(log).Result()
-					res.GasWanted = stdTx.Fee.Gas
-					res.GasUsed = newCtx.GasMeter().GasConsumed()
-					abort = true
-				default:
-					panic(r)
-				}
+func NewConsumeTxSizeGasDecorator(am AccountKeeper) ConsumeTxSizeGasDecorator {
+	return ConsumeTxSizeGasDecorator{am: am}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (ctsgd ConsumeTxSizeGasDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	params := getParams(ctx, ctsgd.am)
+	ctx.GasMeter().ConsumeGas(memoCostPerByte*sdk.Gas(len(stdTx.GetMemo())), "memo")
+	ctx.GasMeter().ConsumeGas(params.TxSizeCostPerByte*sdk.Gas(len(ctx.TxBytes())), "txSize")
+
+	if simulate {
+		// The signatures of a simulated tx are empty, charge for the size
+		// they will have once the tx is signed.
+		for _, sig := range stdTx.GetSignatures() {
+			if len(sig.Signature) != 0 {
+				continue
 			}
-		}()
+			simSigsSize := sdk.Gas(simSignatureSize * countSubKeys(sig.PubKey))
+			ctx.GasMeter().ConsumeGas(params.TxSizeCostPerByte*simSigsSize, "txSize")
+		}
+	}
+	return next(ctx, tx, simulate)
+}
 
-		err := validateBasic(stdTx)
+// SetPubKeyDecorator sets the pubkeys of the signatures on the signer
+// accounts which don't have one yet.
+type SetPubKeyDecorator struct {
+	am AccountKeeper
+}
+
+func NewSetPubKeyDecorator(am AccountKeeper) SetPubKeyDecorator {
+	return SetPubKeyDecorator{am: am}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (spkd SetPubKeyDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	newCtx, signerAccs, loaded, res := getSignerAccsFromContext(ctx, spkd.am, stdTx)
+	if !res.IsOK() {
+		return newCtx, res, true
+	}
+
+	// When simulating, this would just be a 0-length slice.
+	stdSigs := stdTx.GetSignatures()
+	for i := 0; i < len(stdSigs); i++ {
+		pubKey, res := processPubKey(signerAccs[i], stdSigs[i], simulate)
+		if !res.IsOK() {
+			return newCtx, res, true
+		}
+		err := signerAccs[i].SetPubKey(pubKey)
 		if err != nil {
-			return newCtx, err.Result(), true
+			return newCtx, sdk.ErrInternal("setting PubKey on signer's account").Result(), true
 		}
-		// charge gas for the memo
-		newCtx.GasMeter().ConsumeGas(memoCostPerByte*sdk.Gas(len(stdTx.GetMemo())), "memo")
+	}
+
+	return nextAndSetSignerAccs(newCtx, tx, simulate, next, spkd.am, signerAccs, loaded)
+}
+
+// SigVerificationDecorator checks the account numbers and the sequences of
+// the signatures, and the number of signatures against the auth params, and
+// verifies them with the pubkeys of the signer accounts.
+type SigVerificationDecorator struct {
+	am AccountKeeper
+}
+
+func NewSigVerificationDecorator(am AccountKeeper) SigVerificationDecorator {
+	return SigVerificationDecorator{am: am}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (svd SigVerificationDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
 
-		// stdSigs contains the sequence number, account number, and signatures
-		stdSigs := stdTx.GetSignatures() // When simulating, this would just be a 0-length slice.
-		signerAddrs := stdTx.GetSigners()
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	newCtx, signerAccs, loaded, res := getSignerAccsFromContext(ctx, svd.am, stdTx)
+	if !res.IsOK() {
+		return newCtx, res, true
+	}
+
+	// stdSigs contains the sequence number, account number, and signatures
+	stdSigs := stdTx.GetSignatures()
+	res = validateAccNumAndSequence(newCtx, signerAccs, stdSigs)
+	if !res.IsOK() {
+		return newCtx, res, true
+	}
+
+	// the limit applies to the pubkeys the signatures are verified with,
+	// which the pubkeys of the signatures can't override once set
+	params := getParams(newCtx, svd.am)
+	res = validateSigCount(signerAccs, params)
+	if !res.IsOK() {
+		return newCtx, res, true
+	}
 
-		// create the list of all sign bytes
-		signBytesList := getSignBytesList(newCtx.ChainID(), stdTx, stdSigs)
-		signerAccs, res := getSignerAccs(newCtx, am, signerAddrs)
+	// create the list of all sign bytes
+	signBytesList := getSignBytesList(newCtx.ChainID(), stdTx, stdSigs)
+	for i := 0; i < len(stdSigs); i++ {
+		res = verifySig(newCtx, signerAccs[i], stdSigs[i], signBytesList[i], params, simulate)
 		if !res.IsOK() {
 			return newCtx, res, true
 		}
-		res = validateAccNumAndSequence(ctx, signerAccs, stdSigs)
+	}
+
+	return nextAndSetSignerAccs(newCtx, tx, simulate, next, svd.am, signerAccs, loaded)
+}
+
+// IncrementSequenceDecorator increments the sequences of the signer accounts.
+type IncrementSequenceDecorator struct {
+	am AccountKeeper
+}
+
+func NewIncrementSequenceDecorator(am AccountKeeper) IncrementSequenceDecorator {
+	return IncrementSequenceDecorator{am: am}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (isd IncrementSequenceDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	newCtx, signerAccs, loaded, res := getSignerAccsFromContext(ctx, isd.am, stdTx)
+	if !res.IsOK() {
+		return newCtx, res, true
+	}
+
+	for _, acc := range signerAccs {
+		err := acc.SetSequence(acc.GetSequence() + 1)
+		if err != nil {
+			// Handle w/ #870
+			panic(err)
+		}
+	}
+
+	return nextAndSetSignerAccs(newCtx, tx, simulate, next, isd.am, signerAccs, loaded)
+}
+
+// DeductFeeDecorator deducts the fee of the tx from the first signer, and
+// adds it to the collected fees.
+type DeductFeeDecorator struct {
+	am  AccountKeeper
+	fck FeeCollectionKeeper
+}
+
+func NewDeductFeeDecorator(am AccountKeeper, fck FeeCollectionKeeper) DeductFeeDecorator {
+	return DeductFeeDecorator{am: am, fck: fck}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (dfd DeductFeeDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	newCtx, signerAccs, loaded, res := getSignerAccsFromContext(ctx, dfd.am, stdTx)
+	if !res.IsOK() {
+		return newCtx, res, true
+	}
+
+	// first sig pays the fees
+	if !stdTx.Fee.Amount.IsZero() {
+		// signerAccs[0] is the fee payer
+		feePayer, res := deductFees(newCtx.BlockHeader().Time, signerAccs[0], stdTx.Fee)
 		if !res.IsOK() {
 			return newCtx, res, true
 		}
+		signerAccs[0] = feePayer
+		dfd.fck.AddCollectedFees(newCtx, stdTx.Fee.Amount)
+	}
 
-		// first sig pays the fees
-		if !stdTx.Fee.Amount.IsZero() {
-			// signerAccs[0] is the fee payer
-			signerAccs[0], res = deductFees(signerAccs[0], stdTx.Fee)
-			if !res.IsOK() {
-				return newCtx, res, true
-			}
-			fck.AddCollectedFees(newCtx, stdTx.Fee.Amount)
-		}
+	return nextAndSetSignerAccs(newCtx, tx, simulate, next, dfd.am, signerAccs, loaded)
+}
 
-		for i := 0; i < len(stdSigs); i++ {
-			// check signature, return account with incremented nonce
-			signerAccs[i], res = processSig(newCtx, signerAccs[i], stdSigs[i], signBytesList[i], simulate)
-			if !res.IsOK() {
-				return newCtx, res, true
-			}
+// Returns the signer accounts of the tx cached in the context by an earlier
+// step. Otherwise they are loaded and cached in the returned context, so
+// that the next steps update the same accounts, and loaded is true.
+func getSignerAccsFromContext(ctx sdk.Context, am AccountKeeper, stdTx StdTx) (
+	newCtx sdk.Context, accs []Account, loaded bool, res sdk.Result) {
+
+	signerAddrs := stdTx.GetSigners()
+	if accs = GetSigners(ctx); isSignerAccs(accs, signerAddrs) {
+		return ctx, accs, false, sdk.Result{}
+	}
+
+	accs, res = getSignerAccs(ctx, am, signerAddrs)
+	if !res.IsOK() {
+		return ctx, nil, false, res
+	}
+	// cache the signer accounts in the context
+	return WithSigners(ctx, accs), accs, true, sdk.Result{}
+}
 
-			// Save the account.
-			am.SetAccount(newCtx, signerAccs[i])
+// Returns whether the accounts are the ones of the signer addresses.
+func isSignerAccs(accs []Account, signerAddrs []sdk.AccAddress) bool {
+	if len(accs) == 0 || len(accs) != len(signerAddrs) {
+		return false
+	}
+	for i, acc := range accs {
+		if !bytes.Equal(acc.GetAddress(), signerAddrs[i]) {
+			return false
 		}
+	}
+	return true
+}
 
-		// cache the signer accounts in the context
-		newCtx = WithSigners(newCtx, signerAccs)
+// Runs the next steps, then saves the signer accounts if the step calling it
+// loaded them and the next steps succeeded, so that every signer account is
+// saved once.
+func nextAndSetSignerAccs(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+	am AccountKeeper, accs []Account, loaded bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
 
-		// TODO: tx tags (?)
-		return newCtx, sdk.Result{GasWanted: stdTx.Fee.Gas}, false // continue...
+	newCtx, res, abort = next(ctx, tx, simulate)
+	if abort || !loaded {
+		return newCtx, res, abort
 	}
+	for _, acc := range accs {
+		am.SetAccount(ctx, acc)
+	}
+	return newCtx, res, abort
+}
+
+// Returns the auth params. They are the same for every tx, so reading them
+// isn't charged to the gas meter of the tx.
+func getParams(ctx sdk.Context, am AccountKeeper) Params {
+	return am.GetParams(ctx.WithGasMeter(sdk.NewInfiniteGasMeter()))
 }
 
-// Validate the transaction based on things that don't depend on the context
-func validateBasic(tx StdTx) (err sdk.Error) {
+// Validate the transaction based on things that don't depend on the accounts
+func validateBasic(tx StdTx, params Params) (err sdk.Error) {
 	// Assert that there are signatures.
 	sigs := tx.GetSignatures()
 	if len(sigs) == 0 {
@@ -129,14 +435,43 @@ func validateBasic(tx StdTx) (err sdk.Error) {
 	}
 
 	memo := tx.GetMemo()
-	if len(memo) > maxMemoCharacters {
+	if int64(len(memo)) > params.MaxMemoCharacters {
 		return sdk.ErrMemoTooLarge(
 			fmt.Sprintf("maximum number of characters is %d but received %d characters",
-				maxMemoCharacters, len(memo)))
+				params.MaxMemoCharacters, len(memo)))
 	}
 	return nil
 }
 
+// Assert that the signatures, counting the ones of the sub-keys of multisig
+// pubkeys, are within the limit.
+func validateSigCount(accs []Account, params Params) sdk.Result {
+	sigCount := 0
+	for _, acc := range accs {
+		sigCount += countSubKeys(acc.GetPubKey())
+	}
+	if int64(sigCount) > params.TxSigLimit {
+		return sdk.ErrTooManySignatures(
+			fmt.Sprintf("maximum number of signatures is %d but received %d signatures",
+				params.TxSigLimit, sigCount)).Result()
+	}
+	return sdk.Result{}
+}
+
+// Returns the number of keys of the pubkey, which for threshold multisig keys
+// is the number of their sub-keys. A missing pubkey counts as one key.
+func countSubKeys(pubKey crypto.PubKey) int {
+	multisigPubKey, ok := pubKey.(multisig.PubKeyMultisigThreshold)
+	if !ok {
+		return 1
+	}
+	numKeys := 0
+	for _, subKey := range multisigPubKey.PubKeys {
+		numKeys += countSubKeys(subKey)
+	}
+	return numKeys
+}
+
 func getSignerAccs(ctx sdk.Context, am AccountKeeper, addrs []sdk.AccAddress) (accs []Account, res sdk.Result) {
 	accs = make([]Account, len(addrs))
 	for i := 0; i < len(accs); i++ {
@@ -173,32 +508,19 @@ func validateAccNumAndSequence(ctx sdk.Context, accs []Account, sigs []StdSignat
 	return sdk.Result{}
 }
 
-// verify the signature and increment the sequence.
-// if the account doesn't have a pubkey, set it.
-func processSig(ctx sdk.Context,
-	acc Account, sig StdSignature, signBytes []byte, simulate bool) (updatedAcc Account, res sdk.Result) {
-	pubKey, res := processPubKey(acc, sig, simulate)
-	if !res.IsOK() {
-		return nil, res
-	}
-	err := acc.SetPubKey(pubKey)
-	if err != nil {
-		return nil, sdk.ErrInternal("setting PubKey on signer's account").Result()
+// verify the signature with the pubkey of the account, charging gas for it.
+func verifySig(ctx sdk.Context,
+	acc Account, sig StdSignature, signBytes []byte, params Params, simulate bool) sdk.Result {
+	pubKey := acc.GetPubKey()
+	if pubKey == nil {
+		return sdk.ErrInvalidPubKey("PubKey not found").Result()
 	}
 
-	consumeSignatureVerificationGas(ctx.GasMeter(), pubKey)
+	consumeSignatureVerificationGas(ctx.GasMeter(), sig.Signature, pubKey, params)
 	if !simulate && !pubKey.VerifyBytes(signBytes, sig.Signature) {
-		return nil, sdk.ErrUnauthorized("signature verification failed").Result()
+		return sdk.ErrUnauthorized("signature verification failed").Result()
 	}
-
-	// increment the sequence number
-	err = acc.SetSequence(acc.GetSequence() + 1)
-	if err != nil {
-		// Handle w/ #870
-		panic(err)
-	}
-
-	return acc, res
+	return sdk.Result{}
 }
 
 var dummySecp256k1Pubkey secp256k1.PubKeySecp256k1
@@ -235,34 +557,65 @@ func processPubKey(acc Account, sig StdSignature, simulate bool) (crypto.PubKey,
 	return pubKey, sdk.Result{}
 }
 
-func consumeSignatureVerificationGas(meter sdk.GasMeter, pubkey crypto.PubKey) {
-	switch pubkey.(type) {
+// consumeSignatureVerificationGas consumes gas for signature verification based
+// upon the public key type. Threshold multisig keys are charged for every
+// sub-signature present in the multisignature, or for every sub-key if the
+// signature cannot be decoded (e.g. when simulating).
+func consumeSignatureVerificationGas(meter sdk.GasMeter, sig []byte, pubkey crypto.PubKey, params Params) {
+	switch pubkey := pubkey.(type) {
 	case ed25519.PubKeyEd25519:
-		meter.ConsumeGas(ed25519VerifyCost, "ante verify: ed25519")
+		meter.ConsumeGas(params.SigVerifyCostED25519, "ante verify: ed25519")
 	case secp256k1.PubKeySecp256k1:
-		meter.ConsumeGas(secp256k1VerifyCost, "ante verify: secp256k1")
+		meter.ConsumeGas(params.SigVerifyCostSecp256k1, "ante verify: secp256k1")
+	case multisig.PubKeyMultisigThreshold:
+		var multisignature multisig.Multisignature
+		err := codec.Cdc.UnmarshalBinaryBare(sig, &multisignature)
+		if err != nil || multisignature.BitArray == nil {
+			// verification is going to fail anyway, charge the worst case
+			for _, subKey := range pubkey.PubKeys {
+				consumeSignatureVerificationGas(meter, nil, subKey, params)
+			}
+			return
+		}
+		consumeMultisignatureVerificationGas(meter, multisignature, pubkey, params)
 	default:
 		panic("Unrecognized signature type")
 	}
 }
 
-func adjustFeesByGas(fees sdk.Coins, gas int64) sdk.Coins {
-	gasCost := gas / gasPerUnitCost
-	gasFees := make(sdk.Coins, len(fees))
-	// TODO: Make this not price all coins in the same way
-	for i := 0; i < len(fees); i++ {
-		gasFees[i] = sdk.NewInt64Coin(fees[i].Denom, gasCost)
+// consumeMultisignatureVerificationGas consumes gas for each sub-signature
+// contained in a threshold multisignature.
+func consumeMultisignatureVerificationGas(meter sdk.GasMeter,
+	sig multisig.Multisignature, pubkey multisig.PubKeyMultisigThreshold, params Params) {
+	size := sig.BitArray.Size()
+	sigIndex := 0
+	for i := 0; i < size && i < len(pubkey.PubKeys); i++ {
+		if !sig.BitArray.GetIndex(i) {
+			continue
+		}
+		var subSig []byte
+		if sigIndex < len(sig.Sigs) {
+			subSig = sig.Sigs[sigIndex]
+		}
+		consumeSignatureVerificationGas(meter, subSig, pubkey.PubKeys[i], params)
+		sigIndex++
 	}
-	return fees.Plus(gasFees)
 }
 
 // Deduct the fee from the account.
 // We could use the CoinKeeper (in addition to the AccountKeeper,
 // because the CoinKeeper doesn't give us accounts), but it seems easier to do this.
-func deductFees(acc Account, fee StdFee) (Account, sdk.Result) {
+// Coins still locked by a vesting schedule cannot be used to pay fees.
+func deductFees(blockTime time.Time, acc Account, fee StdFee) (Account, sdk.Result) {
 	coins := acc.GetCoins()
 	feeAmount := fee.Amount
 
+	spendableCoins := SpendableCoins(acc, blockTime)
+	if !spendableCoins.Minus(feeAmount).IsNotNegative() {
+		errMsg := fmt.Sprintf("%s < %s", spendableCoins, feeAmount)
+		return nil, sdk.ErrInsufficientFunds(errMsg).Result()
+	}
+
 	newCoins := coins.Minus(feeAmount)
 	if !newCoins.IsNotNegative() {
 		errMsg := fmt.Sprintf("%s < %s", coins, feeAmount)
@@ -276,18 +629,36 @@ func deductFees(acc Account, fee StdFee) (Account, sdk.Result) {
 	return acc, sdk.Result{}
 }
 
+// Ensures the fee of a tx covers the validator's minimum gas prices. The fee is
+// sufficient if, for any one of the denominations the validator accepts, it
+// pays at least the price of that denomination times the gas requested.
 func ensureSufficientMempoolFees(ctx sdk.Context, stdTx StdTx) sdk.Result {
-	// currently we use a very primitive gas pricing model with a constant gasPrice.
-	// adjustFeesByGas handles calculating the amount of fees required based on the provided gas.
-	// TODO: Make the gasPrice not a constant, and account for tx size.
-	requiredFees := adjustFeesByGas(ctx.MinimumFees(), stdTx.Fee.Gas)
+	minGasPrices := ctx.MinGasPrices()
+	if minGasPrices.IsZero() {
+		return sdk.Result{}
+	}
+
+	requiredFees := NewStdFeeFromGasPrices(stdTx.Fee.Gas, minGasPrices).Amount
+	for _, fee := range requiredFees {
+		if !stdTx.Fee.Amount.AmountOf(fee.Denom).LT(fee.Amount) {
+			return sdk.Result{}
+		}
+	}
 
-	if !ctx.MinimumFees().IsZero() && stdTx.Fee.Amount.IsLT(requiredFees) {
-		// validators reject any tx from the mempool with less than the minimum fee per gas * gas factor
-		return sdk.ErrInsufficientFee(fmt.Sprintf(
-			"insufficient fee, got: %q required: %q", stdTx.Fee.Amount, requiredFees)).Result()
+	// validators reject any tx from the mempool with less than the minimum gas price * gas
+	return sdk.ErrInsufficientFee(fmt.Sprintf(
+		"insufficient fee, got: %q required any of: %q", stdTx.Fee.Amount, requiredFees)).Result()
+}
+
+// The priority of a tx in the mempool is its effective gas price, weighted by
+// the fee denominations configured for the validator.
+func mempoolPriority(ctx sdk.Context, stdTx StdTx) int64 {
+	price := ctx.FeeWeights().EffectiveGasPrice(stdTx.Fee.Amount, stdTx.Fee.Gas)
+	priority := price.MulInt(sdk.NewInt(priorityPrecision)).TruncateInt()
+	if !priority.IsInt64() {
+		return math.MaxInt64
 	}
-	return sdk.Result{}
+	return priority.Int64()
 }
 
 func setGasMeter(simulate bool, ctx sdk.Context, stdTx StdTx) sdk.Context {
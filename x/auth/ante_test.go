@@ -6,10 +6,12 @@ import (
 
 	codec "github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/multisig"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/libs/log"
 )
@@ -109,13 +111,15 @@ func newTestTxWithSignBytes(msgs []sdk.Msg, privs []crypto.PrivKey, accNums []in
 // Test various error cases in the AnteHandler control flow.
 func TestAnteHandlerSigErrors(t *testing.T) {
 	// setup
-	ms, capKey, capKey2 := setupMultiStore()
+	ms, capKey, capKey2, keyParams, tkeyParams := setupMultiStore()
 	cdc := codec.New()
 	RegisterBaseAccount(cdc)
-	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
 	anteHandler := NewAnteHandler(mapper, feeCollector)
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+	mapper.SetParams(ctx, DefaultParams())
 
 	// keys and addresses
 	priv1, addr1 := privAndAddr()
@@ -162,13 +166,15 @@ func TestAnteHandlerSigErrors(t *testing.T) {
 // Test logic around account number checking with one signer and many signers.
 func TestAnteHandlerAccountNumbers(t *testing.T) {
 	// setup
-	ms, capKey, capKey2 := setupMultiStore()
+	ms, capKey, capKey2, keyParams, tkeyParams := setupMultiStore()
 	cdc := codec.New()
 	RegisterBaseAccount(cdc)
-	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
 	anteHandler := NewAnteHandler(mapper, feeCollector)
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+	mapper.SetParams(ctx, DefaultParams())
 	ctx = ctx.WithBlockHeight(1)
 
 	// keys and addresses
@@ -222,13 +228,15 @@ func TestAnteHandlerAccountNumbers(t *testing.T) {
 // Test logic around account number checking with many signers when BlockHeight is 0.
 func TestAnteHandlerAccountNumbersAtBlockHeightZero(t *testing.T) {
 	// setup
-	ms, capKey, capKey2 := setupMultiStore()
+	ms, capKey, capKey2, keyParams, tkeyParams := setupMultiStore()
 	cdc := codec.New()
 	RegisterBaseAccount(cdc)
-	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
 	anteHandler := NewAnteHandler(mapper, feeCollector)
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+	mapper.SetParams(ctx, DefaultParams())
 	ctx = ctx.WithBlockHeight(0)
 
 	// keys and addresses
@@ -282,13 +290,15 @@ func TestAnteHandlerAccountNumbersAtBlockHeightZero(t *testing.T) {
 // Test logic around sequence checking with one signer and many signers.
 func TestAnteHandlerSequences(t *testing.T) {
 	// setup
-	ms, capKey, capKey2 := setupMultiStore()
+	ms, capKey, capKey2, keyParams, tkeyParams := setupMultiStore()
 	cdc := codec.New()
 	RegisterBaseAccount(cdc)
-	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
 	anteHandler := NewAnteHandler(mapper, feeCollector)
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+	mapper.SetParams(ctx, DefaultParams())
 	ctx = ctx.WithBlockHeight(1)
 
 	// keys and addresses
@@ -361,13 +371,15 @@ func TestAnteHandlerSequences(t *testing.T) {
 // Test logic around fee deduction.
 func TestAnteHandlerFees(t *testing.T) {
 	// setup
-	ms, capKey, capKey2 := setupMultiStore()
+	ms, capKey, capKey2, keyParams, tkeyParams := setupMultiStore()
 	cdc := codec.New()
 	RegisterBaseAccount(cdc)
-	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
 	anteHandler := NewAnteHandler(mapper, feeCollector)
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+	mapper.SetParams(ctx, DefaultParams())
 
 	// keys and addresses
 	priv1, addr1 := privAndAddr()
@@ -403,13 +415,15 @@ func TestAnteHandlerFees(t *testing.T) {
 // Test logic around memo gas consumption.
 func TestAnteHandlerMemoGas(t *testing.T) {
 	// setup
-	ms, capKey, capKey2 := setupMultiStore()
+	ms, capKey, capKey2, keyParams, tkeyParams := setupMultiStore()
 	cdc := codec.New()
 	RegisterBaseAccount(cdc)
-	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
 	anteHandler := NewAnteHandler(mapper, feeCollector)
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+	mapper.SetParams(ctx, DefaultParams())
 	ctx = ctx.WithBlockHeight(1)
 
 	// keys and addresses
@@ -447,13 +461,15 @@ func TestAnteHandlerMemoGas(t *testing.T) {
 
 func TestAnteHandlerMultiSigner(t *testing.T) {
 	// setup
-	ms, capKey, capKey2 := setupMultiStore()
+	ms, capKey, capKey2, keyParams, tkeyParams := setupMultiStore()
 	cdc := codec.New()
 	RegisterBaseAccount(cdc)
-	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
 	anteHandler := NewAnteHandler(mapper, feeCollector)
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+	mapper.SetParams(ctx, DefaultParams())
 	ctx = ctx.WithBlockHeight(1)
 
 	// keys and addresses
@@ -497,15 +513,159 @@ func TestAnteHandlerMultiSigner(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx, false)
 }
 
+// Test that the signature and memo limits are read from the params.
+func TestAnteHandlerParamsLimits(t *testing.T) {
+	// setup
+	ms, capKey, capKey2, keyParams, tkeyParams := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+	ctx = ctx.WithBlockHeight(1)
+
+	authParams := DefaultParams()
+	authParams.TxSigLimit = 2
+	authParams.MaxMemoCharacters = 10
+	mapper.SetParams(ctx, authParams)
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+	priv2, addr2 := privAndAddr()
+	priv3, addr3 := privAndAddr()
+
+	// set the accounts
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+	acc2 := mapper.NewAccountWithAddress(ctx, addr2)
+	acc2.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc2)
+	acc3 := mapper.NewAccountWithAddress(ctx, addr3)
+	acc3.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc3)
+
+	fee := newStdFee()
+
+	// too many signatures
+	msgs := []sdk.Msg{newTestMsg(addr1, addr2, addr3)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1, priv2, priv3}, []int64{0, 1, 2}, []int64{0, 0, 0}
+	tx := newTestTx(ctx, msgs, privs, accnums, seqs, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeTooManySignatures)
+
+	// too many signatures with the multisig pubkey of the account, which the
+	// signature leaves out
+	multisigPrivs := []crypto.PrivKey{secp256k1.GenPrivKey(), secp256k1.GenPrivKey(), secp256k1.GenPrivKey()}
+	pubs := []crypto.PubKey{multisigPrivs[0].PubKey(), multisigPrivs[1].PubKey(), multisigPrivs[2].PubKey()}
+	multisigKey := multisig.NewPubKeyMultisigThreshold(2, pubs)
+	multisigAddr := sdk.AccAddress(multisigKey.Address())
+	multisigAcc := mapper.NewAccountWithAddress(ctx, multisigAddr)
+	multisigAcc.SetCoins(newCoins())
+	multisigAcc.SetPubKey(multisigKey)
+	mapper.SetAccount(ctx, multisigAcc)
+
+	msgs = []sdk.Msg{newTestMsg(multisigAddr)}
+	signBytes := StdSignBytes(ctx.ChainID(), 3, 0, fee, msgs, "")
+	multisignature := multisig.NewMultisig(len(pubs))
+	for i := 0; i < 2; i++ {
+		sig, err := multisigPrivs[i].Sign(signBytes)
+		require.NoError(t, err)
+		require.NoError(t, multisignature.AddSignatureFromPubKey(sig, pubs[i], pubs))
+	}
+	stdSig := StdSignature{Signature: multisignature.Marshal(), AccountNumber: 3, Sequence: 0}
+	tx = NewStdTx(msgs, fee, []StdSignature{stdSig}, "")
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeTooManySignatures)
+
+	// memo too large
+	msgs = []sdk.Msg{newTestMsg(addr1, addr2)}
+	privs, accnums, seqs = []crypto.PrivKey{priv1, priv2}, []int64{0, 1}, []int64{0, 0}
+	tx = newTestTxWithMemo(ctx, msgs, privs, accnums, seqs, fee, "abcdefghijk")
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeMemoTooLarge)
+
+	// within the limits
+	tx = newTestTxWithMemo(ctx, msgs, privs, accnums, seqs, fee, "abcdefghij")
+	checkValidTx(t, anteHandler, ctx, tx, false)
+}
+
+func TestConsumeTxSizeGas(t *testing.T) {
+	// setup
+	ms, capKey, _, keyParams, tkeyParams := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+	mapper.SetParams(ctx, DefaultParams())
+	anteHandler := sdk.ChainAnteDecorators(NewConsumeTxSizeGasDecorator(mapper))
+
+	priv1, addr1 := privAndAddr()
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	tx := newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{0}, newStdFee())
+	txBytes := []byte("some serialized tx")
+
+	// the tx bytes are charged
+	ctx = ctx.WithGasMeter(sdk.NewGasMeter(1000000)).WithTxBytes(txBytes)
+	newCtx, result, abort := anteHandler(ctx, tx, false)
+	require.False(t, abort)
+	require.True(t, result.IsOK())
+	expectedGas := DefaultTxSizeCostPerByte * sdk.Gas(len(txBytes))
+	require.Equal(t, expectedGas, newCtx.GasMeter().GasConsumed())
+
+	// the empty signatures of a simulated tx are charged with their signed size
+	stdTx := tx.(StdTx)
+	stdTx.Signatures = []StdSignature{{PubKey: priv1.PubKey()}}
+	ctx = ctx.WithGasMeter(sdk.NewGasMeter(1000000))
+	newCtx, result, abort = anteHandler(ctx, stdTx, true)
+	require.False(t, abort)
+	require.True(t, result.IsOK())
+	expectedGas += DefaultTxSizeCostPerByte * simSignatureSize
+	require.Equal(t, expectedGas, newCtx.GasMeter().GasConsumed())
+}
+
+func TestCountSubKeys(t *testing.T) {
+	genPubKeys := func(n int) []crypto.PubKey {
+		var ret []crypto.PubKey
+		for i := 0; i < n; i++ {
+			ret = append(ret, secp256k1.GenPrivKey().PubKey())
+		}
+		return ret
+	}
+	singleKey := secp256k1.GenPrivKey().PubKey()
+	singleLevelMultiKey := multisig.NewPubKeyMultisigThreshold(4, genPubKeys(5))
+	multiLevelSubKey1 := multisig.NewPubKeyMultisigThreshold(4, genPubKeys(5))
+	multiLevelSubKey2 := multisig.NewPubKeyMultisigThreshold(4, genPubKeys(5))
+	multiLevelMultiKey := multisig.NewPubKeyMultisigThreshold(2, []crypto.PubKey{
+		multiLevelSubKey1, multiLevelSubKey2, secp256k1.GenPrivKey().PubKey()})
+
+	tests := []struct {
+		name   string
+		pubKey crypto.PubKey
+		want   int
+	}{
+		{"single key", singleKey, 1},
+		{"single level multikey", singleLevelMultiKey, 5},
+		{"multi level multikey", multiLevelMultiKey, 11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, countSubKeys(tt.pubKey))
+		})
+	}
+}
+
 func TestAnteHandlerBadSignBytes(t *testing.T) {
 	// setup
-	ms, capKey, capKey2 := setupMultiStore()
+	ms, capKey, capKey2, keyParams, tkeyParams := setupMultiStore()
 	cdc := codec.New()
 	RegisterBaseAccount(cdc)
-	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
 	anteHandler := NewAnteHandler(mapper, feeCollector)
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+	mapper.SetParams(ctx, DefaultParams())
 	ctx = ctx.WithBlockHeight(1)
 
 	// keys and addresses
@@ -581,13 +741,15 @@ func TestAnteHandlerBadSignBytes(t *testing.T) {
 
 func TestAnteHandlerSetPubKey(t *testing.T) {
 	// setup
-	ms, capKey, capKey2 := setupMultiStore()
+	ms, capKey, capKey2, keyParams, tkeyParams := setupMultiStore()
 	cdc := codec.New()
 	RegisterBaseAccount(cdc)
-	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
 	anteHandler := NewAnteHandler(mapper, feeCollector)
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+	mapper.SetParams(ctx, DefaultParams())
 	ctx = ctx.WithBlockHeight(1)
 
 	// keys and addresses
@@ -635,10 +797,11 @@ func TestAnteHandlerSetPubKey(t *testing.T) {
 }
 
 func TestProcessPubKey(t *testing.T) {
-	ms, capKey, _ := setupMultiStore()
+	ms, capKey, _, keyParams, tkeyParams := setupMultiStore()
 	cdc := codec.New()
 	RegisterBaseAccount(cdc)
-	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
 	// keys
 	_, addr1 := privAndAddr()
@@ -668,9 +831,21 @@ func TestProcessPubKey(t *testing.T) {
 }
 
 func TestConsumeSignatureVerificationGas(t *testing.T) {
+	msg := []byte{1, 2, 3, 4}
+
+	pkSet1, sigSet1 := generatePubKeysAndSignatures(5, msg, false)
+	multisigKey1 := multisig.NewPubKeyMultisigThreshold(2, pkSet1)
+	multisignature1 := multisig.NewMultisig(len(pkSet1))
+	expectedCost1 := expectedGasCostByKeys(pkSet1)
+	for i := 0; i < len(pkSet1); i++ {
+		multisignature1.AddSignatureFromPubKey(sigSet1[i], pkSet1[i], pkSet1)
+	}
+
 	type args struct {
 		meter  sdk.GasMeter
+		sig    []byte
 		pubkey crypto.PubKey
+		params Params
 	}
 	tests := []struct {
 		name        string
@@ -678,39 +853,231 @@ func TestConsumeSignatureVerificationGas(t *testing.T) {
 		gasConsumed int64
 		wantPanic   bool
 	}{
-		{"PubKeyEd25519", args{sdk.NewInfiniteGasMeter(), ed25519.GenPrivKey().PubKey()}, ed25519VerifyCost, false},
-		{"PubKeySecp256k1", args{sdk.NewInfiniteGasMeter(), secp256k1.GenPrivKey().PubKey()}, secp256k1VerifyCost, false},
-		{"unknown key", args{sdk.NewInfiniteGasMeter(), nil}, 0, true},
+		{"PubKeyEd25519", args{sdk.NewInfiniteGasMeter(), nil, ed25519.GenPrivKey().PubKey(), DefaultParams()}, DefaultSigVerifyCostED25519, false},
+		{"PubKeySecp256k1", args{sdk.NewInfiniteGasMeter(), nil, secp256k1.GenPrivKey().PubKey(), DefaultParams()}, DefaultSigVerifyCostSecp256k1, false},
+		{"Multisig", args{sdk.NewInfiniteGasMeter(), multisignature1.Marshal(), multisigKey1, DefaultParams()}, expectedCost1, false},
+		{"Multisig without signature", args{sdk.NewInfiniteGasMeter(), nil, multisigKey1, DefaultParams()}, expectedCost1, false},
+		{"unknown key", args{sdk.NewInfiniteGasMeter(), nil, nil, DefaultParams()}, 0, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.wantPanic {
-				require.Panics(t, func() { consumeSignatureVerificationGas(tt.args.meter, tt.args.pubkey) })
+				require.Panics(t, func() { consumeSignatureVerificationGas(tt.args.meter, tt.args.sig, tt.args.pubkey, tt.args.params) })
 			} else {
-				consumeSignatureVerificationGas(tt.args.meter, tt.args.pubkey)
-				require.Equal(t, tt.args.meter.GasConsumed(), tt.gasConsumed)
+				consumeSignatureVerificationGas(tt.args.meter, tt.args.sig, tt.args.pubkey, tt.args.params)
+				require.Equal(t, tt.gasConsumed, tt.args.meter.GasConsumed())
 			}
 		})
 	}
 }
 
-func TestAdjustFeesByGas(t *testing.T) {
-	type args struct {
-		fee sdk.Coins
-		gas int64
+func TestAnteHandlerMultisig(t *testing.T) {
+	// setup
+	ms, capKey, capKey2, keyParams, tkeyParams := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+	mapper.SetParams(ctx, DefaultParams())
+	ctx = ctx.WithBlockHeight(1)
+
+	// 2-of-3 multisig account
+	privs := []crypto.PrivKey{secp256k1.GenPrivKey(), secp256k1.GenPrivKey(), ed25519.GenPrivKey()}
+	pubs := []crypto.PubKey{privs[0].PubKey(), privs[1].PubKey(), privs[2].PubKey()}
+	multisigKey := multisig.NewPubKeyMultisigThreshold(2, pubs)
+	addr := sdk.AccAddress(multisigKey.Address())
+
+	acc := mapper.NewAccountWithAddress(ctx, addr)
+	acc.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc)
+
+	msgs := []sdk.Msg{newTestMsg(addr)}
+	fee := newStdFee()
+	signBytes := StdSignBytes(ctx.ChainID(), 0, 0, fee, msgs, "")
+
+	newMultisigTx := func(signers ...int) sdk.Tx {
+		multisignature := multisig.NewMultisig(len(pubs))
+		for _, i := range signers {
+			sig, err := privs[i].Sign(signBytes)
+			require.NoError(t, err)
+			require.NoError(t, multisignature.AddSignatureFromPubKey(sig, pubs[i], pubs))
+		}
+		stdSig := StdSignature{PubKey: multisigKey, Signature: multisignature.Marshal()}
+		return NewStdTx(msgs, fee, []StdSignature{stdSig}, "")
 	}
-	tests := []struct {
-		name string
-		args args
-		want sdk.Coins
+
+	// below threshold
+	checkInvalidTx(t, anteHandler, ctx, newMultisigTx(0), false, sdk.CodeUnauthorized)
+
+	// threshold met, gas charged per sub-signature
+	newCtx, result, abort := anteHandler(ctx, newMultisigTx(0, 2), false)
+	require.False(t, abort)
+	require.True(t, result.IsOK())
+	require.True(t, newCtx.GasMeter().GasConsumed() >= DefaultSigVerifyCostSecp256k1+DefaultSigVerifyCostED25519)
+	require.Equal(t, int64(1), mapper.GetAccount(ctx, addr).GetSequence())
+}
+
+// rejects the txs with the given memo
+type memoBlocklistDecorator struct {
+	memo string
+}
+
+func (mbd memoBlocklistDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	if tx.(StdTx).GetMemo() == mbd.memo {
+		return ctx, sdk.ErrUnauthorized("blocked memo").Result(), true
+	}
+	return next(ctx, tx, simulate)
+}
+
+// Test that apps can insert and drop steps of the AnteHandler.
+func TestAnteHandlerCustomDecorators(t *testing.T) {
+	// setup
+	ms, capKey, capKey2, keyParams, tkeyParams := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+	mapper.SetParams(ctx, DefaultParams())
+	ctx = ctx.WithBlockHeight(1)
+
+	// insert a memo check and drop the fee deduction
+	decorators := NewAnteDecorators(mapper, feeCollector)
+	decorators = append(decorators[:len(decorators)-1], memoBlocklistDecorator{"blocked"})
+	anteHandler := sdk.ChainAnteDecorators(decorators...)
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	privs, accnums := []crypto.PrivKey{priv1}, []int64{0}
+	fee := newStdFee()
+
+	tx := newTestTxWithMemo(ctx, msgs, privs, accnums, []int64{0}, fee, "blocked")
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeUnauthorized)
+	require.Equal(t, int64(0), mapper.GetAccount(ctx, addr1).GetSequence())
+
+	tx = newTestTxWithMemo(ctx, msgs, privs, accnums, []int64{0}, fee, "allowed")
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	acc1 = mapper.GetAccount(ctx, addr1)
+	require.Equal(t, int64(1), acc1.GetSequence())
+	require.Equal(t, priv1.PubKey(), acc1.GetPubKey())
+	require.True(t, acc1.GetCoins().IsEqual(newCoins()))
+	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(emptyCoins))
+}
+
+func generatePubKeysAndSignatures(n int, msg []byte, keyTypeed25519 bool) (pubkeys []crypto.PubKey, signatures [][]byte) {
+	pubkeys = make([]crypto.PubKey, n)
+	signatures = make([][]byte, n)
+	for i := 0; i < n; i++ {
+		var privkey crypto.PrivKey
+		if keyTypeed25519 {
+			privkey = ed25519.GenPrivKey()
+		} else {
+			privkey = secp256k1.GenPrivKey()
+		}
+		pubkeys[i] = privkey.PubKey()
+		signatures[i], _ = privkey.Sign(msg)
+	}
+	return
+}
+
+func expectedGasCostByKeys(pubkeys []crypto.PubKey) int64 {
+	cost := int64(0)
+	for _, pubkey := range pubkeys {
+		switch pubkey.(type) {
+		case ed25519.PubKeyEd25519:
+			cost += DefaultSigVerifyCostED25519
+		case secp256k1.PubKeySecp256k1:
+			cost += DefaultSigVerifyCostSecp256k1
+		default:
+			panic("unexpected key type")
+		}
+	}
+	return cost
+}
+
+func TestEnsureSufficientMempoolFees(t *testing.T) {
+	ctx := sdk.NewContext(nil, abci.Header{}, true, log.NewNopLogger())
+	ctx = ctx.WithMinGasPrices(sdk.DecCoins{
+		{Denom: "photino", Amount: sdk.NewDecWithPrec(1, 4)},
+		{Denom: "steak", Amount: sdk.NewDecWithPrec(25, 3)},
+	})
+
+	testCases := []struct {
+		input      StdFee
+		expectedOK bool
 	}{
-		{"nil coins", args{sdk.Coins{}, 10000}, sdk.Coins{}},
-		{"nil coins", args{sdk.Coins{sdk.NewInt64Coin("A", 10), sdk.NewInt64Coin("B", 0)}, 10000}, sdk.Coins{sdk.NewInt64Coin("A", 20), sdk.NewInt64Coin("B", 10)}},
-		{"negative coins", args{sdk.Coins{sdk.NewInt64Coin("A", -10), sdk.NewInt64Coin("B", 10)}, 10000}, sdk.Coins{sdk.NewInt64Coin("B", 20)}},
+		{NewStdFee(200000, sdk.NewInt64Coin("photino", 5)), false},
+		{NewStdFee(200000, sdk.NewInt64Coin("steak", 5)), false},
+		{NewStdFee(200000, sdk.NewInt64Coin("photino", 20)), true},
+		{NewStdFee(200000, sdk.NewInt64Coin("steak", 5000)), true},
+		{NewStdFee(200000, sdk.NewInt64Coin("photino", 20), sdk.NewInt64Coin("steak", 1)), true},
+		{NewStdFee(200000, sdk.NewInt64Coin("photino", 19), sdk.NewInt64Coin("steak", 4999)), false},
+		// fees are rounded up: 0.0001 * 10001 gas requires 2photino
+		{NewStdFee(10001, sdk.NewInt64Coin("photino", 1)), false},
+		{NewStdFee(10001, sdk.NewInt64Coin("photino", 2)), true},
+		{NewStdFee(200000, sdk.NewInt64Coin("atom", 100000)), false},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			require.True(t, tt.want.IsEqual(adjustFeesByGas(tt.args.fee, tt.args.gas)))
-		})
+
+	for i, tc := range testCases {
+		res := ensureSufficientMempoolFees(ctx, StdTx{Fee: tc.input})
+		require.Equal(t, tc.expectedOK, res.IsOK(), "unexpected result; tc #%d, input: %v, log: %v", i, tc.input, res.Log)
 	}
+
+	// without minimum gas prices every fee is accepted
+	ctx = ctx.WithMinGasPrices(sdk.DecCoins{})
+	require.True(t, ensureSufficientMempoolFees(ctx, StdTx{Fee: NewStdFee(200000)}).IsOK())
+}
+
+// Test that CheckTx reports the weighted gas price of the fee as the priority.
+func TestAnteHandlerMempoolPriority(t *testing.T) {
+	// setup
+	ms, capKey, capKey2, keyParams, tkeyParams := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, true, log.NewNopLogger())
+	mapper.SetParams(ctx, DefaultParams())
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(sdk.Coins{sdk.NewInt64Coin("atom", 1000), sdk.NewInt64Coin("photon", 1000)})
+	mapper.SetAccount(ctx, acc1)
+
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	fee := NewStdFee(5000, sdk.NewInt64Coin("atom", 100), sdk.NewInt64Coin("photon", 100))
+	tx := newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{0}, fee)
+
+	// without weights every denomination counts: 200 / 5000 gas
+	_, result, abort := anteHandler(ctx, tx, false)
+	require.False(t, abort)
+	require.Equal(t, int64(40000), result.Priority)
+
+	// only weighted denominations count: 0.5 * 100 / 5000 gas
+	ctx = ctx.WithFeeWeights(sdk.FeeWeights{"photon": sdk.NewDecWithPrec(5, 1)})
+	tx = newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{1}, fee)
+	_, result, abort = anteHandler(ctx, tx, false)
+	require.False(t, abort)
+	require.Equal(t, int64(10000), result.Priority)
+
+	// the priority is only computed on CheckTx
+	ctx = ctx.WithIsCheckTx(false)
+	tx = newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{2}, fee)
+	_, result, abort = anteHandler(ctx, tx, false)
+	require.False(t, abort)
+	require.Equal(t, int64(0), result.Priority)
 }
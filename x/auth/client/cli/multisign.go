@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	amino "github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto/multisig"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+)
+
+// GetMultiSignCommand returns the multi-sign command
+func GetMultiSignCommand(codec *amino.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "multisign <file> <name> <<signature>...>",
+		Short: "Generate multisig signatures for transactions generated offline",
+		Long: `Sign transactions created with the --generate-only flag that require multisig signatures.
+
+Read signature(s) from <signature> file(s), generate a multisig signature compliant to the
+multisig key <name>, and attach it to the transaction read from <file>. Example:
+
+   gaiacli tx multisign transaction.json k1k2k3 k1sig.json k2sig.json k3sig.json
+
+If the flag --signature-only flag is on, it outputs a JSON representation
+of the generated signature only.
+
+The --offline flag makes sure that the client will not reach out to the local cache.
+Thus account number or sequence number lookups will not be performed and it is
+recommended to set such parameters manually.`,
+		RunE: makeMultiSignCmd(codec, decoder),
+		Args: cobra.MinimumNArgs(3),
+	}
+	cmd.Flags().Bool(flagSignatureOnly, false, "Print only the generated signature, then exit")
+	cmd.Flags().Bool(flagOffline, false, "Offline mode. Do not query local cache.")
+	return cmd
+}
+
+func makeMultiSignCmd(cdc *amino.Codec, decoder auth.AccountDecoder) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) (err error) {
+		stdTx, err := readAndUnmarshalStdTx(cdc, args[0])
+		if err != nil {
+			return
+		}
+
+		keybase, err := keys.GetKeyBase()
+		if err != nil {
+			return
+		}
+
+		multisigInfo, err := keybase.Get(args[1])
+		if err != nil {
+			return
+		}
+		multisigPub, ok := multisigInfo.GetPubKey().(multisig.PubKeyMultisigThreshold)
+		if !ok {
+			return fmt.Errorf("%q must be a multisig threshold public key", args[1])
+		}
+
+		cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(decoder)
+		txBldr := authtxb.NewTxBuilderFromCLI()
+
+		if !viper.GetBool(flagOffline) {
+			addr := multisigInfo.GetAddress()
+			accnum, err := cliCtx.GetAccountNumber(addr)
+			if err != nil {
+				return err
+			}
+			seq, err := cliCtx.GetAccountSequence(addr)
+			if err != nil {
+				return err
+			}
+			txBldr = txBldr.WithAccountNumber(accnum).WithSequence(seq)
+		}
+
+		// read each signature and add it to the multisig if valid
+		multisigSig := multisig.NewMultisig(len(multisigPub.PubKeys))
+		signBytes := auth.StdSignBytes(
+			txBldr.ChainID, txBldr.AccountNumber, txBldr.Sequence,
+			stdTx.Fee, stdTx.GetMsgs(), stdTx.GetMemo(),
+		)
+		for _, sigFile := range args[2:] {
+			stdSig, err := readAndUnmarshalStdSignature(cdc, sigFile)
+			if err != nil {
+				return err
+			}
+			if stdSig.PubKey == nil || !stdSig.PubKey.VerifyBytes(signBytes, stdSig.Signature) {
+				return fmt.Errorf("couldn't verify signature from %s", sigFile)
+			}
+			if err := multisigSig.AddSignatureFromPubKey(stdSig.Signature, stdSig.PubKey, multisigPub.PubKeys); err != nil {
+				return errors.Wrap(err, sigFile)
+			}
+		}
+
+		newStdSig := auth.StdSignature{
+			PubKey:        multisigPub,
+			Signature:     multisigSig.Marshal(),
+			AccountNumber: txBldr.AccountNumber,
+			Sequence:      txBldr.Sequence,
+		}
+		newTx := auth.NewStdTx(stdTx.GetMsgs(), stdTx.Fee, []auth.StdSignature{newStdSig}, stdTx.GetMemo())
+
+		var json []byte
+		if viper.GetBool(flagSignatureOnly) {
+			json, err = marshalJSON(cdc, cliCtx.Indent, newTx.Signatures[0])
+		} else {
+			json, err = marshalJSON(cdc, cliCtx.Indent, newTx)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", json)
+		return
+	}
+}
+
+func readAndUnmarshalStdSignature(cdc *amino.Codec, filename string) (stdSig auth.StdSignature, err error) {
+	var bytes []byte
+	if bytes, err = ioutil.ReadFile(filename); err != nil {
+		return
+	}
+	if err = cdc.UnmarshalJSON(bytes, &stdSig); err != nil {
+		return
+	}
+	return
+}
@@ -17,9 +17,11 @@ import (
 )
 
 const (
-	flagAppend    = "append"
-	flagPrintSigs = "print-sigs"
-	flagOffline   = "offline"
+	flagAppend        = "append"
+	flagPrintSigs     = "print-sigs"
+	flagOffline       = "offline"
+	flagMultisig      = "multisig"
+	flagSignatureOnly = "signature-only"
 )
 
 // GetSignCommand returns the sign command
@@ -32,7 +34,12 @@ Read a transaction from <file>, sign it, and print its JSON encoding.
 
 The --offline flag makes sure that the client will not reach out to the local cache.
 Thus account number or sequence number lookups will not be performed and it is
-recommended to set such parameters manually.`,
+recommended to set such parameters manually.
+
+The --multisig=<multisig_address> flag generates a signature on behalf of a
+multisig account. The account and sequence numbers of the multisig account are
+used and only the resulting signature is printed, ready to be combined with
+the other signers' ones via the multisign command.`,
 		RunE: makeSignCmd(codec, decoder),
 		Args: cobra.ExactArgs(1),
 	}
@@ -40,6 +47,9 @@ recommended to set such parameters manually.`,
 	cmd.Flags().Bool(flagAppend, true, "Append the signature to the existing ones. If disabled, old signatures would be overwritten")
 	cmd.Flags().Bool(flagPrintSigs, false, "Print the addresses that must sign the transaction and those who have already signed it, then exit")
 	cmd.Flags().Bool(flagOffline, false, "Offline mode. Do not query local cache.")
+	cmd.Flags().String(flagMultisig, "",
+		"Address of the multisig account on behalf of which the transaction shall be signed")
+	cmd.Flags().Bool(flagSignatureOnly, false, "Print only the generated signature, then exit")
 	return cmd
 }
 
@@ -59,15 +69,31 @@ func makeSignCmd(cdc *amino.Codec, decoder auth.AccountDecoder) func(cmd *cobra.
 		cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(decoder)
 		txBldr := authtxb.NewTxBuilderFromCLI()
 
-		newTx, err := utils.SignStdTx(txBldr, cliCtx, name, stdTx, viper.GetBool(flagAppend), viper.GetBool(flagOffline))
+		var newTx auth.StdTx
+		generateSignatureOnly := viper.GetBool(flagSignatureOnly)
+		multisigAddrStr := viper.GetString(flagMultisig)
+		if multisigAddrStr != "" {
+			var multisigAddr sdk.AccAddress
+			multisigAddr, err = sdk.AccAddressFromBech32(multisigAddrStr)
+			if err != nil {
+				return err
+			}
+			newTx, err = utils.SignStdTxWithSignerAddress(
+				txBldr, cliCtx, multisigAddr, name, stdTx, viper.GetBool(flagOffline))
+			generateSignatureOnly = true
+		} else {
+			appendSig := viper.GetBool(flagAppend) && !generateSignatureOnly
+			newTx, err = utils.SignStdTx(txBldr, cliCtx, name, stdTx, appendSig, viper.GetBool(flagOffline))
+		}
 		if err != nil {
 			return err
 		}
+
 		var json []byte
-		if cliCtx.Indent {
-			json, err = cdc.MarshalJSONIndent(newTx, "", "  ")
+		if generateSignatureOnly {
+			json, err = marshalJSON(cdc, cliCtx.Indent, newTx.Signatures[0])
 		} else {
-			json, err = cdc.MarshalJSON(newTx)
+			json, err = marshalJSON(cdc, cliCtx.Indent, newTx)
 		}
 		if err != nil {
 			return err
@@ -77,6 +103,13 @@ func makeSignCmd(cdc *amino.Codec, decoder auth.AccountDecoder) func(cmd *cobra.
 	}
 }
 
+func marshalJSON(cdc *amino.Codec, indent bool, o interface{}) ([]byte, error) {
+	if indent {
+		return cdc.MarshalJSONIndent(o, "", "  ")
+	}
+	return cdc.MarshalJSON(o)
+}
+
 func printSignatures(stdTx auth.StdTx) {
 	fmt.Println("Signers:")
 	for i, signer := range stdTx.GetSigners() {
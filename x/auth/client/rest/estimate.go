@@ -0,0 +1,71 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+)
+
+// EstimateGasBody defines the properties of a gas estimation request's body.
+// The tx is unsigned, e.g. as returned by the generate_only mode of the tx
+// endpoints.
+type EstimateGasBody struct {
+	Tx            auth.StdTx `json:"tx"`
+	GasAdjustment string     `json:"gas_adjustment"`
+	GasPrices     string     `json:"gas_prices"`
+}
+
+// EstimateGasResponse defines the properties of a gas estimation response.
+// The fee pays for the adjusted gas at the requested gas prices, or carries
+// the amount of the tx's fee if none were given.
+type EstimateGasResponse struct {
+	GasEstimate int64       `json:"gas_estimate"`
+	GasAdjusted int64       `json:"gas_adjusted"`
+	Fee         auth.StdFee `json:"fee"`
+}
+
+// estimate gas REST handler
+func EstimateGasRequestHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	cliCtx = cliCtx.WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		var m EstimateGasBody
+		if err := utils.ReadRESTReq(w, r, cdc, &m); err != nil {
+			return
+		}
+
+		adjustment, ok := utils.ParseFloat64OrReturnBadRequest(w, m.GasAdjustment, client.DefaultGasAdjustment)
+		if !ok {
+			return
+		}
+
+		gasPrices, err := sdk.ParseDecCoins(m.GasPrices)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		estimate, adjusted, err := utils.EstimateStdTxGas(cliCtx, m.Tx, adjustment)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		fee := auth.NewStdFee(adjusted, m.Tx.Fee.Amount...)
+		if len(gasPrices) > 0 {
+			fee = auth.NewStdFeeFromGasPrices(adjusted, gasPrices)
+		}
+
+		utils.PostProcessResponse(w, cdc, EstimateGasResponse{
+			GasEstimate: estimate,
+			GasAdjusted: adjusted,
+			Fee:         fee,
+		}, cliCtx.Indent)
+	}
+}
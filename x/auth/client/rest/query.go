@@ -19,6 +19,10 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec,
 		"/auth/accounts/{address}",
 		QueryAccountRequestHandlerFn(storeName, cdc, authcmd.GetAccountDecoder(cdc), cliCtx),
 	).Methods("GET")
+	r.HandleFunc(
+		"/auth/params",
+		QueryParamsRequestHandlerFn(cdc, cliCtx),
+	).Methods("GET")
 	r.HandleFunc(
 		"/bank/balances/{address}",
 		QueryBalancesRequestHandlerFn(storeName, cdc, authcmd.GetAccountDecoder(cdc), cliCtx),
@@ -27,6 +31,10 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec,
 		"/tx/sign",
 		SignTxRequestHandlerFn(cdc, cliCtx),
 	).Methods("POST")
+	r.HandleFunc(
+		"/tx/estimate_gas",
+		EstimateGasRequestHandlerFn(cdc, cliCtx),
+	).Methods("POST")
 }
 
 // query accountREST Handler
@@ -105,3 +113,15 @@ func QueryBalancesRequestHandlerFn(
 		utils.PostProcessResponse(w, cdc, account.GetCoins(), cliCtx.Indent)
 	}
 }
+
+// query auth params REST Handler
+func QueryParamsRequestHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := cliCtx.QueryWithData("custom/"+auth.QuerierRoute+"/"+auth.QueryParams, nil)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
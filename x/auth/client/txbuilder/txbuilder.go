@@ -22,6 +22,7 @@ type TxBuilder struct {
 	ChainID       string
 	Memo          string
 	Fee           string
+	GasPrices     string
 }
 
 // NewTxBuilderFromCLI returns a new initialized TxBuilder with parameters from
@@ -44,6 +45,7 @@ func NewTxBuilderFromCLI() TxBuilder {
 		Sequence:      viper.GetInt64(client.FlagSequence),
 		SimulateGas:   client.GasFlagVar.Simulate,
 		Fee:           viper.GetString(client.FlagFee),
+		GasPrices:     viper.GetString(client.FlagGasPrices),
 		Memo:          viper.GetString(client.FlagMemo),
 	}
 }
@@ -72,6 +74,12 @@ func (bldr TxBuilder) WithFee(fee string) TxBuilder {
 	return bldr
 }
 
+// WithGasPrices returns a copy of the context with updated gas prices.
+func (bldr TxBuilder) WithGasPrices(gasPrices string) TxBuilder {
+	bldr.GasPrices = gasPrices
+	return bldr
+}
+
 // WithSequence returns a copy of the context with an updated sequence number.
 func (bldr TxBuilder) WithSequence(sequence int64) TxBuilder {
 	bldr.Sequence = sequence
@@ -91,21 +99,35 @@ func (bldr TxBuilder) WithAccountNumber(accnum int64) TxBuilder {
 }
 
 // Build builds a single message to be signed from a TxBuilder given a set of
-// messages. It returns an error if a fee is supplied but cannot be parsed.
+// messages. It returns an error if a fee or gas prices are supplied but cannot
+// be parsed, or if both are supplied. When gas prices are supplied, the fee
+// pays for the gas at those prices in each of their denominations.
 func (bldr TxBuilder) Build(msgs []sdk.Msg) (StdSignMsg, error) {
 	chainID := bldr.ChainID
 	if chainID == "" {
 		return StdSignMsg{}, errors.Errorf("chain ID required but not specified")
 	}
+	if bldr.Fee != "" && bldr.GasPrices != "" {
+		return StdSignMsg{}, errors.Errorf("cannot provide both fees and gas prices")
+	}
 
-	fee := sdk.Coin{}
+	fees := sdk.Coins{sdk.Coin{}}
 	if bldr.Fee != "" {
 		parsedFee, err := sdk.ParseCoin(bldr.Fee)
 		if err != nil {
 			return StdSignMsg{}, err
 		}
 
-		fee = parsedFee
+		fees = sdk.Coins{parsedFee}
+	}
+
+	if bldr.GasPrices != "" {
+		gasPrices, err := sdk.ParseDecCoins(bldr.GasPrices)
+		if err != nil {
+			return StdSignMsg{}, err
+		}
+
+		fees = auth.NewStdFeeFromGasPrices(bldr.Gas, gasPrices).Amount
 	}
 
 	return StdSignMsg{
@@ -114,7 +136,7 @@ func (bldr TxBuilder) Build(msgs []sdk.Msg) (StdSignMsg, error) {
 		Sequence:      bldr.Sequence,
 		Memo:          bldr.Memo,
 		Msgs:          msgs,
-		Fee:           auth.NewStdFee(bldr.Gas, fee),
+		Fee:           auth.NewStdFee(bldr.Gas, fees...),
 	}, nil
 }
 
@@ -28,6 +28,7 @@ func TestTxBuilderBuild(t *testing.T) {
 		ChainID       string
 		Memo          string
 		Fee           string
+		GasPrices     string
 	}
 	defaultMsg := []sdk.Msg{sdk.NewTestMsg(addr)}
 	tests := []struct {
@@ -59,6 +60,37 @@ func TestTxBuilderBuild(t *testing.T) {
 			},
 			false,
 		},
+		{
+			fields{
+				Codec:         codec.New(),
+				AccountNumber: 1,
+				Sequence:      1,
+				Gas:           150,
+				ChainID:       "test-chain",
+				GasPrices:     "0.01steak,1.5photino",
+			},
+			defaultMsg,
+			StdSignMsg{
+				ChainID:       "test-chain",
+				AccountNumber: 1,
+				Sequence:      1,
+				Msgs:          defaultMsg,
+				Fee:           auth.NewStdFee(150, sdk.NewCoin("photino", sdk.NewInt(225)), sdk.NewCoin("steak", sdk.NewInt(2))),
+			},
+			false,
+		},
+		{
+			fields{
+				Codec:     codec.New(),
+				Gas:       100,
+				ChainID:   "test-chain",
+				Fee:       "1steak",
+				GasPrices: "0.01steak",
+			},
+			defaultMsg,
+			StdSignMsg{},
+			true,
+		},
 	}
 	for i, tc := range tests {
 		bldr := TxBuilder{
@@ -71,6 +103,7 @@ func TestTxBuilderBuild(t *testing.T) {
 			ChainID:       tc.fields.ChainID,
 			Memo:          tc.fields.Memo,
 			Fee:           tc.fields.Fee,
+			GasPrices:     tc.fields.GasPrices,
 		}
 		got, err := bldr.Build(tc.msgs)
 		require.Equal(t, tc.wantErr, (err != nil), "TxBuilder.Build() error = %v, wantErr %v, tc %d", err, tc.wantErr, i)
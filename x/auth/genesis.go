@@ -0,0 +1,37 @@
+package auth
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all auth state that must be provided at genesis
+type GenesisState struct {
+	Params Params `json:"params"`
+}
+
+func NewGenesisState(params Params) GenesisState {
+	return GenesisState{
+		Params: params,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return NewGenesisState(DefaultParams())
+}
+
+// InitGenesis sets the auth parameters
+func InitGenesis(ctx sdk.Context, keeper AccountKeeper, data GenesisState) {
+	keeper.SetParams(ctx, data.Params)
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper
+func ExportGenesis(ctx sdk.Context, keeper AccountKeeper) GenesisState {
+	return NewGenesisState(keeper.GetParams(ctx))
+}
+
+// ValidateGenesis validates the provided auth genesis state to ensure the
+// expected invariants holds (i.e. params in correct bounds)
+func ValidateGenesis(data GenesisState) error {
+	return validateParams(data.Params)
+}
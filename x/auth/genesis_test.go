@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	codec "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+func TestGenesis(t *testing.T) {
+	ms, capKey, _, keyParams, tkeyParams := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
+
+	genesis := DefaultGenesisState()
+	require.Nil(t, ValidateGenesis(genesis))
+
+	genesis.Params.TxSigLimit = 3
+	InitGenesis(ctx, mapper, genesis)
+	require.Equal(t, genesis, ExportGenesis(ctx, mapper))
+
+	genesis.Params.TxSizeCostPerByte = 0
+	require.NotNil(t, ValidateGenesis(genesis))
+	require.NotNil(t, ValidateGenesis(GenesisState{}))
+}
+
+func TestQueryParams(t *testing.T) {
+	ms, capKey, _, keyParams, tkeyParams := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
+	mapper.SetParams(ctx, DefaultParams())
+	querier := NewQuerier(mapper)
+
+	_, err := querier(ctx, []string{"other"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	res, err := querier(ctx, []string{QueryParams}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var params Params
+	require.Nil(t, cdc.UnmarshalJSON(res, &params))
+	require.Equal(t, DefaultParams(), params)
+}
+
+func TestGetParamsMissing(t *testing.T) {
+	ms, capKey, _, keyParams, tkeyParams := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
+
+	// no params stored, as on a chain started before they existed
+	require.Equal(t, DefaultParams(), mapper.GetParams(ctx))
+
+	// the stored params override the defaults
+	mapper.paramSubspace.Set(ctx, KeyTxSigLimit, int64(3))
+	expected := DefaultParams()
+	expected.TxSigLimit = 3
+	require.Equal(t, expected, mapper.GetParams(ctx))
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	codec "github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/tendermint/tendermint/crypto"
 )
 
@@ -20,16 +21,20 @@ type AccountKeeper struct {
 
 	// The codec codec for binary encoding/decoding of accounts.
 	cdc *codec.Codec
+
+	// The parameters of the AnteHandler, see Params.
+	paramSubspace params.Subspace
 }
 
 // NewAccountKeeper returns a new sdk.AccountKeeper that
 // uses go-amino to (binary) encode and decode concrete sdk.Accounts.
 // nolint
-func NewAccountKeeper(cdc *codec.Codec, key sdk.StoreKey, proto func() Account) AccountKeeper {
+func NewAccountKeeper(cdc *codec.Codec, key sdk.StoreKey, paramstore params.Subspace, proto func() Account) AccountKeeper {
 	return AccountKeeper{
-		key:   key,
-		proto: proto,
-		cdc:   cdc,
+		key:           key,
+		proto:         proto,
+		cdc:           cdc,
+		paramSubspace: paramstore.WithTypeTable(ParamTypeTable()),
 	}
 }
 
@@ -12,27 +12,33 @@ import (
 	codec "github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
-func setupMultiStore() (sdk.MultiStore, *sdk.KVStoreKey, *sdk.KVStoreKey) {
+func setupMultiStore() (sdk.MultiStore, *sdk.KVStoreKey, *sdk.KVStoreKey, *sdk.KVStoreKey, *sdk.TransientStoreKey) {
 	db := dbm.NewMemDB()
 	capKey := sdk.NewKVStoreKey("capkey")
 	capKey2 := sdk.NewKVStoreKey("capkey2")
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(capKey, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(capKey2, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
 	ms.LoadLatestVersion()
-	return ms, capKey, capKey2
+	return ms, capKey, capKey2, keyParams, tkeyParams
 }
 
 func TestAccountMapperGetSet(t *testing.T) {
-	ms, capKey, _ := setupMultiStore()
+	ms, capKey, _, keyParams, tkeyParams := setupMultiStore()
 	cdc := codec.New()
 	RegisterBaseAccount(cdc)
 
 	// make context and mapper
 	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
-	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
 
 	addr := sdk.AccAddress([]byte("some-address"))
 
@@ -62,13 +68,14 @@ func TestAccountMapperGetSet(t *testing.T) {
 }
 
 func TestAccountMapperRemoveAccount(t *testing.T) {
-	ms, capKey, _ := setupMultiStore()
+	ms, capKey, _, keyParams, tkeyParams := setupMultiStore()
 	cdc := codec.New()
 	RegisterBaseAccount(cdc)
 
 	// make context and mapper
 	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
-	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
 
 	addr1 := sdk.AccAddress([]byte("addr1"))
 	addr2 := sdk.AccAddress([]byte("addr2"))
@@ -100,13 +107,14 @@ func TestAccountMapperRemoveAccount(t *testing.T) {
 }
 
 func BenchmarkAccountMapperGetAccountFound(b *testing.B) {
-	ms, capKey, _ := setupMultiStore()
+	ms, capKey, _, keyParams, tkeyParams := setupMultiStore()
 	cdc := codec.New()
 	RegisterBaseAccount(cdc)
 
 	// make context and mapper
 	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
-	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	mapper := NewAccountKeeper(cdc, capKey, pk.Subspace(DefaultParamspace), ProtoBaseAccount)
 
 	// assumes b.N < 2**24
 	for i := 0; i < b.N; i++ {
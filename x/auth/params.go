@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// Default parameter namespace
+const (
+	DefaultParamspace = "auth"
+)
+
+// Default parameter values
+const (
+	DefaultMaxMemoCharacters      int64 = 100
+	DefaultTxSigLimit             int64 = 7
+	DefaultTxSizeCostPerByte      int64 = 10
+	DefaultSigVerifyCostED25519   int64 = 59
+	DefaultSigVerifyCostSecp256k1 int64 = 100
+)
+
+// Parameter store key
+var (
+	KeyMaxMemoCharacters      = []byte("MaxMemoCharacters")
+	KeyTxSigLimit             = []byte("TxSigLimit")
+	KeyTxSizeCostPerByte      = []byte("TxSizeCostPerByte")
+	KeySigVerifyCostED25519   = []byte("SigVerifyCostED25519")
+	KeySigVerifyCostSecp256k1 = []byte("SigVerifyCostSecp256k1")
+)
+
+// ParamTypeTable for auth module
+func ParamTypeTable() params.TypeTable {
+	return params.NewTypeTable().RegisterParamSet(&Params{})
+}
+
+// Params defines the limits and the gas costs the AnteHandler applies to txs
+type Params struct {
+	MaxMemoCharacters      int64 `json:"max_memo_characters"`
+	TxSigLimit             int64 `json:"tx_sig_limit"`
+	TxSizeCostPerByte      int64 `json:"tx_size_cost_per_byte"`
+	SigVerifyCostED25519   int64 `json:"sig_verify_cost_ed25519"`
+	SigVerifyCostSecp256k1 int64 `json:"sig_verify_cost_secp256k1"`
+}
+
+// Implements params.ParamSet
+func (p *Params) KeyValuePairs() params.KeyValuePairs {
+	return params.KeyValuePairs{
+		{KeyMaxMemoCharacters, &p.MaxMemoCharacters},
+		{KeyTxSigLimit, &p.TxSigLimit},
+		{KeyTxSizeCostPerByte, &p.TxSizeCostPerByte},
+		{KeySigVerifyCostED25519, &p.SigVerifyCostED25519},
+		{KeySigVerifyCostSecp256k1, &p.SigVerifyCostSecp256k1},
+	}
+}
+
+// DefaultParams returns a default set of parameters.
+func DefaultParams() Params {
+	return Params{
+		MaxMemoCharacters:      DefaultMaxMemoCharacters,
+		TxSigLimit:             DefaultTxSigLimit,
+		TxSizeCostPerByte:      DefaultTxSizeCostPerByte,
+		SigVerifyCostED25519:   DefaultSigVerifyCostED25519,
+		SigVerifyCostSecp256k1: DefaultSigVerifyCostSecp256k1,
+	}
+}
+
+// HumanReadableString returns a human readable string representation of the
+// parameters.
+func (p Params) HumanReadableString() string {
+	resp := "Params \n"
+	resp += fmt.Sprintf("Max Memo Characters: %d\n", p.MaxMemoCharacters)
+	resp += fmt.Sprintf("Tx Signature Limit: %d\n", p.TxSigLimit)
+	resp += fmt.Sprintf("Tx Size Cost Per Byte: %d\n", p.TxSizeCostPerByte)
+	resp += fmt.Sprintf("Signature Verification Cost ED25519: %d\n", p.SigVerifyCostED25519)
+	resp += fmt.Sprintf("Signature Verification Cost Secp256k1: %d\n", p.SigVerifyCostSecp256k1)
+	return resp
+}
+
+// validateParams checks that the parameters are all set, as a zero value
+// would disable the corresponding limit or make a tx free.
+func validateParams(params Params) error {
+	if params.MaxMemoCharacters <= 0 {
+		return fmt.Errorf("auth parameter MaxMemoCharacters must be positive")
+	}
+	if params.TxSigLimit <= 0 {
+		return fmt.Errorf("auth parameter TxSigLimit must be positive")
+	}
+	if params.TxSizeCostPerByte <= 0 {
+		return fmt.Errorf("auth parameter TxSizeCostPerByte must be positive")
+	}
+	if params.SigVerifyCostED25519 <= 0 {
+		return fmt.Errorf("auth parameter SigVerifyCostED25519 must be positive")
+	}
+	if params.SigVerifyCostSecp256k1 <= 0 {
+		return fmt.Errorf("auth parameter SigVerifyCostSecp256k1 must be positive")
+	}
+	return nil
+}
+
+// GetParams returns the auth parameters. The parameters missing from the
+// store, as on chains started before they were introduced, have their
+// default value.
+func (am AccountKeeper) GetParams(ctx sdk.Context) (params Params) {
+	params = DefaultParams()
+	for _, pair := range params.KeyValuePairs() {
+		am.paramSubspace.GetIfExists(ctx, pair.Key, pair.Value)
+	}
+	return
+}
+
+// SetParams sets the auth parameters.
+func (am AccountKeeper) SetParams(ctx sdk.Context, params Params) {
+	am.paramSubspace.SetParamSet(ctx, &params)
+}
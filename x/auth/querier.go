@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// QuerierRoute is the route of the auth Querier
+const QuerierRoute = "auth"
+
+// query endpoints supported by the auth Querier
+const (
+	QueryParams = "params"
+)
+
+// NewQuerier creates a querier for auth REST endpoints
+func NewQuerier(am AccountKeeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryParams:
+			return queryParams(ctx, am)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown auth query endpoint")
+		}
+	}
+}
+
+func queryParams(ctx sdk.Context, am AccountKeeper) (res []byte, err sdk.Error) {
+	params := am.GetParams(ctx)
+
+	bz, err2 := codec.MarshalJSONIndent(am.cdc, params)
+	if err2 != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+	}
+	return bz, nil
+}
@@ -63,6 +63,16 @@ func (tx StdTx) GetMemo() string { return tx.Memo }
 // .Empty().
 func (tx StdTx) GetSignatures() []StdSignature { return tx.Signatures }
 
+// GetFeePayerSequence returns the first signer, who pays the fee, and the
+// sequence of its signature. The address is empty for txs without signatures.
+func (tx StdTx) GetFeePayerSequence() (sdk.AccAddress, int64) {
+	signers := tx.GetSigners()
+	if len(signers) == 0 || len(tx.Signatures) == 0 {
+		return nil, 0
+	}
+	return signers[0], tx.Signatures[0].Sequence
+}
+
 //__________________________________________________________
 
 // StdFee includes the amount of coins paid in fees and the maximum
@@ -80,6 +90,18 @@ func NewStdFee(gas int64, amount ...sdk.Coin) StdFee {
 	}
 }
 
+// NewStdFeeFromGasPrices returns a fee paying for the given gas at the given
+// prices, in each of their denominations. Amounts are rounded up.
+func NewStdFeeFromGasPrices(gas int64, gasPrices sdk.DecCoins) StdFee {
+	amount := make(sdk.Coins, len(gasPrices))
+	glDec := sdk.NewDec(gas)
+	for i, gp := range gasPrices {
+		fee := gp.Amount.Mul(glDec)
+		amount[i] = sdk.NewCoin(gp.Denom, fee.Ceil().RoundInt())
+	}
+	return NewStdFee(gas, amount...)
+}
+
 // fee bytes for signing later
 func (fee StdFee) Bytes() []byte {
 	// normalize. XXX
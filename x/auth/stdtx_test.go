@@ -27,6 +27,19 @@ func TestStdTx(t *testing.T) {
 	require.Equal(t, addr, feePayer)
 }
 
+func TestNewStdFeeFromGasPrices(t *testing.T) {
+	gasPrices, err := sdk.ParseDecCoins("0.025steak,0.0001photino")
+	require.NoError(t, err)
+
+	// 0.0001 * 10001 gas is rounded up
+	fee := NewStdFeeFromGasPrices(10001, gasPrices)
+	require.Equal(t, int64(10001), fee.Gas)
+	require.True(t, fee.Amount.IsEqual(sdk.Coins{sdk.NewInt64Coin("photino", 2), sdk.NewInt64Coin("steak", 251)}))
+
+	fee = NewStdFeeFromGasPrices(10000, nil)
+	require.Empty(t, fee.Amount)
+}
+
 func TestStdSignBytes(t *testing.T) {
 	type args struct {
 		chainID  string
@@ -23,14 +23,19 @@ func NewHandler(k Keeper) sdk.Handler {
 func handleMsgSend(ctx sdk.Context, k Keeper, msg MsgSend) sdk.Result {
 	// NOTE: totalIn == totalOut should already have been checked
 
-	tags, err := k.InputOutputCoins(ctx, msg.Inputs, msg.Outputs)
+	err := k.InputOutputCoins(ctx, msg.Inputs, msg.Outputs)
 	if err != nil {
 		return err.Result()
 	}
 
-	return sdk.Result{
-		Tags: tags,
+	for _, in := range msg.Inputs {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, "bank"),
+			sdk.NewAttribute(sdk.AttributeKeySender, in.Address.String()),
+		))
 	}
+
+	return sdk.Result{}
 }
 
 // Handle MsgIssue.
@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank/tags"
 )
 
 const (
@@ -20,8 +21,11 @@ const (
 type Keeper interface {
 	SendKeeper
 	SetCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) sdk.Error
-	SubtractCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error)
-	AddCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error)
+	SubtractCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Error)
+	AddCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Error)
+
+	DelegateCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) sdk.Error
+	UndelegateCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) sdk.Error
 }
 
 var _ Keeper = (*BaseKeeper)(nil)
@@ -55,7 +59,7 @@ func (keeper BaseKeeper) HasCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.
 // SubtractCoins subtracts amt from the coins at the addr.
 func (keeper BaseKeeper) SubtractCoins(
 	ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins,
-) (sdk.Coins, sdk.Tags, sdk.Error) {
+) (sdk.Coins, sdk.Error) {
 
 	return subtractCoins(ctx, keeper.am, addr, amt)
 }
@@ -63,21 +67,40 @@ func (keeper BaseKeeper) SubtractCoins(
 // AddCoins adds amt to the coins at the addr.
 func (keeper BaseKeeper) AddCoins(
 	ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins,
-) (sdk.Coins, sdk.Tags, sdk.Error) {
+) (sdk.Coins, sdk.Error) {
 
 	return addCoins(ctx, keeper.am, addr, amt)
 }
 
+// DelegateCoins removes amt from the coins at the addr for the purpose of
+// staking. Unlike SubtractCoins, coins locked by a vesting schedule may be
+// delegated.
+func (keeper BaseKeeper) DelegateCoins(
+	ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins,
+) sdk.Error {
+
+	return delegateCoins(ctx, keeper.am, addr, amt)
+}
+
+// UndelegateCoins returns amt to the coins at the addr once it is no longer
+// staked, restoring the vesting bookkeeping of vesting accounts.
+func (keeper BaseKeeper) UndelegateCoins(
+	ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins,
+) sdk.Error {
+
+	return undelegateCoins(ctx, keeper.am, addr, amt)
+}
+
 // SendCoins moves coins from one account to another
 func (keeper BaseKeeper) SendCoins(
 	ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins,
-) (sdk.Tags, sdk.Error) {
+) sdk.Error {
 
 	return sendCoins(ctx, keeper.am, fromAddr, toAddr, amt)
 }
 
 // InputOutputCoins handles a list of inputs and outputs
-func (keeper BaseKeeper) InputOutputCoins(ctx sdk.Context, inputs []Input, outputs []Output) (sdk.Tags, sdk.Error) {
+func (keeper BaseKeeper) InputOutputCoins(ctx sdk.Context, inputs []Input, outputs []Output) sdk.Error {
 	return inputOutputCoins(ctx, keeper.am, inputs, outputs)
 }
 
@@ -87,8 +110,8 @@ func (keeper BaseKeeper) InputOutputCoins(ctx sdk.Context, inputs []Input, outpu
 // between accounts without the possibility of creating coins.
 type SendKeeper interface {
 	ViewKeeper
-	SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error)
-	InputOutputCoins(ctx sdk.Context, inputs []Input, outputs []Output) (sdk.Tags, sdk.Error)
+	SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) sdk.Error
+	InputOutputCoins(ctx sdk.Context, inputs []Input, outputs []Output) sdk.Error
 }
 
 var _ SendKeeper = (*BaseSendKeeper)(nil)
@@ -117,7 +140,7 @@ func (keeper BaseSendKeeper) HasCoins(ctx sdk.Context, addr sdk.AccAddress, amt
 // SendCoins moves coins from one account to another
 func (keeper BaseSendKeeper) SendCoins(
 	ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins,
-) (sdk.Tags, sdk.Error) {
+) sdk.Error {
 
 	return sendCoins(ctx, keeper.am, fromAddr, toAddr, amt)
 }
@@ -125,7 +148,7 @@ func (keeper BaseSendKeeper) SendCoins(
 // InputOutputCoins handles a list of inputs and outputs
 func (keeper BaseSendKeeper) InputOutputCoins(
 	ctx sdk.Context, inputs []Input, outputs []Output,
-) (sdk.Tags, sdk.Error) {
+) sdk.Error {
 
 	return inputOutputCoins(ctx, keeper.am, inputs, outputs)
 }
@@ -187,74 +210,150 @@ func setCoins(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, amt s
 	return nil
 }
 
+// getSpendableCoins returns the coins at the addr which are not locked by a
+// vesting schedule at the current block time.
+func getSpendableCoins(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress) sdk.Coins {
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		return sdk.Coins{}
+	}
+	return auth.SpendableCoins(acc, ctx.BlockHeader().Time)
+}
+
 // HasCoins returns whether or not an account has at least amt coins.
 func hasCoins(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, amt sdk.Coins) bool {
 	ctx.GasMeter().ConsumeGas(costHasCoins, "hasCoins")
 	return getCoins(ctx, am, addr).IsGTE(amt)
 }
 
-// SubtractCoins subtracts amt from the coins at the addr.
-func subtractCoins(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+// SubtractCoins subtracts amt from the coins at the addr, emitting a transfer
+// event from the addr. Coins locked by a vesting schedule cannot be
+// subtracted.
+func subtractCoins(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Error) {
 	ctx.GasMeter().ConsumeGas(costSubtractCoins, "subtractCoins")
 	oldCoins := getCoins(ctx, am, addr)
+	spendableCoins := getSpendableCoins(ctx, am, addr)
+	if !spendableCoins.Minus(amt).IsNotNegative() {
+		return amt, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", spendableCoins, amt))
+	}
 	newCoins := oldCoins.Minus(amt)
 	if !newCoins.IsNotNegative() {
-		return amt, nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", oldCoins, amt))
+		return amt, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", oldCoins, amt))
 	}
 	err := setCoins(ctx, am, addr, newCoins)
-	tags := sdk.NewTags("sender", []byte(addr.String()))
-	return newCoins, tags, err
+	emitTransfer(ctx, tags.Sender, addr, amt)
+	return newCoins, err
 }
 
-// AddCoins adds amt to the coins at the addr.
-func addCoins(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+// AddCoins adds amt to the coins at the addr, emitting a transfer event to
+// the addr.
+func addCoins(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Error) {
 	ctx.GasMeter().ConsumeGas(costAddCoins, "addCoins")
 	oldCoins := getCoins(ctx, am, addr)
 	newCoins := oldCoins.Plus(amt)
 	if !newCoins.IsNotNegative() {
-		return amt, nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", oldCoins, amt))
+		return amt, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", oldCoins, amt))
 	}
 	err := setCoins(ctx, am, addr, newCoins)
-	tags := sdk.NewTags("recipient", []byte(addr.String()))
-	return newCoins, tags, err
+	emitTransfer(ctx, tags.Recipient, addr, amt)
+	return newCoins, err
 }
 
-// SendCoins moves coins from one account to another
-// NOTE: Make sure to revert state changes from tx on error
-func sendCoins(ctx sdk.Context, am auth.AccountKeeper, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error) {
-	_, subTags, err := subtractCoins(ctx, am, fromAddr, amt)
-	if err != nil {
-		return nil, err
+// emits a transfer event of amt from or to the addr, as the sender or the
+// recipient
+func emitTransfer(ctx sdk.Context, role string, addr sdk.AccAddress, amt sdk.Coins) {
+	ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeTransfer,
+		sdk.NewAttribute(role, addr.String()),
+		sdk.NewAttribute(tags.Amount, amt.String()),
+	))
+}
+
+// delegateCoins removes amt from the coins at the addr, tracking the
+// delegated vesting and delegated free amounts of vesting accounts.
+func delegateCoins(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, amt sdk.Coins) sdk.Error {
+	ctx.GasMeter().ConsumeGas(costSubtractCoins, "delegateCoins")
+	if !amt.IsNotNegative() {
+		return sdk.ErrInvalidCoins(amt.String())
+	}
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		return sdk.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", addr))
+	}
+	oldCoins := acc.GetCoins()
+	if !oldCoins.IsGTE(amt) {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", oldCoins, amt))
 	}
 
-	_, addTags, err := addCoins(ctx, am, toAddr, amt)
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		if !amt.IsZero() {
+			vacc.TrackDelegation(ctx.BlockHeader().Time, amt)
+		}
+	} else if err := acc.SetCoins(oldCoins.Minus(amt)); err != nil {
+		// Handle w/ #870
+		panic(err)
+	}
+	ctx.GasMeter().ConsumeGas(costSetCoins, "setCoins")
+	am.SetAccount(ctx, acc)
+
+	emitTransfer(ctx, tags.Sender, addr, amt)
+	return nil
+}
+
+// undelegateCoins adds amt back to the coins at the addr, releasing the
+// delegated free coins before the delegated vesting ones of vesting accounts.
+func undelegateCoins(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, amt sdk.Coins) sdk.Error {
+	ctx.GasMeter().ConsumeGas(costAddCoins, "undelegateCoins")
+	if !amt.IsNotNegative() {
+		return sdk.ErrInvalidCoins(amt.String())
+	}
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		acc = am.NewAccountWithAddress(ctx, addr)
+	}
+
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		if !amt.IsZero() {
+			vacc.TrackUndelegation(amt)
+		}
+	} else if err := acc.SetCoins(acc.GetCoins().Plus(amt)); err != nil {
+		// Handle w/ #870
+		panic(err)
+	}
+	ctx.GasMeter().ConsumeGas(costSetCoins, "setCoins")
+	am.SetAccount(ctx, acc)
+
+	emitTransfer(ctx, tags.Recipient, addr, amt)
+	return nil
+}
+
+// SendCoins moves coins from one account to another
+// NOTE: Make sure to revert state changes from tx on error
+func sendCoins(ctx sdk.Context, am auth.AccountKeeper, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) sdk.Error {
+	_, err := subtractCoins(ctx, am, fromAddr, amt)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return subTags.AppendTags(addTags), nil
+	_, err = addCoins(ctx, am, toAddr, amt)
+	return err
 }
 
 // InputOutputCoins handles a list of inputs and outputs
 // NOTE: Make sure to revert state changes from tx on error
-func inputOutputCoins(ctx sdk.Context, am auth.AccountKeeper, inputs []Input, outputs []Output) (sdk.Tags, sdk.Error) {
-	allTags := sdk.EmptyTags()
-
+func inputOutputCoins(ctx sdk.Context, am auth.AccountKeeper, inputs []Input, outputs []Output) sdk.Error {
 	for _, in := range inputs {
-		_, tags, err := subtractCoins(ctx, am, in.Address, in.Coins)
+		_, err := subtractCoins(ctx, am, in.Address, in.Coins)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		allTags = allTags.AppendTags(tags)
 	}
 
 	for _, out := range outputs {
-		_, tags, err := addCoins(ctx, am, out.Address, out.Coins)
+		_, err := addCoins(ctx, am, out.Address, out.Coins)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		allTags = allTags.AppendTags(tags)
 	}
 
-	return allTags, nil
+	return nil
 }
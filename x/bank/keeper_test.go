@@ -2,6 +2,7 @@ package bank
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,25 +16,32 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank/tags"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
-func setupMultiStore() (sdk.MultiStore, *sdk.KVStoreKey) {
+func setupMultiStore() (sdk.MultiStore, *sdk.KVStoreKey, *sdk.KVStoreKey, *sdk.TransientStoreKey) {
 	db := dbm.NewMemDB()
 	authKey := sdk.NewKVStoreKey("authkey")
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(authKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
 	ms.LoadLatestVersion()
-	return ms, authKey
+	return ms, authKey, keyParams, tkeyParams
 }
 
 func TestKeeper(t *testing.T) {
-	ms, authKey := setupMultiStore()
+	ms, authKey, keyParams, tkeyParams := setupMultiStore()
 
 	cdc := codec.New()
 	auth.RegisterBaseAccount(cdc)
 
 	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
-	accountKeeper := auth.NewAccountKeeper(cdc, authKey, auth.ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	accountKeeper := auth.NewAccountKeeper(cdc, authKey, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
 	bankKeeper := NewBaseKeeper(accountKeeper)
 
 	addr := sdk.AccAddress([]byte("addr1"))
@@ -74,11 +82,16 @@ func TestKeeper(t *testing.T) {
 	require.False(t, bankKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("barcoin", 1)}))
 
 	// Test SendCoins
-	bankKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 5)})
+	em := sdk.NewEventManager()
+	bankKeeper.SendCoins(ctx.WithEventManager(em), addr, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 5)})
 	require.True(t, bankKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}))
 	require.True(t, bankKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewInt64Coin("foocoin", 5)}))
+	require.Equal(t, sdk.Events{
+		sdk.NewEvent(tags.EventTypeTransfer, sdk.NewAttribute(tags.Sender, addr.String()), sdk.NewAttribute(tags.Amount, "5foocoin")),
+		sdk.NewEvent(tags.EventTypeTransfer, sdk.NewAttribute(tags.Recipient, addr2.String()), sdk.NewAttribute(tags.Amount, "5foocoin")),
+	}, em.Events())
 
-	_, err2 := bankKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 50)})
+	err2 := bankKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 50)})
 	assert.Implements(t, (*sdk.Error)(nil), err2)
 	require.True(t, bankKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}))
 	require.True(t, bankKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewInt64Coin("foocoin", 5)}))
@@ -112,13 +125,14 @@ func TestKeeper(t *testing.T) {
 }
 
 func TestSendKeeper(t *testing.T) {
-	ms, authKey := setupMultiStore()
+	ms, authKey, keyParams, tkeyParams := setupMultiStore()
 
 	cdc := codec.New()
 	auth.RegisterBaseAccount(cdc)
 
 	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
-	accountKeeper := auth.NewAccountKeeper(cdc, authKey, auth.ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	accountKeeper := auth.NewAccountKeeper(cdc, authKey, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
 	bankKeeper := NewBaseKeeper(accountKeeper)
 	sendKeeper := NewBaseSendKeeper(accountKeeper)
 
@@ -147,7 +161,7 @@ func TestSendKeeper(t *testing.T) {
 	require.True(t, sendKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}))
 	require.True(t, sendKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewInt64Coin("foocoin", 5)}))
 
-	_, err2 := sendKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 50)})
+	err2 := sendKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 50)})
 	assert.Implements(t, (*sdk.Error)(nil), err2)
 	require.True(t, sendKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}))
 	require.True(t, sendKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewInt64Coin("foocoin", 5)}))
@@ -181,13 +195,14 @@ func TestSendKeeper(t *testing.T) {
 }
 
 func TestViewKeeper(t *testing.T) {
-	ms, authKey := setupMultiStore()
+	ms, authKey, keyParams, tkeyParams := setupMultiStore()
 
 	cdc := codec.New()
 	auth.RegisterBaseAccount(cdc)
 
 	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
-	accountKeeper := auth.NewAccountKeeper(cdc, authKey, auth.ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	accountKeeper := auth.NewAccountKeeper(cdc, authKey, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
 	bankKeeper := NewBaseKeeper(accountKeeper)
 	viewKeeper := NewBaseViewKeeper(accountKeeper)
 
@@ -207,3 +222,63 @@ func TestViewKeeper(t *testing.T) {
 	require.False(t, viewKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("foocoin", 15)}))
 	require.False(t, viewKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("barcoin", 5)}))
 }
+
+func TestVestingAccountSendAndDelegate(t *testing.T) {
+	ms, authKey, keyParams, tkeyParams := setupMultiStore()
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+
+	now := time.Now()
+	endTime := now.Add(24 * time.Hour)
+	ctx := sdk.NewContext(ms, abci.Header{Time: now}, false, log.NewNopLogger())
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	accountKeeper := auth.NewAccountKeeper(cdc, authKey, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bankKeeper := NewBaseKeeper(accountKeeper)
+
+	origCoins := sdk.Coins{sdk.NewInt64Coin("steak", 100)}
+	sendCoins := sdk.Coins{sdk.NewInt64Coin("steak", 50)}
+	addr1 := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+
+	bacc := auth.NewBaseAccountWithAddress(addr1)
+	bacc.SetCoins(origCoins)
+	vacc := auth.NewContinuousVestingAccount(&bacc, now.Unix(), endTime.Unix())
+	accountKeeper.SetAccount(ctx, vacc)
+
+	// require that no coins be sendable at the beginning of the vesting schedule
+	err := bankKeeper.SendCoins(ctx, addr1, addr2, sendCoins)
+	require.Error(t, err)
+	_, err = bankKeeper.SubtractCoins(ctx, addr1, sendCoins)
+	require.Error(t, err)
+
+	// negative amounts can be neither delegated nor undelegated
+	negCoins := sdk.Coins{sdk.NewInt64Coin("steak", -10)}
+	require.Error(t, bankKeeper.DelegateCoins(ctx, addr1, negCoins))
+	require.Error(t, bankKeeper.UndelegateCoins(ctx, addr1, negCoins))
+
+	// locked coins can still be delegated and undelegated
+	err = bankKeeper.DelegateCoins(ctx, addr1, sendCoins)
+	require.NoError(t, err)
+	vacc = accountKeeper.GetAccount(ctx, addr1).(*auth.ContinuousVestingAccount)
+	require.True(t, vacc.GetDelegatedVesting().IsEqual(sendCoins))
+	require.True(t, vacc.GetCoins().IsEqual(sendCoins))
+
+	err = bankKeeper.UndelegateCoins(ctx, addr1, sendCoins)
+	require.NoError(t, err)
+	vacc = accountKeeper.GetAccount(ctx, addr1).(*auth.ContinuousVestingAccount)
+	require.True(t, vacc.GetDelegatedVesting().IsZero())
+	require.True(t, vacc.GetCoins().IsEqual(origCoins))
+
+	// require that all vested coins are spendable plus any received
+	ctx = ctx.WithBlockTime(now.Add(12 * time.Hour))
+	err = bankKeeper.SendCoins(ctx, addr1, addr2, sendCoins)
+	require.NoError(t, err)
+	require.True(t, bankKeeper.GetCoins(ctx, addr1).IsEqual(sendCoins))
+	require.True(t, bankKeeper.GetCoins(ctx, addr2).IsEqual(sendCoins))
+
+	err = bankKeeper.SendCoins(ctx, addr2, addr1, sendCoins)
+	require.NoError(t, err)
+	err = bankKeeper.SendCoins(ctx, addr1, addr2, sendCoins)
+	require.NoError(t, err)
+}
@@ -0,0 +1,12 @@
+// nolint
+package tags
+
+// Types and attribute keys of the events emitted by the bank module. They
+// are indexed as "<type>.<key>" tags, e.g. "transfer.recipient".
+var (
+	EventTypeTransfer = "transfer"
+
+	Sender    = "sender"
+	Recipient = "recipient"
+	Amount    = "amount"
+)
@@ -23,10 +23,13 @@ type (
 	MsgWithdrawValidatorRewardsAll = types.MsgWithdrawValidatorRewardsAll
 
 	GenesisState = types.GenesisState
+
+	QueryDistInfosParams = keeper.QueryDistInfosParams
 )
 
 var (
-	NewKeeper = keeper.NewKeeper
+	NewKeeper  = keeper.NewKeeper
+	NewQuerier = keeper.NewQuerier
 
 	GetValidatorDistInfoKey     = keeper.GetValidatorDistInfoKey
 	GetDelegationDistInfoKey    = keeper.GetDelegationDistInfoKey
@@ -54,6 +57,9 @@ var (
 )
 
 const (
+	QueryValidatorDistInfos  = keeper.QueryValidatorDistInfos
+	QueryDelegationDistInfos = keeper.QueryDelegationDistInfos
+
 	DefaultCodespace = types.DefaultCodespace
 	CodeInvalidInput = types.CodeInvalidInput
 )
@@ -65,12 +71,11 @@ var (
 )
 
 var (
-	ActionModifyWithdrawAddress       = tags.ActionModifyWithdrawAddress
-	ActionWithdrawDelegatorRewardsAll = tags.ActionWithdrawDelegatorRewardsAll
-	ActionWithdrawDelegatorReward     = tags.ActionWithdrawDelegatorReward
-	ActionWithdrawValidatorRewardsAll = tags.ActionWithdrawValidatorRewardsAll
+	EventTypeModifyWithdrawAddress       = tags.EventTypeModifyWithdrawAddress
+	EventTypeWithdrawDelegatorRewardsAll = tags.EventTypeWithdrawDelegatorRewardsAll
+	EventTypeWithdrawDelegatorReward     = tags.EventTypeWithdrawDelegatorReward
+	EventTypeWithdrawValidatorRewardsAll = tags.EventTypeWithdrawValidatorRewardsAll
 
-	TagAction    = tags.Action
 	TagValidator = tags.Validator
 	TagDelegator = tags.Delegator
 )
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+)
+
+// GetCmdQueryValidatorDistInfos implements the command to query the
+// distribution info of all validators.
+func GetCmdQueryValidatorDistInfos(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator-dist-infos",
+		Short: "Query the distribution information of all validators",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryDistInfos(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, distr.QueryValidatorDistInfos))
+		},
+	}
+
+	client.PageCommands(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryDelegationDistInfos implements the command to query the
+// distribution info of all delegations.
+func GetCmdQueryDelegationDistInfos(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegation-dist-infos",
+		Short: "Query the distribution information of all delegations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryDistInfos(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, distr.QueryDelegationDistInfos))
+		},
+	}
+
+	client.PageCommands(cmd)
+
+	return cmd
+}
+
+// query the page of distribution infos requested by the pagination flags
+func queryDistInfos(cdc *codec.Codec, path string) error {
+	cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+	params := distr.QueryDistInfosParams{
+		Page: client.ReadPageRequest(),
+	}
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return err
+	}
+
+	res, err := cliCtx.QueryWithData(path, bz)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(res))
+	return nil
+}
@@ -34,51 +34,39 @@ func handleMsgModifyWithdrawAddress(ctx sdk.Context, msg types.MsgSetWithdrawAdd
 
 	k.SetDelegatorWithdrawAddr(ctx, msg.DelegatorAddr, msg.WithdrawAddr)
 
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionModifyWithdrawAddress,
-		tags.Delegator, []byte(msg.DelegatorAddr.String()),
-	)
-	return sdk.Result{
-		Tags: tags,
-	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeModifyWithdrawAddress,
+		sdk.NewAttribute(tags.Delegator, msg.DelegatorAddr.String()),
+	))
+	return sdk.Result{}
 }
 
 func handleMsgWithdrawDelegatorRewardsAll(ctx sdk.Context, msg types.MsgWithdrawDelegatorRewardsAll, k keeper.Keeper) sdk.Result {
 
 	k.WithdrawDelegationRewardsAll(ctx, msg.DelegatorAddr)
 
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionWithdrawDelegatorRewardsAll,
-		tags.Delegator, []byte(msg.DelegatorAddr.String()),
-	)
-	return sdk.Result{
-		Tags: tags,
-	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeWithdrawDelegatorRewardsAll,
+		sdk.NewAttribute(tags.Delegator, msg.DelegatorAddr.String()),
+	))
+	return sdk.Result{}
 }
 
 func handleMsgWithdrawDelegatorReward(ctx sdk.Context, msg types.MsgWithdrawDelegatorReward, k keeper.Keeper) sdk.Result {
 
 	k.WithdrawDelegationReward(ctx, msg.DelegatorAddr, msg.ValidatorAddr)
 
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionWithdrawDelegatorReward,
-		tags.Delegator, []byte(msg.DelegatorAddr.String()),
-		tags.Validator, []byte(msg.ValidatorAddr.String()),
-	)
-	return sdk.Result{
-		Tags: tags,
-	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeWithdrawDelegatorReward,
+		sdk.NewAttribute(tags.Delegator, msg.DelegatorAddr.String()),
+		sdk.NewAttribute(tags.Validator, msg.ValidatorAddr.String()),
+	))
+	return sdk.Result{}
 }
 
 func handleMsgWithdrawValidatorRewardsAll(ctx sdk.Context, msg types.MsgWithdrawValidatorRewardsAll, k keeper.Keeper) sdk.Result {
 
 	k.WithdrawValidatorRewardsAll(ctx, msg.ValidatorAddr)
 
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionWithdrawValidatorRewardsAll,
-		tags.Validator, []byte(msg.ValidatorAddr.String()),
-	)
-	return sdk.Result{
-		Tags: tags,
-	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeWithdrawValidatorRewardsAll,
+		sdk.NewAttribute(tags.Validator, msg.ValidatorAddr.String()),
+	))
+	return sdk.Result{}
 }
@@ -18,7 +18,7 @@ func (k Keeper) AllocateTokens(ctx sdk.Context, percentVotes sdk.Dec, proposer s
 	// get the fees which have been getting collected through all the
 	// transactions in the block
 	feesCollected := k.feeCollectionKeeper.GetCollectedFees(ctx)
-	feesCollectedDec := types.NewDecCoins(feesCollected)
+	feesCollectedDec := sdk.NewDecCoins(feesCollected)
 
 	// allocated rewards to proposer
 	baseProposerReward := k.GetBaseProposerReward(ctx)
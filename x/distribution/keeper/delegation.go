@@ -81,7 +81,7 @@ func (k Keeper) WithdrawDelegationReward(ctx sdk.Context, delegatorAddr sdk.AccA
 	k.SetValidatorDistInfo(ctx, valInfo)
 	k.SetDelegationDistInfo(ctx, delInfo)
 	withdrawAddr := k.GetDelegatorWithdrawAddr(ctx, delegatorAddr)
-	_, _, err := k.bankKeeper.AddCoins(ctx, withdrawAddr, withdraw.TruncateDecimal())
+	_, err := k.bankKeeper.AddCoins(ctx, withdrawAddr, withdraw.TruncateDecimal())
 	if err != nil {
 		panic(err)
 	}
@@ -94,16 +94,16 @@ func (k Keeper) WithdrawDelegationRewardsAll(ctx sdk.Context, delegatorAddr sdk.
 	height := ctx.BlockHeight()
 	withdraw := k.getDelegatorRewardsAll(ctx, delegatorAddr, height)
 	withdrawAddr := k.GetDelegatorWithdrawAddr(ctx, delegatorAddr)
-	_, _, err := k.bankKeeper.AddCoins(ctx, withdrawAddr, withdraw.TruncateDecimal())
+	_, err := k.bankKeeper.AddCoins(ctx, withdrawAddr, withdraw.TruncateDecimal())
 	if err != nil {
 		panic(err)
 	}
 }
 
 // return all rewards for all delegations of a delegator
-func (k Keeper) getDelegatorRewardsAll(ctx sdk.Context, delAddr sdk.AccAddress, height int64) types.DecCoins {
+func (k Keeper) getDelegatorRewardsAll(ctx sdk.Context, delAddr sdk.AccAddress, height int64) sdk.DecCoins {
 
-	withdraw := types.DecCoins{}
+	withdraw := sdk.DecCoins{}
 	bondedTokens := k.stakeKeeper.TotalPower(ctx)
 	feePool := k.GetFeePool(ctx)
 
@@ -7,28 +7,46 @@ import (
 
 // Get the set of all validator-distribution-info's with no limits, used during genesis dump
 func (k Keeper) GetAllValidatorDistInfos(ctx sdk.Context) (vdis []types.ValidatorDistInfo) {
+	return k.GetValidatorDistInfosPage(ctx, sdk.PageRequest{})
+}
+
+// Get a page of the set of all validator-distribution-info's, the zero page
+// request has no limits
+func (k Keeper) GetValidatorDistInfosPage(ctx sdk.Context, page sdk.PageRequest) (vdis []types.ValidatorDistInfo) {
 	store := ctx.KVStore(k.storeKey)
 	iterator := sdk.KVStorePrefixIterator(store, ValidatorDistInfoKey)
 	defer iterator.Close()
 
-	for ; iterator.Valid(); iterator.Next() {
-		var vdi types.ValidatorDistInfo
-		k.cdc.MustUnmarshalBinary(iterator.Value(), &vdi)
-		vdis = append(vdis, vdi)
+	for i := 0; iterator.Valid() && !page.Done(i); iterator.Next() {
+		if page.Contains(i) {
+			var vdi types.ValidatorDistInfo
+			k.cdc.MustUnmarshalBinary(iterator.Value(), &vdi)
+			vdis = append(vdis, vdi)
+		}
+		i++
 	}
 	return vdis
 }
 
 // Get the set of all delegator-distribution-info's with no limits, used during genesis dump
 func (k Keeper) GetAllDelegationDistInfos(ctx sdk.Context) (ddis []types.DelegationDistInfo) {
+	return k.GetDelegationDistInfosPage(ctx, sdk.PageRequest{})
+}
+
+// Get a page of the set of all delegator-distribution-info's, the zero page
+// request has no limits
+func (k Keeper) GetDelegationDistInfosPage(ctx sdk.Context, page sdk.PageRequest) (ddis []types.DelegationDistInfo) {
 	store := ctx.KVStore(k.storeKey)
 	iterator := sdk.KVStorePrefixIterator(store, DelegationDistInfoKey)
 	defer iterator.Close()
 
-	for ; iterator.Valid(); iterator.Next() {
-		var ddi types.DelegationDistInfo
-		k.cdc.MustUnmarshalBinary(iterator.Value(), &ddi)
-		ddis = append(ddis, ddi)
+	for i := 0; iterator.Valid() && !page.Done(i); iterator.Next() {
+		if page.Contains(i) {
+			var ddi types.DelegationDistInfo
+			k.cdc.MustUnmarshalBinary(iterator.Value(), &ddi)
+			ddis = append(ddis, ddi)
+		}
+		i++
 	}
 	return ddis
 }
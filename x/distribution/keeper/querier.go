@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// query endpoints supported by the distribution Querier
+const (
+	QueryValidatorDistInfos  = "validatorDistInfos"
+	QueryDelegationDistInfos = "delegationDistInfos"
+)
+
+// creates a querier for distribution REST endpoints
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryValidatorDistInfos:
+			return queryValidatorDistInfos(ctx, req, k)
+		case QueryDelegationDistInfos:
+			return queryDelegationDistInfos(ctx, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown distr query endpoint")
+		}
+	}
+}
+
+// defines the params for the following queries:
+// - 'custom/distr/validatorDistInfos'
+// - 'custom/distr/delegationDistInfos'
+type QueryDistInfosParams struct {
+	Page sdk.PageRequest
+}
+
+func queryValidatorDistInfos(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryDistInfosParams
+	errRes := k.cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", errRes.Error()))
+	}
+	params.Page, err = params.Page.QuerierPage()
+	if err != nil {
+		return nil, err
+	}
+
+	vdis := k.GetValidatorDistInfosPage(ctx, params.Page)
+
+	res, errRes = codec.MarshalJSONIndent(k.cdc, vdis)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryDelegationDistInfos(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryDistInfosParams
+	errRes := k.cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", errRes.Error()))
+	}
+	params.Page, err = params.Page.QuerierPage()
+	if err != nil {
+		return nil, err
+	}
+
+	ddis := k.GetDelegationDistInfosPage(ctx, params.Page)
+
+	res, errRes = codec.MarshalJSONIndent(k.cdc, ddis)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
@@ -0,0 +1,47 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+func TestQueryValidatorDistInfos(t *testing.T) {
+	ctx, _, keeper, _, _ := CreateTestInputDefault(t, false, 0)
+	querier := NewQuerier(keeper)
+
+	for _, valOpAddr := range []sdk.ValAddress{valOpAddr1, valOpAddr2, valOpAddr3} {
+		keeper.SetValidatorDistInfo(ctx, types.NewValidatorDistInfo(valOpAddr, 0))
+	}
+
+	_, err := querier(ctx, []string{"other"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	// returns the operator addresses of the queried page of infos
+	queryValidatorDistInfos := func(page sdk.PageRequest) (valOpAddrs []sdk.ValAddress) {
+		bz, err := keeper.cdc.MarshalJSON(QueryDistInfosParams{Page: page})
+		require.Nil(t, err)
+		res, sdkErr := querier(ctx, []string{QueryValidatorDistInfos}, abci.RequestQuery{Data: bz})
+		require.Nil(t, sdkErr)
+		var vdis []types.ValidatorDistInfo
+		require.Nil(t, keeper.cdc.UnmarshalJSON(res, &vdis))
+		for _, vdi := range vdis {
+			valOpAddrs = append(valOpAddrs, vdi.OperatorAddr)
+		}
+		return valOpAddrs
+	}
+
+	var allValOpAddrs []sdk.ValAddress
+	for _, vdi := range keeper.GetAllValidatorDistInfos(ctx) {
+		allValOpAddrs = append(allValOpAddrs, vdi.OperatorAddr)
+	}
+	require.Equal(t, 3, len(allValOpAddrs))
+	require.Equal(t, allValOpAddrs, queryValidatorDistInfos(sdk.PageRequest{}))
+	require.Equal(t, allValOpAddrs[:2], queryValidatorDistInfos(sdk.NewPageRequest(1, 2)))
+	require.Equal(t, allValOpAddrs[2:], queryValidatorDistInfos(sdk.NewPageRequest(2, 2)))
+	require.Equal(t, 0, len(queryValidatorDistInfos(sdk.NewPageRequest(3, 2))))
+}
@@ -53,7 +53,7 @@ func (k Keeper) WithdrawValidatorRewardsAll(ctx sdk.Context, operatorAddr sdk.Va
 	k.SetFeePool(ctx, feePool)
 
 	withdrawAddr := k.GetDelegatorWithdrawAddr(ctx, accAddr)
-	_, _, err := k.bankKeeper.AddCoins(ctx, withdrawAddr, withdraw.TruncateDecimal())
+	_, err := k.bankKeeper.AddCoins(ctx, withdrawAddr, withdraw.TruncateDecimal())
 	if err != nil {
 		panic(err)
 	}
@@ -1,17 +1,15 @@
 // nolint
 package tags
 
-import (
-	sdk "github.com/cosmos/cosmos-sdk/types"
-)
-
+// Types and attribute keys of the events emitted by the distribution module.
+// They are indexed as "<type>.<key>" tags, e.g.
+// "withdraw-delegator-reward.delegator".
 var (
-	ActionModifyWithdrawAddress       = []byte("modify-withdraw-address")
-	ActionWithdrawDelegatorRewardsAll = []byte("withdraw-delegator-rewards-all")
-	ActionWithdrawDelegatorReward     = []byte("withdraw-delegator-reward")
-	ActionWithdrawValidatorRewardsAll = []byte("withdraw-validator-rewards-all")
+	EventTypeModifyWithdrawAddress       = "modify-withdraw-address"
+	EventTypeWithdrawDelegatorRewardsAll = "withdraw-delegator-rewards-all"
+	EventTypeWithdrawDelegatorReward     = "withdraw-delegator-reward"
+	EventTypeWithdrawValidatorRewardsAll = "withdraw-validator-rewards-all"
 
-	Action    = sdk.TagAction
-	Validator = sdk.TagSrcValidator
-	Delegator = sdk.TagDelegator
+	Validator = "validator"
+	Delegator = "delegator"
 )
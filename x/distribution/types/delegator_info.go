@@ -24,12 +24,12 @@ func NewDelegationDistInfo(delegatorAddr sdk.AccAddress, valOperatorAddr sdk.Val
 // withdraw rewards from delegator
 func (di DelegationDistInfo) WithdrawRewards(fp FeePool, vi ValidatorDistInfo,
 	height int64, totalBonded, vdTokens, totalDelShares, delegatorShares,
-	commissionRate sdk.Dec) (DelegationDistInfo, ValidatorDistInfo, FeePool, DecCoins) {
+	commissionRate sdk.Dec) (DelegationDistInfo, ValidatorDistInfo, FeePool, sdk.DecCoins) {
 
 	vi = vi.UpdateTotalDelAccum(height, totalDelShares)
 
 	if vi.DelAccum.Accum.IsZero() {
-		return di, vi, fp, DecCoins{}
+		return di, vi, fp, sdk.DecCoins{}
 	}
 
 	vi, fp = vi.TakeFeePoolRewards(fp, height, totalBonded, vdTokens, commissionRate)
@@ -33,9 +33,9 @@ func (ta TotalAccum) UpdateForNewHeight(height int64, accumCreatedPerBlock sdk.D
 
 // global fee pool for distribution
 type FeePool struct {
-	ValAccum      TotalAccum `json:"val_accum"`      // total valdator accum held by validators
-	Pool          DecCoins   `json:"pool"`           // funds for all validators which have yet to be withdrawn
-	CommunityPool DecCoins   `json:"community_pool"` // pool for community funds yet to be spent
+	ValAccum      TotalAccum   `json:"val_accum"`      // total valdator accum held by validators
+	Pool          sdk.DecCoins `json:"pool"`           // funds for all validators which have yet to be withdrawn
+	CommunityPool sdk.DecCoins `json:"community_pool"` // pool for community funds yet to be spent
 }
 
 // update total validator accumulation factor
@@ -48,7 +48,7 @@ func (f FeePool) UpdateTotalValAccum(height int64, totalBondedTokens sdk.Dec) Fe
 func InitialFeePool() FeePool {
 	return FeePool{
 		ValAccum:      NewTotalAccum(0),
-		Pool:          DecCoins{},
-		CommunityPool: DecCoins{},
+		Pool:          sdk.DecCoins{},
+		CommunityPool: sdk.DecCoins{},
 	}
 }
@@ -8,9 +8,9 @@ import (
 type ValidatorDistInfo struct {
 	OperatorAddr sdk.ValAddress `json:"operator_addr"`
 
-	FeePoolWithdrawalHeight int64    `json:"global_withdrawal_height"` // last height this validator withdrew from the global pool
-	Pool                    DecCoins `json:"pool"`                     // rewards owed to delegators, commission has already been charged (includes proposer reward)
-	PoolCommission          DecCoins `json:"pool_commission"`          // commission collected by this validator (pending withdrawal)
+	FeePoolWithdrawalHeight int64        `json:"global_withdrawal_height"` // last height this validator withdrew from the global pool
+	Pool                    sdk.DecCoins `json:"pool"`                     // rewards owed to delegators, commission has already been charged (includes proposer reward)
+	PoolCommission          sdk.DecCoins `json:"pool_commission"`          // commission collected by this validator (pending withdrawal)
 
 	DelAccum TotalAccum `json:"del_accum"` // total proposer pool accumulation factor held by delegators
 }
@@ -19,8 +19,8 @@ func NewValidatorDistInfo(operatorAddr sdk.ValAddress, currentHeight int64) Vali
 	return ValidatorDistInfo{
 		OperatorAddr:            operatorAddr,
 		FeePoolWithdrawalHeight: currentHeight,
-		Pool:                    DecCoins{},
-		PoolCommission:          DecCoins{},
+		Pool:                    sdk.DecCoins{},
+		PoolCommission:          sdk.DecCoins{},
 		DelAccum:                NewTotalAccum(currentHeight),
 	}
 }
@@ -61,12 +61,12 @@ func (vi ValidatorDistInfo) TakeFeePoolRewards(fp FeePool, height int64, totalBo
 
 // withdraw commission rewards
 func (vi ValidatorDistInfo) WithdrawCommission(fp FeePool, height int64,
-	totalBonded, vdTokens, commissionRate sdk.Dec) (vio ValidatorDistInfo, fpo FeePool, withdrawn DecCoins) {
+	totalBonded, vdTokens, commissionRate sdk.Dec) (vio ValidatorDistInfo, fpo FeePool, withdrawn sdk.DecCoins) {
 
 	vi, fp = vi.TakeFeePoolRewards(fp, height, totalBonded, vdTokens, commissionRate)
 
 	withdrawalTokens := vi.PoolCommission
-	vi.PoolCommission = DecCoins{} // zero
+	vi.PoolCommission = sdk.DecCoins{} // zero
 
 	return vi, fp, withdrawalTokens
 }
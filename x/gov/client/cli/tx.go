@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 
+	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/utils"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -10,6 +11,7 @@ import (
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 
 	"encoding/json"
 	"io/ioutil"
@@ -38,6 +40,8 @@ type proposal struct {
 	Description string
 	Type        string
 	Deposit     string
+	Changes     []gov.ParamChange
+	Plan        upgrade.Plan
 }
 
 var proposalFlags = []string{
@@ -69,6 +73,30 @@ where proposal.json contains:
 is equivalent to
 
 $ gaiacli gov submit-proposal --title="Test Proposal" --description="My awesome proposal" --type="Text" --deposit="1000test"
+
+ParameterChange proposals can only be submitted through a proposal JSON file, listing the
+changes to apply once the proposal passes. Values are given in their JSON encoding:
+
+{
+  "title": "Lower Max Validators",
+  "description": "Reduce the size of the validator set",
+  "type": "ParameterChange",
+  "deposit": "1000test",
+  "changes": [
+    {"subspace": "stake", "key": "MaxValidators", "value": "80"}
+  ]
+}
+
+SoftwareUpgrade proposals are also submitted through a proposal JSON file, with the plan
+scheduled once the proposal passes. Exactly one of height and time must be set:
+
+{
+  "title": "Gaia v2",
+  "description": "Upgrade to gaia v2",
+  "type": "SoftwareUpgrade",
+  "deposit": "1000test",
+  "plan": {"name": "v2", "height": 1000000, "info": "https://example.com/gaia-v2"}
+}
 `),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			proposal, err := parseSubmitProposalFlags()
@@ -97,6 +125,12 @@ $ gaiacli gov submit-proposal --title="Test Proposal" --description="My awesome
 			}
 
 			msg := gov.NewMsgSubmitProposal(proposal.Title, proposal.Description, proposalType, fromAddr, amount)
+			switch proposalType {
+			case gov.ProposalTypeParameterChange:
+				msg = gov.NewMsgSubmitParameterChangeProposal(proposal.Title, proposal.Description, proposal.Changes, fromAddr, amount)
+			case gov.ProposalTypeSoftwareUpgrade:
+				msg = gov.NewMsgSubmitSoftwareUpgradeProposal(proposal.Title, proposal.Description, proposal.Plan, fromAddr, amount)
+			}
 			err = msg.ValidateBasic()
 			if err != nil {
 				return err
@@ -294,6 +328,7 @@ func GetCmdQueryProposals(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 			params := gov.QueryProposalsParams{
 				NumLatestProposals: latestProposalsIDs,
+				Page:               client.ReadPageRequest(),
 			}
 
 			if len(bechDepositerAddr) != 0 {
@@ -355,6 +390,7 @@ func GetCmdQueryProposals(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(flagDepositer, "", "(optional) filter by proposals deposited on by depositer")
 	cmd.Flags().String(flagVoter, "", "(optional) filter by proposals voted on by voted")
 	cmd.Flags().String(flagStatus, "", "(optional) filter proposals by proposal status")
+	client.PageCommands(cmd)
 
 	return cmd
 }
@@ -410,6 +446,7 @@ func GetCmdQueryVotes(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 			params := gov.QueryVotesParams{
 				ProposalID: proposalID,
+				Page:       client.ReadPageRequest(),
 			}
 			bz, err := cdc.MarshalJSON(params)
 			if err != nil {
@@ -427,6 +464,7 @@ func GetCmdQueryVotes(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 
 	cmd.Flags().String(flagProposalID, "", "proposalID of which proposal's votes are being queried")
+	client.PageCommands(cmd)
 
 	return cmd
 }
@@ -482,6 +520,7 @@ func GetCmdQueryDeposits(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 			params := gov.QueryDepositsParams{
 				ProposalID: proposalID,
+				Page:       client.ReadPageRequest(),
 			}
 			bz, err := cdc.MarshalJSON(params)
 			if err != nil {
@@ -499,6 +538,7 @@ func GetCmdQueryDeposits(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 
 	cmd.Flags().String(flagProposalID, "", "proposalID of which proposal's deposits are being queried")
+	client.PageCommands(cmd)
 
 	return cmd
 }
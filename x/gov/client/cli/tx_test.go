@@ -5,6 +5,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"io/ioutil"
 	"testing"
+
+	"github.com/cosmos/cosmos-sdk/x/gov"
 )
 
 func TestParseSubmitProposalFlags(t *testing.T) {
@@ -42,6 +44,27 @@ func TestParseSubmitProposalFlags(t *testing.T) {
 	require.Equal(t, "Text", proposal1.Type)
 	require.Equal(t, "1000test", proposal1.Deposit)
 
+	// parameter change json
+	changesJSON, err := ioutil.TempFile("", "proposal")
+	require.Nil(t, err, "unexpected error")
+	changesJSON.WriteString(`
+{
+  "title": "Test Proposal",
+  "description": "My awesome proposal",
+  "type": "ParameterChange",
+  "deposit": "1000test",
+  "changes": [
+    {"subspace": "stake", "key": "MaxValidators", "value": "80"}
+  ]
+}
+`)
+	viper.Set(flagProposal, changesJSON.Name())
+	proposal3, err := parseSubmitProposalFlags()
+	require.Nil(t, err, "unexpected error")
+	require.Equal(t, "ParameterChange", proposal3.Type)
+	require.Equal(t, []gov.ParamChange{gov.NewParamChange("stake", "MaxValidators", "80")}, proposal3.Changes)
+	viper.Set(flagProposal, okJSON.Name())
+
 	// flags that can't be used with --proposal
 	for _, incompatibleFlag := range proposalFlags {
 		viper.Set(incompatibleFlag, "some value")
@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
@@ -40,12 +41,14 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec)
 }
 
 type postProposalReq struct {
-	BaseReq        utils.BaseReq    `json:"base_req"`
-	Title          string           `json:"title"`           //  Title of the proposal
-	Description    string           `json:"description"`     //  Description of the proposal
-	ProposalType   gov.ProposalKind `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Proposer       sdk.AccAddress   `json:"proposer"`        //  Address of the proposer
-	InitialDeposit sdk.Coins        `json:"initial_deposit"` // Coins to add to the proposal's deposit
+	BaseReq        utils.BaseReq     `json:"base_req"`
+	Title          string            `json:"title"`           //  Title of the proposal
+	Description    string            `json:"description"`     //  Description of the proposal
+	ProposalType   gov.ProposalKind  `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Proposer       sdk.AccAddress    `json:"proposer"`        //  Address of the proposer
+	InitialDeposit sdk.Coins         `json:"initial_deposit"` // Coins to add to the proposal's deposit
+	Changes        []gov.ParamChange `json:"changes"`         // Parameter changes of a ParameterChange proposal
+	Plan           upgrade.Plan      `json:"plan"`            // Upgrade plan of a SoftwareUpgrade proposal
 }
 
 type depositReq struct {
@@ -75,6 +78,12 @@ func postProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Han
 
 		// create the message
 		msg := gov.NewMsgSubmitProposal(req.Title, req.Description, req.ProposalType, req.Proposer, req.InitialDeposit)
+		switch req.ProposalType {
+		case gov.ProposalTypeParameterChange:
+			msg = gov.NewMsgSubmitParameterChangeProposal(req.Title, req.Description, req.Changes, req.Proposer, req.InitialDeposit)
+		case gov.ProposalTypeSoftwareUpgrade:
+			msg = gov.NewMsgSubmitSoftwareUpgradeProposal(req.Title, req.Description, req.Plan, req.Proposer, req.InitialDeposit)
+		}
 		err = msg.ValidateBasic()
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -209,8 +218,14 @@ func queryDepositsHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Ha
 			return
 		}
 
+		page, ok := utils.ParsePageRequestOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+
 		params := gov.QueryDepositsParams{
 			ProposalID: proposalID,
+			Page:       page,
 		}
 
 		bz, err := cdc.MarshalJSON(params)
@@ -377,8 +392,14 @@ func queryVotesOnProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext)
 			return
 		}
 
+		page, ok := utils.ParsePageRequestOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+
 		params := gov.QueryVotesParams{
 			ProposalID: proposalID,
+			Page:       page,
 		}
 		bz, err := cdc.MarshalJSON(params)
 		if err != nil {
@@ -403,7 +424,12 @@ func queryProposalsWithParameterFn(cdc *codec.Codec, cliCtx context.CLIContext)
 		strProposalStatus := r.URL.Query().Get(RestProposalStatus)
 		strNumLatest := r.URL.Query().Get(RestNumLatest)
 
-		params := gov.QueryProposalsParams{}
+		page, ok := utils.ParsePageRequestOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+
+		params := gov.QueryProposalsParams{Page: page}
 
 		if len(bechVoterAddr) != 0 {
 			voterAddr, err := sdk.AccAddressFromBech32(bechVoterAddr)
@@ -1,12 +1,16 @@
 package gov
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov/tags"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
@@ -16,8 +20,9 @@ func TestTickExpiredDepositPeriod(t *testing.T) {
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
 	govHandler := NewHandler(keeper)
 
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue := keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
 	newProposalMsg := NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewInt64Coin("steak", 5)})
 
@@ -25,26 +30,30 @@ func TestTickExpiredDepositPeriod(t *testing.T) {
 	require.True(t, res.IsOK())
 
 	EndBlocker(ctx, keeper)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
 	newHeader := ctx.BlockHeader()
 	newHeader.Time = ctx.BlockHeader().Time.Add(time.Duration(1) * time.Second)
 	ctx = ctx.WithBlockHeader(newHeader)
 
 	EndBlocker(ctx, keeper)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
 	newHeader = ctx.BlockHeader()
 	newHeader.Time = ctx.BlockHeader().Time.Add(keeper.GetDepositProcedure(ctx).MaxDepositPeriod)
 	ctx = ctx.WithBlockHeader(newHeader)
 
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.True(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.True(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 	EndBlocker(ctx, keeper)
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 }
 
 func TestTickMultipleExpiredDepositPeriod(t *testing.T) {
@@ -53,8 +62,9 @@ func TestTickMultipleExpiredDepositPeriod(t *testing.T) {
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
 	govHandler := NewHandler(keeper)
 
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue := keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
 	newProposalMsg := NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewInt64Coin("steak", 5)})
 
@@ -62,16 +72,18 @@ func TestTickMultipleExpiredDepositPeriod(t *testing.T) {
 	require.True(t, res.IsOK())
 
 	EndBlocker(ctx, keeper)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
 	newHeader := ctx.BlockHeader()
 	newHeader.Time = ctx.BlockHeader().Time.Add(time.Duration(2) * time.Second)
 	ctx = ctx.WithBlockHeader(newHeader)
 
 	EndBlocker(ctx, keeper)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
 	newProposalMsg2 := NewMsgSubmitProposal("Test2", "test2", ProposalTypeText, addrs[1], sdk.Coins{sdk.NewInt64Coin("steak", 5)})
 	res = govHandler(ctx, newProposalMsg2)
@@ -81,21 +93,25 @@ func TestTickMultipleExpiredDepositPeriod(t *testing.T) {
 	newHeader.Time = ctx.BlockHeader().Time.Add(keeper.GetDepositProcedure(ctx).MaxDepositPeriod).Add(time.Duration(-1) * time.Second)
 	ctx = ctx.WithBlockHeader(newHeader)
 
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.True(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.True(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 	EndBlocker(ctx, keeper)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
 	newHeader = ctx.BlockHeader()
 	newHeader.Time = ctx.BlockHeader().Time.Add(time.Duration(5) * time.Second)
 	ctx = ctx.WithBlockHeader(newHeader)
 
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.True(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.True(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 	EndBlocker(ctx, keeper)
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 }
 
 func TestTickPassedDepositPeriod(t *testing.T) {
@@ -104,10 +120,12 @@ func TestTickPassedDepositPeriod(t *testing.T) {
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
 	govHandler := NewHandler(keeper)
 
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
-	require.Nil(t, keeper.ActiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopActiveProposalQueue(ctx, keeper))
+	inactiveQueue := keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
+	activeQueue := keeper.ActiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, activeQueue.Valid())
+	activeQueue.Close()
 
 	newProposalMsg := NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewInt64Coin("steak", 5)})
 
@@ -117,31 +135,35 @@ func TestTickPassedDepositPeriod(t *testing.T) {
 	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
 
 	EndBlocker(ctx, keeper)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
 	newHeader := ctx.BlockHeader()
 	newHeader.Time = ctx.BlockHeader().Time.Add(time.Duration(1) * time.Second)
 	ctx = ctx.WithBlockHeader(newHeader)
 
 	EndBlocker(ctx, keeper)
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
 
 	newDepositMsg := NewMsgDeposit(addrs[1], proposalID, sdk.Coins{sdk.NewInt64Coin("steak", 5)})
 	res = govHandler(ctx, newDepositMsg)
 	require.True(t, res.IsOK())
 
-	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.True(t, shouldPopInactiveProposalQueue(ctx, keeper))
-	require.NotNil(t, keeper.ActiveProposalQueuePeek(ctx))
+	activeQueue = keeper.ActiveProposalQueueIterator(ctx, keeper.GetProposal(ctx, proposalID).GetVotingEndTime())
+	require.True(t, activeQueue.Valid())
+	activeQueue.Close()
 
 	EndBlocker(ctx, keeper)
 
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
-	require.NotNil(t, keeper.ActiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopActiveProposalQueue(ctx, keeper))
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
+	activeQueue = keeper.ActiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, activeQueue.Valid())
+	activeQueue.Close()
 
 }
 
@@ -152,10 +174,12 @@ func TestTickPassedVotingPeriod(t *testing.T) {
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
 	govHandler := NewHandler(keeper)
 
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
-	require.Nil(t, keeper.ActiveProposalQueuePeek(ctx))
-	require.False(t, shouldPopActiveProposalQueue(ctx, keeper))
+	inactiveQueue := keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
+	activeQueue := keeper.ActiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, activeQueue.Valid())
+	activeQueue.Close()
 
 	newProposalMsg := NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[0], sdk.Coins{sdk.NewInt64Coin("steak", 5)})
 
@@ -178,7 +202,9 @@ func TestTickPassedVotingPeriod(t *testing.T) {
 	newHeader.Time = ctx.BlockHeader().Time.Add(keeper.GetDepositProcedure(ctx).MaxDepositPeriod).Add(keeper.GetDepositProcedure(ctx).MaxDepositPeriod)
 	ctx = ctx.WithBlockHeader(newHeader)
 
-	require.True(t, shouldPopActiveProposalQueue(ctx, keeper))
+	activeQueue = keeper.ActiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.True(t, activeQueue.Valid())
+	activeQueue.Close()
 	depositsIterator := keeper.GetDeposits(ctx, proposalID)
 	require.True(t, depositsIterator.Valid())
 	depositsIterator.Close()
@@ -186,10 +212,174 @@ func TestTickPassedVotingPeriod(t *testing.T) {
 
 	EndBlocker(ctx, keeper)
 
-	require.Nil(t, keeper.ActiveProposalQueuePeek(ctx))
+	activeQueue = keeper.ActiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, activeQueue.Valid())
+	activeQueue.Close()
 	depositsIterator = keeper.GetDeposits(ctx, proposalID)
 	require.False(t, depositsIterator.Valid())
 	depositsIterator.Close()
 	require.Equal(t, StatusRejected, keeper.GetProposal(ctx, proposalID).GetStatus())
 	require.True(t, keeper.GetProposal(ctx, proposalID).GetTallyResult().Equals(EmptyTallyResult()))
 }
+
+func TestTickPassedParameterChangeProposal(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	SortAddresses(addrs)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs[:2]))
+	for i, addr := range addrs[:2] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5})
+	stake.EndBlocker(ctx, sk)
+
+	// changes are checked against the registered subspaces on submission
+	invalidChanges := [][]ParamChange{
+		{NewParamChange("unknown", "MaxValidators", `10`)},
+		{NewParamChange(stake.DefaultParamspace, "unknown", `10`)},
+		{NewParamChange(stake.DefaultParamspace, "MaxValidators", `"ten"`)},
+	}
+	for i, changes := range invalidChanges {
+		msg := NewMsgSubmitParameterChangeProposal("Test", "test", changes, addrs[2], sdk.Coins{sdk.NewInt64Coin("steak", 10)})
+		res := govHandler(ctx, msg)
+		require.False(t, res.IsOK(), "test: %v", i)
+		require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidParamChange), res.Code, "test: %v", i)
+	}
+
+	changes := []ParamChange{NewParamChange(stake.DefaultParamspace, "MaxValidators", `10`)}
+	newProposalMsg := NewMsgSubmitParameterChangeProposal("Test", "test", changes, addrs[2], sdk.Coins{sdk.NewInt64Coin("steak", 10)})
+	res := govHandler(ctx, newProposalMsg)
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+
+	proposal, ok := keeper.GetProposal(ctx, proposalID).(*ParameterChangeProposal)
+	require.True(t, ok)
+	require.Equal(t, changes, proposal.Changes)
+	require.Equal(t, StatusVotingPeriod, proposal.GetStatus())
+
+	for _, addr := range addrs[:2] {
+		res = govHandler(ctx, NewMsgVote(addr, proposalID, OptionYes))
+		require.True(t, res.IsOK())
+	}
+
+	EndBlocker(ctx, keeper)
+	require.Equal(t, uint16(100), sk.MaxValidators(ctx))
+
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(keeper.GetVotingProcedure(ctx).VotingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+
+	EndBlocker(ctx, keeper)
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.Equal(t, uint16(10), sk.MaxValidators(ctx))
+}
+
+func TestTickPassedSoftwareUpgradeProposal(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	SortAddresses(addrs)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Height: 1})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs[:2]))
+	for i, addr := range addrs[:2] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5})
+	stake.EndBlocker(ctx, sk)
+
+	// plans which are already due are refused
+	duePlan := upgrade.NewHeightPlan("v2", 1, "")
+	res := govHandler(ctx, NewMsgSubmitSoftwareUpgradeProposal("Test", "test", duePlan, addrs[2], sdk.Coins{sdk.NewInt64Coin("steak", 10)}))
+	require.False(t, res.IsOK())
+
+	plan := upgrade.NewHeightPlan("v2", 100, "info")
+	res = govHandler(ctx, NewMsgSubmitSoftwareUpgradeProposal("Test", "test", plan, addrs[2], sdk.Coins{sdk.NewInt64Coin("steak", 10)}))
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+
+	proposal, ok := keeper.GetProposal(ctx, proposalID).(*SoftwareUpgradeProposal)
+	require.True(t, ok)
+	require.Equal(t, plan, proposal.Plan)
+
+	for _, addr := range addrs[:2] {
+		res = govHandler(ctx, NewMsgVote(addr, proposalID, OptionYes))
+		require.True(t, res.IsOK())
+	}
+
+	EndBlocker(ctx, keeper)
+	_, found := keeper.uk.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(keeper.GetVotingProcedure(ctx).VotingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+
+	EndBlocker(ctx, keeper)
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+	scheduled, found := keeper.uk.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, plan, scheduled)
+}
+
+func TestTickPassedVotingPeriodPenalizesNonVoters(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs[:3]))
+	for i, addr := range addrs[:3] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{6, 6, 7})
+	stake.EndBlocker(ctx, sk)
+
+	res := govHandler(ctx, NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[3], sdk.Coins{sdk.NewInt64Coin("steak", 10)}))
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+
+	for _, addr := range []sdk.AccAddress{addrs[0], addrs[2]} {
+		res = govHandler(ctx, NewMsgVote(addr, proposalID, OptionYes))
+		require.True(t, res.IsOK())
+	}
+
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(keeper.GetVotingProcedure(ctx).VotingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	EndBlocker(ctx, keeper)
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+
+	penalty := keeper.GetTallyingProcedure(ctx).GovernancePenalty
+	expectedTokens := sdk.NewDec(6).Sub(sdk.NewDec(6).Mul(penalty))
+	validator, found := sk.GetValidator(ctx, valAddrs[1])
+	require.True(t, found)
+	require.True(t, expectedTokens.Equal(validator.Tokens), "got %v", validator.Tokens)
+
+	// validators which voted are left untouched
+	for i, valAddr := range []sdk.ValAddress{valAddrs[0], valAddrs[2]} {
+		validator, found := sk.GetValidator(ctx, valAddr)
+		require.True(t, found)
+		require.True(t, sdk.NewDec([]int64{6, 7}[i]).Equal(validator.Tokens))
+	}
+
+	var penalized []string
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == tags.EventTypePenalizeNonVoter {
+			require.Equal(t, sdk.NewAttribute(tags.ProposalID, fmt.Sprintf("%d", proposalID)), event.Attributes[0])
+			penalized = append(penalized, event.Attributes[1].Value)
+		}
+	}
+	require.Equal(t, []string{valAddrs[1].String()}, penalized)
+}
@@ -1,6 +1,7 @@
 package gov
 
 import (
+	"fmt"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -35,6 +36,7 @@ func DefaultGenesisState() GenesisState {
 			VotingPeriod: time.Duration(172800) * time.Second,
 		},
 		TallyingProcedure: TallyingProcedure{
+			Quorum:            sdk.NewDecWithPrec(334, 3),
 			Threshold:         sdk.NewDecWithPrec(5, 1),
 			Veto:              sdk.NewDecWithPrec(334, 3),
 			GovernancePenalty: sdk.NewDecWithPrec(1, 2),
@@ -52,6 +54,34 @@ func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
 	k.setDepositProcedure(ctx, data.DepositProcedure)
 	k.setVotingProcedure(ctx, data.VotingProcedure)
 	k.setTallyingProcedure(ctx, data.TallyingProcedure)
+
+	// move any proposals left in the legacy queue format into the time-keyed queues
+	k.MigrateProposalQueues(ctx)
+}
+
+// ValidateGenesis checks that the tallying procedure of the genesis state is
+// set and its ratios are within [0, 1], as a genesis written before the
+// quorum existed has none.
+func ValidateGenesis(data GenesisState) error {
+	tp := data.TallyingProcedure
+	ratios := []struct {
+		name  string
+		value sdk.Dec
+	}{
+		{"quorum", tp.Quorum},
+		{"threshold", tp.Threshold},
+		{"veto", tp.Veto},
+		{"governance_penalty", tp.GovernancePenalty},
+	}
+	for _, ratio := range ratios {
+		if ratio.value.IsNil() {
+			return fmt.Errorf("gov tallying procedure %s must be set", ratio.name)
+		}
+		if ratio.value.LT(sdk.ZeroDec()) || ratio.value.GT(sdk.OneDec()) {
+			return fmt.Errorf("gov tallying procedure %s must be within [0, 1], is %s", ratio.name, ratio.value)
+		}
+	}
+	return nil
 }
 
 // WriteGenesis - output genesis parameters
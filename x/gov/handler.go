@@ -26,28 +26,38 @@ func NewHandler(keeper Keeper) sdk.Handler {
 
 func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitProposal) sdk.Result {
 
-	proposal := keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType)
+	var proposal Proposal
+	switch msg.ProposalType {
+	case ProposalTypeParameterChange:
+		err := keeper.ValidateParamChanges(msg.Changes)
+		if err != nil {
+			return err.Result()
+		}
+		proposal = keeper.NewParameterChangeProposal(ctx, msg.Title, msg.Description, msg.Changes)
+	case ProposalTypeSoftwareUpgrade:
+		if msg.Plan.ShouldExecute(ctx) {
+			return ErrInvalidUpgradePlan(keeper.codespace, fmt.Sprintf("Upgrade plan is already due (%s)", msg.Plan.DueAt())).Result()
+		}
+		proposal = keeper.NewSoftwareUpgradeProposal(ctx, msg.Title, msg.Description, msg.Plan)
+	default:
+		proposal = keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType)
+	}
 
 	err, votingStarted := keeper.AddDeposit(ctx, proposal.GetProposalID(), msg.Proposer, msg.InitialDeposit)
 	if err != nil {
 		return err.Result()
 	}
 
-	proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(proposal.GetProposalID())
-
-	resTags := sdk.NewTags(
-		tags.Action, tags.ActionSubmitProposal,
-		tags.Proposer, []byte(msg.Proposer.String()),
-		tags.ProposalID, proposalIDBytes,
-	)
-
+	ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeSubmitProposal,
+		sdk.NewAttribute(tags.ProposalID, fmt.Sprintf("%d", proposal.GetProposalID())),
+		sdk.NewAttribute(tags.Proposer, msg.Proposer.String()),
+	))
 	if votingStarted {
-		resTags.AppendTag(tags.VotingPeriodStart, proposalIDBytes)
+		emitVotingPeriodStart(ctx, proposal.GetProposalID())
 	}
 
 	return sdk.Result{
-		Data: proposalIDBytes,
-		Tags: resTags,
+		Data: keeper.cdc.MustMarshalBinaryBare(proposal.GetProposalID()),
 	}
 }
 
@@ -58,22 +68,15 @@ func handleMsgDeposit(ctx sdk.Context, keeper Keeper, msg MsgDeposit) sdk.Result
 		return err.Result()
 	}
 
-	proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(msg.ProposalID)
-
-	// TODO: Add tag for if voting period started
-	resTags := sdk.NewTags(
-		tags.Action, tags.ActionDeposit,
-		tags.Depositer, []byte(msg.Depositer.String()),
-		tags.ProposalID, proposalIDBytes,
-	)
-
+	ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeDeposit,
+		sdk.NewAttribute(tags.ProposalID, fmt.Sprintf("%d", msg.ProposalID)),
+		sdk.NewAttribute(tags.Depositer, msg.Depositer.String()),
+	))
 	if votingStarted {
-		resTags.AppendTag(tags.VotingPeriodStart, proposalIDBytes)
+		emitVotingPeriodStart(ctx, msg.ProposalID)
 	}
 
-	return sdk.Result{
-		Tags: resTags,
-	}
+	return sdk.Result{}
 }
 
 func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
@@ -83,36 +86,42 @@ func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
 		return err.Result()
 	}
 
-	proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(msg.ProposalID)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeVote,
+		sdk.NewAttribute(tags.ProposalID, fmt.Sprintf("%d", msg.ProposalID)),
+		sdk.NewAttribute(tags.Voter, msg.Voter.String()),
+		sdk.NewAttribute(tags.Option, msg.Option.String()),
+	))
 
-	resTags := sdk.NewTags(
-		tags.Action, tags.ActionVote,
-		tags.Voter, []byte(msg.Voter.String()),
-		tags.ProposalID, proposalIDBytes,
-	)
-	return sdk.Result{
-		Tags: resTags,
-	}
+	return sdk.Result{}
 }
 
-// Called every block, process inflation, update validator set
-func EndBlocker(ctx sdk.Context, keeper Keeper) (resTags sdk.Tags) {
+// emits the event of a proposal entering its voting period
+func emitVotingPeriodStart(ctx sdk.Context, proposalID int64) {
+	ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeVotingPeriodStart,
+		sdk.NewAttribute(tags.ProposalID, fmt.Sprintf("%d", proposalID)),
+	))
+}
 
-	logger := ctx.Logger().With("module", "x/gov")
+// Called every block, drops the proposals which didn't meet the minimum
+// deposit and tallies the ones whose voting period ended, emitting an event
+// for each of them
+func EndBlocker(ctx sdk.Context, keeper Keeper) {
 
-	resTags = sdk.NewTags()
+	logger := ctx.Logger().With("module", "x/gov")
 
-	// Delete proposals that haven't met minDeposit
-	for shouldPopInactiveProposalQueue(ctx, keeper) {
-		inactiveProposal := keeper.InactiveProposalQueuePop(ctx)
-		if inactiveProposal.GetStatus() != StatusDepositPeriod {
-			continue
-		}
+	// Delete proposals that haven't met minDeposit by the end of their deposit period
+	inactiveIterator := keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	for ; inactiveIterator.Valid(); inactiveIterator.Next() {
+		var proposalID int64
+		keeper.cdc.MustUnmarshalBinary(inactiveIterator.Value(), &proposalID)
+		inactiveProposal := keeper.GetProposal(ctx, proposalID)
+		keeper.RemoveFromInactiveProposalQueue(ctx, proposalID, inactiveProposal.GetDepositEndTime())
 
-		proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(inactiveProposal.GetProposalID())
 		keeper.DeleteProposal(ctx, inactiveProposal)
-		resTags.AppendTag(tags.Action, tags.ActionProposalDropped)
-		resTags.AppendTag(tags.ProposalID, proposalIDBytes)
+		ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeInactiveProposal,
+			sdk.NewAttribute(tags.ProposalID, fmt.Sprintf("%d", proposalID)),
+			sdk.NewAttribute(tags.ProposalResult, tags.ProposalResultDropped),
+		))
 
 		logger.Info(
 			fmt.Sprintf("proposal %d (%s) didn't meet minimum deposit of %v steak (had only %v steak); deleted",
@@ -123,28 +132,40 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) (resTags sdk.Tags) {
 			),
 		)
 	}
-
-	// Check if earliest Active Proposal ended voting period yet
-	for shouldPopActiveProposalQueue(ctx, keeper) {
-		activeProposal := keeper.ActiveProposalQueuePop(ctx)
-
-		proposalStartTime := activeProposal.GetVotingStartTime()
-		votingPeriod := keeper.GetVotingProcedure(ctx).VotingPeriod
-		if ctx.BlockHeader().Time.Before(proposalStartTime.Add(votingPeriod)) {
-			continue
-		}
-
-		passes, tallyResults := tally(ctx, keeper, activeProposal)
-		proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(activeProposal.GetProposalID())
-		var action []byte
+	inactiveIterator.Close()
+
+	// Tally the proposals whose voting period has ended
+	activeIterator := keeper.ActiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	for ; activeIterator.Valid(); activeIterator.Next() {
+		var proposalID int64
+		keeper.cdc.MustUnmarshalBinary(activeIterator.Value(), &proposalID)
+		activeProposal := keeper.GetProposal(ctx, proposalID)
+		keeper.RemoveFromActiveProposalQueue(ctx, proposalID, activeProposal.GetVotingEndTime())
+
+		passes, tallyResults, nonVoters := tally(ctx, keeper, activeProposal)
+		var result string
 		if passes {
 			keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
 			activeProposal.SetStatus(StatusPassed)
-			action = tags.ActionProposalPassed
+			result = tags.ProposalResultPassed
+			switch proposal := activeProposal.(type) {
+			case *ParameterChangeProposal:
+				err := keeper.applyParamChanges(ctx, proposal.Changes)
+				if err != nil {
+					logger.Error(fmt.Sprintf("proposal %d (%s) passed but its parameter changes could not be applied: %s",
+						proposal.GetProposalID(), proposal.GetTitle(), err.Error()))
+				}
+			case *SoftwareUpgradeProposal:
+				err := keeper.uk.ScheduleUpgrade(ctx, proposal.Plan)
+				if err != nil {
+					logger.Error(fmt.Sprintf("proposal %d (%s) passed but its upgrade plan could not be scheduled: %s",
+						proposal.GetProposalID(), proposal.GetTitle(), err.Error()))
+				}
+			}
 		} else {
 			keeper.DeleteDeposits(ctx, activeProposal.GetProposalID())
 			activeProposal.SetStatus(StatusRejected)
-			action = tags.ActionProposalRejected
+			result = tags.ProposalResultRejected
 		}
 		activeProposal.SetTallyResult(tallyResults)
 		keeper.SetProposal(ctx, activeProposal)
@@ -152,34 +173,11 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) (resTags sdk.Tags) {
 		logger.Info(fmt.Sprintf("proposal %d (%s) tallied; passed: %v",
 			activeProposal.GetProposalID(), activeProposal.GetTitle(), passes))
 
-		resTags.AppendTag(tags.Action, action)
-		resTags.AppendTag(tags.ProposalID, proposalIDBytes)
-	}
-
-	return resTags
-}
-func shouldPopInactiveProposalQueue(ctx sdk.Context, keeper Keeper) bool {
-	depositProcedure := keeper.GetDepositProcedure(ctx)
-	peekProposal := keeper.InactiveProposalQueuePeek(ctx)
-
-	if peekProposal == nil {
-		return false
-	} else if peekProposal.GetStatus() != StatusDepositPeriod {
-		return true
-	} else if !ctx.BlockHeader().Time.Before(peekProposal.GetSubmitTime().Add(depositProcedure.MaxDepositPeriod)) {
-		return true
-	}
-	return false
-}
-
-func shouldPopActiveProposalQueue(ctx sdk.Context, keeper Keeper) bool {
-	votingProcedure := keeper.GetVotingProcedure(ctx)
-	peekProposal := keeper.ActiveProposalQueuePeek(ctx)
-
-	if peekProposal == nil {
-		return false
-	} else if !ctx.BlockHeader().Time.Before(peekProposal.GetVotingStartTime().Add(votingProcedure.VotingPeriod)) {
-		return true
+		ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeActiveProposal,
+			sdk.NewAttribute(tags.ProposalID, fmt.Sprintf("%d", proposalID)),
+			sdk.NewAttribute(tags.ProposalResult, result),
+		))
+		penalizeNonVoters(ctx, keeper, activeProposal, nonVoters)
 	}
-	return false
+	activeIterator.Close()
 }
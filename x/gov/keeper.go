@@ -1,10 +1,14 @@
 package gov
 
 import (
+	"fmt"
+	"time"
+
 	codec "github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 // Parameter store default namestore
@@ -45,6 +49,9 @@ type Keeper struct {
 	// The reference to the DelegationSet to get information about delegators
 	ds sdk.DelegationSet
 
+	// The reference to the UpgradeKeeper to schedule passed software upgrades
+	uk upgrade.Keeper
+
 	// The (unexposed) keys used to access the stores from the Context.
 	storeKey sdk.StoreKey
 
@@ -60,7 +67,7 @@ type Keeper struct {
 // - depositing funds into proposals, and activating upon sufficient funds being deposited
 // - users voting on proposals, with weight proportional to stake in the system
 // - and tallying the result of the vote.
-func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramsKeeper params.Keeper, paramSpace params.Subspace, ck bank.Keeper, ds sdk.DelegationSet, codespace sdk.CodespaceType) Keeper {
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramsKeeper params.Keeper, paramSpace params.Subspace, ck bank.Keeper, ds sdk.DelegationSet, uk upgrade.Keeper, codespace sdk.CodespaceType) Keeper {
 	return Keeper{
 		storeKey:     key,
 		paramsKeeper: paramsKeeper,
@@ -68,6 +75,7 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramsKeeper params.Keeper, p
 		ck:           ck,
 		ds:           ds,
 		vs:           ds.GetValidatorSet(),
+		uk:           uk,
 		cdc:          cdc,
 		codespace:    codespace,
 	}
@@ -82,21 +90,93 @@ func (keeper Keeper) NewTextProposal(ctx sdk.Context, title string, description
 	if err != nil {
 		return nil
 	}
-	var proposal Proposal = &TextProposal{
-		ProposalID:   proposalID,
-		Title:        title,
-		Description:  description,
-		ProposalType: proposalType,
-		Status:       StatusDepositPeriod,
-		TallyResult:  EmptyTallyResult(),
-		TotalDeposit: sdk.Coins{},
-		SubmitTime:   ctx.BlockHeader().Time,
+	textProposal := keeper.newTextProposal(ctx, proposalID, title, description, proposalType)
+	var proposal Proposal = &textProposal
+	keeper.SetProposal(ctx, proposal)
+	keeper.InsertInactiveProposalQueue(ctx, proposalID, proposal.GetDepositEndTime())
+	return proposal
+}
+
+// Creates a new ParameterChangeProposal, the changes are expected to have
+// been validated with ValidateParamChanges beforehand
+func (keeper Keeper) NewParameterChangeProposal(ctx sdk.Context, title string, description string, changes []ParamChange) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var proposal Proposal = &ParameterChangeProposal{
+		TextProposal: keeper.newTextProposal(ctx, proposalID, title, description, ProposalTypeParameterChange),
+		Changes:      changes,
+	}
+	keeper.SetProposal(ctx, proposal)
+	keeper.InsertInactiveProposalQueue(ctx, proposalID, proposal.GetDepositEndTime())
+	return proposal
+}
+
+// Creates a new SoftwareUpgradeProposal
+func (keeper Keeper) NewSoftwareUpgradeProposal(ctx sdk.Context, title string, description string, plan upgrade.Plan) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var proposal Proposal = &SoftwareUpgradeProposal{
+		TextProposal: keeper.newTextProposal(ctx, proposalID, title, description, ProposalTypeSoftwareUpgrade),
+		Plan:         plan,
 	}
 	keeper.SetProposal(ctx, proposal)
-	keeper.InactiveProposalQueuePush(ctx, proposal)
+	keeper.InsertInactiveProposalQueue(ctx, proposalID, proposal.GetDepositEndTime())
 	return proposal
 }
 
+func (keeper Keeper) newTextProposal(ctx sdk.Context, proposalID int64, title string, description string, proposalType ProposalKind) TextProposal {
+	submitTime := ctx.BlockHeader().Time
+	return TextProposal{
+		ProposalID:     proposalID,
+		Title:          title,
+		Description:    description,
+		ProposalType:   proposalType,
+		Status:         StatusDepositPeriod,
+		TallyResult:    EmptyTallyResult(),
+		TotalDeposit:   sdk.Coins{},
+		SubmitTime:     submitTime,
+		DepositEndTime: submitTime.Add(keeper.GetDepositProcedure(ctx).MaxDepositPeriod),
+	}
+}
+
+// Checks that every change targets an existing subspace and decodes to the
+// type registered for its key
+func (keeper Keeper) ValidateParamChanges(changes []ParamChange) sdk.Error {
+	for _, change := range changes {
+		space, ok := keeper.paramsKeeper.GetSubspace(change.Subspace)
+		if !ok {
+			return ErrInvalidParamChange(keeper.codespace, fmt.Sprintf("Unknown params subspace '%s'", change.Subspace))
+		}
+		err := space.CheckRaw([]byte(change.Key), []byte(change.Value))
+		if err != nil {
+			return ErrInvalidParamChange(keeper.codespace, err.Error())
+		}
+	}
+	return nil
+}
+
+// Applies the changes of a passed ParameterChangeProposal. Either all changes
+// are written or none is.
+func (keeper Keeper) applyParamChanges(ctx sdk.Context, changes []ParamChange) sdk.Error {
+	cacheCtx, writeCache := ctx.CacheContext()
+	for _, change := range changes {
+		space, ok := keeper.paramsKeeper.GetSubspace(change.Subspace)
+		if !ok {
+			return ErrInvalidParamChange(keeper.codespace, fmt.Sprintf("Unknown params subspace '%s'", change.Subspace))
+		}
+		err := space.Update(cacheCtx, []byte(change.Key), []byte(change.Value))
+		if err != nil {
+			return ErrInvalidParamChange(keeper.codespace, err.Error())
+		}
+	}
+	writeCache()
+	return nil
+}
+
 // Get Proposal from store by ProposalID
 func (keeper Keeper) GetProposal(ctx sdk.Context, proposalID int64) Proposal {
 	store := ctx.KVStore(keeper.storeKey)
@@ -126,6 +206,12 @@ func (keeper Keeper) DeleteProposal(ctx sdk.Context, proposal Proposal) {
 
 // Get Proposal from store by ProposalID
 func (keeper Keeper) GetProposalsFiltered(ctx sdk.Context, voterAddr sdk.AccAddress, depositerAddr sdk.AccAddress, status ProposalStatus, numLatest int64) []Proposal {
+	return keeper.GetProposalsFilteredPage(ctx, voterAddr, depositerAddr, status, numLatest, sdk.PageRequest{})
+}
+
+// Get a page of the proposals matching the filters, the zero page request
+// returns all of them
+func (keeper Keeper) GetProposalsFilteredPage(ctx sdk.Context, voterAddr sdk.AccAddress, depositerAddr sdk.AccAddress, status ProposalStatus, numLatest int64, page sdk.PageRequest) []Proposal {
 
 	maxProposalID, err := keeper.peekCurrentProposalID(ctx)
 	if err != nil {
@@ -138,7 +224,8 @@ func (keeper Keeper) GetProposalsFiltered(ctx sdk.Context, voterAddr sdk.AccAddr
 		numLatest = maxProposalID
 	}
 
-	for proposalID := maxProposalID - numLatest; proposalID < maxProposalID; proposalID++ {
+	numMatching := 0
+	for proposalID := maxProposalID - numLatest; proposalID < maxProposalID && !page.Done(numMatching); proposalID++ {
 		if voterAddr != nil && len(voterAddr) != 0 {
 			_, found := keeper.GetVote(ctx, proposalID, voterAddr)
 			if !found {
@@ -164,7 +251,10 @@ func (keeper Keeper) GetProposalsFiltered(ctx sdk.Context, voterAddr sdk.AccAddr
 			}
 		}
 
-		matchingProposals = append(matchingProposals, proposal)
+		if page.Contains(numMatching) {
+			matchingProposals = append(matchingProposals, proposal)
+		}
+		numMatching++
 	}
 	return matchingProposals
 }
@@ -215,10 +305,14 @@ func (keeper Keeper) peekCurrentProposalID(ctx sdk.Context) (proposalID int64, e
 }
 
 func (keeper Keeper) activateVotingPeriod(ctx sdk.Context, proposal Proposal) {
-	proposal.SetVotingStartTime(ctx.BlockHeader().Time)
+	votingStartTime := ctx.BlockHeader().Time
+	proposal.SetVotingStartTime(votingStartTime)
+	proposal.SetVotingEndTime(votingStartTime.Add(keeper.GetVotingProcedure(ctx).VotingPeriod))
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
-	keeper.ActiveProposalQueuePush(ctx, proposal)
+
+	keeper.RemoveFromInactiveProposalQueue(ctx, proposal.GetProposalID(), proposal.GetDepositEndTime())
+	keeper.InsertActiveProposalQueue(ctx, proposal.GetProposalID(), proposal.GetVotingEndTime())
 }
 
 // =====================================================
@@ -355,7 +449,7 @@ func (keeper Keeper) AddDeposit(ctx sdk.Context, proposalID int64, depositerAddr
 	}
 
 	// Subtract coins from depositer's account
-	_, _, err := keeper.ck.SubtractCoins(ctx, depositerAddr, depositAmount)
+	_, err := keeper.ck.SubtractCoins(ctx, depositerAddr, depositAmount)
 	if err != nil {
 		return err, false
 	}
@@ -400,7 +494,7 @@ func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID int64) {
 		deposit := &Deposit{}
 		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), deposit)
 
-		_, _, err := keeper.ck.AddCoins(ctx, deposit.Depositer, deposit.Amount)
+		_, err := keeper.ck.AddCoins(ctx, deposit.Depositer, deposit.Amount)
 		if err != nil {
 			panic("should not happen")
 		}
@@ -426,93 +520,103 @@ func (keeper Keeper) DeleteDeposits(ctx sdk.Context, proposalID int64) {
 // =====================================================
 // ProposalQueues
 
-func (keeper Keeper) getActiveProposalQueue(ctx sdk.Context) ProposalQueue {
+// Returns an iterator for all the proposals in the Active Queue that expire by endTime
+func (keeper Keeper) ActiveProposalQueueIterator(ctx sdk.Context, endTime time.Time) sdk.Iterator {
 	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyActiveProposalQueue)
-	if bz == nil {
-		return nil
-	}
-
-	var proposalQueue ProposalQueue
-	keeper.cdc.MustUnmarshalBinary(bz, &proposalQueue)
-
-	return proposalQueue
+	return store.Iterator(PrefixActiveProposalQueue, sdk.PrefixEndBytes(PrefixActiveProposalQueueTime(endTime)))
 }
 
-func (keeper Keeper) setActiveProposalQueue(ctx sdk.Context, proposalQueue ProposalQueue) {
+// Returns the number of proposals in the Active Queue
+func (keeper Keeper) ActiveProposalCount(ctx sdk.Context) (count int64) {
 	store := ctx.KVStore(keeper.storeKey)
-	bz := keeper.cdc.MustMarshalBinary(proposalQueue)
-	store.Set(KeyActiveProposalQueue, bz)
-}
-
-// Return the Proposal at the front of the ProposalQueue
-func (keeper Keeper) ActiveProposalQueuePeek(ctx sdk.Context) Proposal {
-	proposalQueue := keeper.getActiveProposalQueue(ctx)
-	if len(proposalQueue) == 0 {
-		return nil
+	iterator := sdk.KVStorePrefixIterator(store, PrefixActiveProposalQueue)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		count++
 	}
-	return keeper.GetProposal(ctx, proposalQueue[0])
+	return count
 }
 
-// Remove and return a Proposal from the front of the ProposalQueue
-func (keeper Keeper) ActiveProposalQueuePop(ctx sdk.Context) Proposal {
-	proposalQueue := keeper.getActiveProposalQueue(ctx)
-	if len(proposalQueue) == 0 {
-		return nil
-	}
-	frontElement, proposalQueue := proposalQueue[0], proposalQueue[1:]
-	keeper.setActiveProposalQueue(ctx, proposalQueue)
-	return keeper.GetProposal(ctx, frontElement)
+// Inserts a proposalID into the active proposal queue at endTime
+func (keeper Keeper) InsertActiveProposalQueue(ctx sdk.Context, proposalID int64, endTime time.Time) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := keeper.cdc.MustMarshalBinary(proposalID)
+	store.Set(KeyActiveProposalQueueProposal(endTime, proposalID), bz)
 }
 
-// Add a proposalID to the back of the ProposalQueue
-func (keeper Keeper) ActiveProposalQueuePush(ctx sdk.Context, proposal Proposal) {
-	proposalQueue := append(keeper.getActiveProposalQueue(ctx), proposal.GetProposalID())
-	keeper.setActiveProposalQueue(ctx, proposalQueue)
+// Removes a proposalID from the Active Proposal Queue
+func (keeper Keeper) RemoveFromActiveProposalQueue(ctx sdk.Context, proposalID int64, endTime time.Time) {
+	store := ctx.KVStore(keeper.storeKey)
+	store.Delete(KeyActiveProposalQueueProposal(endTime, proposalID))
 }
 
-func (keeper Keeper) getInactiveProposalQueue(ctx sdk.Context) ProposalQueue {
+// Returns an iterator for all the proposals in the Inactive Queue that expire by endTime
+func (keeper Keeper) InactiveProposalQueueIterator(ctx sdk.Context, endTime time.Time) sdk.Iterator {
 	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyInactiveProposalQueue)
-	if bz == nil {
-		return nil
-	}
-
-	var proposalQueue ProposalQueue
-
-	keeper.cdc.MustUnmarshalBinary(bz, &proposalQueue)
+	return store.Iterator(PrefixInactiveProposalQueue, sdk.PrefixEndBytes(PrefixInactiveProposalQueueTime(endTime)))
+}
 
-	return proposalQueue
+// Inserts a proposalID into the inactive proposal queue at endTime
+func (keeper Keeper) InsertInactiveProposalQueue(ctx sdk.Context, proposalID int64, endTime time.Time) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := keeper.cdc.MustMarshalBinary(proposalID)
+	store.Set(KeyInactiveProposalQueueProposal(endTime, proposalID), bz)
 }
 
-func (keeper Keeper) setInactiveProposalQueue(ctx sdk.Context, proposalQueue ProposalQueue) {
+// Removes a proposalID from the Inactive Proposal Queue
+func (keeper Keeper) RemoveFromInactiveProposalQueue(ctx sdk.Context, proposalID int64, endTime time.Time) {
 	store := ctx.KVStore(keeper.storeKey)
-	bz := keeper.cdc.MustMarshalBinary(proposalQueue)
-	store.Set(KeyInactiveProposalQueue, bz)
+	store.Delete(KeyInactiveProposalQueueProposal(endTime, proposalID))
 }
 
-// Return the Proposal at the front of the ProposalQueue
-func (keeper Keeper) InactiveProposalQueuePeek(ctx sdk.Context) Proposal {
-	proposalQueue := keeper.getInactiveProposalQueue(ctx)
-	if len(proposalQueue) == 0 {
-		return nil
+// ProposalQueuesUpgradeHandler returns the handler of the software upgrade
+// switching a running chain to the time-keyed proposal queues, which
+// migrates its legacy queues. Only the release performing that upgrade
+// registers it, chains restarted from an exported genesis are migrated by
+// InitGenesis.
+func ProposalQueuesUpgradeHandler(keeper Keeper) upgrade.UpgradeHandler {
+	return func(ctx sdk.Context, plan upgrade.Plan) {
+		keeper.MigrateProposalQueues(ctx)
 	}
-	return keeper.GetProposal(ctx, proposalQueue[0])
 }
 
-// Remove and return a Proposal from the front of the ProposalQueue
-func (keeper Keeper) InactiveProposalQueuePop(ctx sdk.Context) Proposal {
-	proposalQueue := keeper.getInactiveProposalQueue(ctx)
-	if len(proposalQueue) == 0 {
-		return nil
+// Moves the proposals of the legacy queues, stored as a single ProposalQueue
+// blob each, into the time-keyed queues. Proposals created before the end
+// times were tracked get them derived from the current procedures.
+func (keeper Keeper) MigrateProposalQueues(ctx sdk.Context) {
+	store := ctx.KVStore(keeper.storeKey)
+
+	if bz := store.Get(KeyInactiveProposalQueue); bz != nil {
+		var proposalQueue ProposalQueue
+		keeper.cdc.MustUnmarshalBinary(bz, &proposalQueue)
+		for _, proposalID := range proposalQueue {
+			proposal := keeper.GetProposal(ctx, proposalID)
+			if proposal == nil || proposal.GetStatus() != StatusDepositPeriod {
+				continue
+			}
+			if proposal.GetDepositEndTime().IsZero() {
+				proposal.SetDepositEndTime(proposal.GetSubmitTime().Add(keeper.GetDepositProcedure(ctx).MaxDepositPeriod))
+				keeper.SetProposal(ctx, proposal)
+			}
+			keeper.InsertInactiveProposalQueue(ctx, proposalID, proposal.GetDepositEndTime())
+		}
+		store.Delete(KeyInactiveProposalQueue)
 	}
-	frontElement, proposalQueue := proposalQueue[0], proposalQueue[1:]
-	keeper.setInactiveProposalQueue(ctx, proposalQueue)
-	return keeper.GetProposal(ctx, frontElement)
-}
 
-// Add a proposalID to the back of the ProposalQueue
-func (keeper Keeper) InactiveProposalQueuePush(ctx sdk.Context, proposal Proposal) {
-	proposalQueue := append(keeper.getInactiveProposalQueue(ctx), proposal.GetProposalID())
-	keeper.setInactiveProposalQueue(ctx, proposalQueue)
+	if bz := store.Get(KeyActiveProposalQueue); bz != nil {
+		var proposalQueue ProposalQueue
+		keeper.cdc.MustUnmarshalBinary(bz, &proposalQueue)
+		for _, proposalID := range proposalQueue {
+			proposal := keeper.GetProposal(ctx, proposalID)
+			if proposal == nil || proposal.GetStatus() != StatusVotingPeriod {
+				continue
+			}
+			if proposal.GetVotingEndTime().IsZero() {
+				proposal.SetVotingEndTime(proposal.GetVotingStartTime().Add(keeper.GetVotingProcedure(ctx).VotingPeriod))
+				keeper.SetProposal(ctx, proposal)
+			}
+			keeper.InsertActiveProposalQueue(ctx, proposalID, proposal.GetVotingEndTime())
+		}
+		store.Delete(KeyActiveProposalQueue)
+	}
 }
@@ -1,7 +1,9 @@
 package gov
 
 import (
+	"encoding/binary"
 	"fmt"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -10,7 +12,15 @@ import (
 
 // Key for getting a the next available proposalID from the store
 var (
-	KeyNextProposalID        = []byte("newProposalID")
+	KeyNextProposalID = []byte("newProposalID")
+
+	// prefixes of the proposal queues, keyed by the time at which the
+	// period of the proposal ends
+	PrefixActiveProposalQueue   = []byte("activeProposalQueue:")
+	PrefixInactiveProposalQueue = []byte("inactiveProposalQueue:")
+
+	// keys of the legacy proposal queues, stored as a single ProposalQueue
+	// each, only read to migrate them
 	KeyActiveProposalQueue   = []byte("activeProposalQueue")
 	KeyInactiveProposalQueue = []byte("inactiveProposalQueue")
 )
@@ -39,3 +49,30 @@ func KeyDepositsSubspace(proposalID int64) []byte {
 func KeyVotesSubspace(proposalID int64) []byte {
 	return []byte(fmt.Sprintf("votes:%d:", proposalID))
 }
+
+// Key for getting all active proposals ending at a given time
+func PrefixActiveProposalQueueTime(endTime time.Time) []byte {
+	return append(PrefixActiveProposalQueue, sdk.FormatTimeBytes(endTime)...)
+}
+
+// Key for an active proposal in the queue
+func KeyActiveProposalQueueProposal(endTime time.Time, proposalID int64) []byte {
+	return append(PrefixActiveProposalQueueTime(endTime), proposalIDBytes(proposalID)...)
+}
+
+// Key for getting all inactive proposals ending at a given time
+func PrefixInactiveProposalQueueTime(endTime time.Time) []byte {
+	return append(PrefixInactiveProposalQueue, sdk.FormatTimeBytes(endTime)...)
+}
+
+// Key for an inactive proposal in the queue
+func KeyInactiveProposalQueueProposal(endTime time.Time, proposalID int64) []byte {
+	return append(PrefixInactiveProposalQueueTime(endTime), proposalIDBytes(proposalID)...)
+}
+
+// big endian, so that proposals ending at the same time are ordered by ID
+func proposalIDBytes(proposalID int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(proposalID))
+	return bz
+}
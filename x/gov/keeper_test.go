@@ -9,6 +9,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 func TestGetSetProposal(t *testing.T) {
@@ -47,12 +48,23 @@ func TestActivateVotingPeriod(t *testing.T) {
 	proposal := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
 
 	require.True(t, proposal.GetVotingStartTime().Equal(time.Time{}))
-	require.Nil(t, keeper.ActiveProposalQueuePeek(ctx))
 
 	keeper.activateVotingPeriod(ctx, proposal)
 
 	require.True(t, proposal.GetVotingStartTime().Equal(ctx.BlockHeader().Time))
-	require.Equal(t, proposal.GetProposalID(), keeper.ActiveProposalQueuePeek(ctx).GetProposalID())
+	votingPeriod := keeper.GetVotingProcedure(ctx).VotingPeriod
+	require.True(t, proposal.GetVotingEndTime().Equal(ctx.BlockHeader().Time.Add(votingPeriod)))
+
+	inactiveQueue := keeper.InactiveProposalQueueIterator(ctx, proposal.GetDepositEndTime())
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
+
+	activeQueue := keeper.ActiveProposalQueueIterator(ctx, proposal.GetVotingEndTime())
+	require.True(t, activeQueue.Valid())
+	var proposalID int64
+	keeper.cdc.MustUnmarshalBinary(activeQueue.Value(), &proposalID)
+	require.Equal(t, proposal.GetProposalID(), proposalID)
+	activeQueue.Close()
 }
 
 func TestDeposits(t *testing.T) {
@@ -79,7 +91,6 @@ func TestDeposits(t *testing.T) {
 	deposit, found := keeper.GetDeposit(ctx, proposalID, addrs[1])
 	require.False(t, found)
 	require.True(t, keeper.GetProposal(ctx, proposalID).GetVotingStartTime().Equal(time.Time{}))
-	require.Nil(t, keeper.ActiveProposalQueuePeek(ctx))
 
 	// Check first deposit
 	err, votingStarted := keeper.AddDeposit(ctx, proposalID, addrs[0], fourSteak)
@@ -116,8 +127,12 @@ func TestDeposits(t *testing.T) {
 
 	// Check that proposal moved to voting period
 	require.True(t, keeper.GetProposal(ctx, proposalID).GetVotingStartTime().Equal(ctx.BlockHeader().Time))
-	require.NotNil(t, keeper.ActiveProposalQueuePeek(ctx))
-	require.Equal(t, proposalID, keeper.ActiveProposalQueuePeek(ctx).GetProposalID())
+	activeQueue := keeper.ActiveProposalQueueIterator(ctx, keeper.GetProposal(ctx, proposalID).GetVotingEndTime())
+	require.True(t, activeQueue.Valid())
+	var activeProposalID int64
+	keeper.cdc.MustUnmarshalBinary(activeQueue.Value(), &activeProposalID)
+	require.Equal(t, proposalID, activeProposalID)
+	activeQueue.Close()
 
 	// Test deposit iterator
 	depositsIterator := keeper.GetDeposits(ctx, proposalID)
@@ -207,44 +222,82 @@ func TestProposalQueues(t *testing.T) {
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
 	mapp.InitChainer(ctx, abci.RequestInitChain{})
 
-	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
-	require.Nil(t, keeper.ActiveProposalQueuePeek(ctx))
-
-	// create test proposals
+	// create test proposals, which are added to the inactive proposal queue
 	proposal := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: ctx.BlockHeader().Time.Add(time.Second)})
 	proposal2 := keeper.NewTextProposal(ctx, "Test2", "description", ProposalTypeText)
 	proposal3 := keeper.NewTextProposal(ctx, "Test3", "description", ProposalTypeText)
-	proposal4 := keeper.NewTextProposal(ctx, "Test4", "description", ProposalTypeText)
-
-	// test pushing to inactive proposal queue
-	keeper.InactiveProposalQueuePush(ctx, proposal)
-	keeper.InactiveProposalQueuePush(ctx, proposal2)
-	keeper.InactiveProposalQueuePush(ctx, proposal3)
-	keeper.InactiveProposalQueuePush(ctx, proposal4)
-
-	// test peeking and popping from inactive proposal queue
-	require.Equal(t, keeper.InactiveProposalQueuePeek(ctx).GetProposalID(), proposal.GetProposalID())
-	require.Equal(t, keeper.InactiveProposalQueuePop(ctx).GetProposalID(), proposal.GetProposalID())
-	require.Equal(t, keeper.InactiveProposalQueuePeek(ctx).GetProposalID(), proposal2.GetProposalID())
-	require.Equal(t, keeper.InactiveProposalQueuePop(ctx).GetProposalID(), proposal2.GetProposalID())
-	require.Equal(t, keeper.InactiveProposalQueuePeek(ctx).GetProposalID(), proposal3.GetProposalID())
-	require.Equal(t, keeper.InactiveProposalQueuePop(ctx).GetProposalID(), proposal3.GetProposalID())
-	require.Equal(t, keeper.InactiveProposalQueuePeek(ctx).GetProposalID(), proposal4.GetProposalID())
-	require.Equal(t, keeper.InactiveProposalQueuePop(ctx).GetProposalID(), proposal4.GetProposalID())
-
-	// test pushing to active proposal queue
-	keeper.ActiveProposalQueuePush(ctx, proposal)
-	keeper.ActiveProposalQueuePush(ctx, proposal2)
-	keeper.ActiveProposalQueuePush(ctx, proposal3)
-	keeper.ActiveProposalQueuePush(ctx, proposal4)
-
-	// test peeking and popping from active proposal queue
-	require.Equal(t, keeper.ActiveProposalQueuePeek(ctx).GetProposalID(), proposal.GetProposalID())
-	require.Equal(t, keeper.ActiveProposalQueuePop(ctx).GetProposalID(), proposal.GetProposalID())
-	require.Equal(t, keeper.ActiveProposalQueuePeek(ctx).GetProposalID(), proposal2.GetProposalID())
-	require.Equal(t, keeper.ActiveProposalQueuePop(ctx).GetProposalID(), proposal2.GetProposalID())
-	require.Equal(t, keeper.ActiveProposalQueuePeek(ctx).GetProposalID(), proposal3.GetProposalID())
-	require.Equal(t, keeper.ActiveProposalQueuePop(ctx).GetProposalID(), proposal3.GetProposalID())
-	require.Equal(t, keeper.ActiveProposalQueuePeek(ctx).GetProposalID(), proposal4.GetProposalID())
-	require.Equal(t, keeper.ActiveProposalQueuePop(ctx).GetProposalID(), proposal4.GetProposalID())
+
+	// only the proposals whose deposit period ends by the given time are iterated
+	inactiveQueue := keeper.InactiveProposalQueueIterator(ctx, proposal.GetDepositEndTime())
+	require.Equal(t, []int64{proposal.GetProposalID()}, queueProposalIDs(keeper, inactiveQueue))
+
+	// proposals ending at the same time are ordered by ID
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, proposal3.GetDepositEndTime())
+	require.Equal(t, []int64{proposal.GetProposalID(), proposal2.GetProposalID(), proposal3.GetProposalID()},
+		queueProposalIDs(keeper, inactiveQueue))
+
+	// moving a proposal to the voting period moves it to the active proposal queue
+	keeper.activateVotingPeriod(ctx, proposal2)
+	inactiveQueue = keeper.InactiveProposalQueueIterator(ctx, proposal3.GetDepositEndTime())
+	require.Equal(t, []int64{proposal.GetProposalID(), proposal3.GetProposalID()}, queueProposalIDs(keeper, inactiveQueue))
+	activeQueue := keeper.ActiveProposalQueueIterator(ctx, proposal2.GetVotingEndTime().Add(-time.Second))
+	require.Empty(t, queueProposalIDs(keeper, activeQueue))
+	activeQueue = keeper.ActiveProposalQueueIterator(ctx, proposal2.GetVotingEndTime())
+	require.Equal(t, []int64{proposal2.GetProposalID()}, queueProposalIDs(keeper, activeQueue))
+	require.Equal(t, int64(1), keeper.ActiveProposalCount(ctx))
+
+	keeper.RemoveFromActiveProposalQueue(ctx, proposal2.GetProposalID(), proposal2.GetVotingEndTime())
+	activeQueue = keeper.ActiveProposalQueueIterator(ctx, proposal2.GetVotingEndTime())
+	require.Empty(t, queueProposalIDs(keeper, activeQueue))
+	require.Equal(t, int64(0), keeper.ActiveProposalCount(ctx))
+}
+
+func TestMigrateProposalQueues(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	mapp.InitChainer(ctx, abci.RequestInitChain{})
+
+	inactiveProposal := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+	activeProposal := keeper.NewTextProposal(ctx, "Test2", "description", ProposalTypeText)
+	keeper.activateVotingPeriod(ctx, activeProposal)
+
+	// rewrite the state as it was before the end times were tracked
+	for _, proposal := range []Proposal{inactiveProposal, activeProposal} {
+		keeper.RemoveFromInactiveProposalQueue(ctx, proposal.GetProposalID(), proposal.GetDepositEndTime())
+		keeper.RemoveFromActiveProposalQueue(ctx, proposal.GetProposalID(), proposal.GetVotingEndTime())
+		proposal.SetDepositEndTime(time.Time{})
+		proposal.SetVotingEndTime(time.Time{})
+		keeper.SetProposal(ctx, proposal)
+	}
+	store := ctx.KVStore(keeper.storeKey)
+	store.Set(KeyInactiveProposalQueue, keeper.cdc.MustMarshalBinary(ProposalQueue{inactiveProposal.GetProposalID(), activeProposal.GetProposalID()}))
+	store.Set(KeyActiveProposalQueue, keeper.cdc.MustMarshalBinary(ProposalQueue{activeProposal.GetProposalID()}))
+
+	ProposalQueuesUpgradeHandler(keeper)(ctx, upgrade.NewHeightPlan("proposal-queues", 1, ""))
+
+	require.Nil(t, store.Get(KeyInactiveProposalQueue))
+	require.Nil(t, store.Get(KeyActiveProposalQueue))
+
+	depositEndTime := inactiveProposal.GetSubmitTime().Add(keeper.GetDepositProcedure(ctx).MaxDepositPeriod)
+	require.True(t, keeper.GetProposal(ctx, inactiveProposal.GetProposalID()).GetDepositEndTime().Equal(depositEndTime))
+	inactiveQueue := keeper.InactiveProposalQueueIterator(ctx, depositEndTime)
+	require.Equal(t, []int64{inactiveProposal.GetProposalID()}, queueProposalIDs(keeper, inactiveQueue))
+
+	votingEndTime := activeProposal.GetVotingStartTime().Add(keeper.GetVotingProcedure(ctx).VotingPeriod)
+	require.True(t, keeper.GetProposal(ctx, activeProposal.GetProposalID()).GetVotingEndTime().Equal(votingEndTime))
+	activeQueue := keeper.ActiveProposalQueueIterator(ctx, votingEndTime)
+	require.Equal(t, []int64{activeProposal.GetProposalID()}, queueProposalIDs(keeper, activeQueue))
+}
+
+// collects the proposal IDs of a proposal queue iterator and closes it
+func queueProposalIDs(keeper Keeper, iterator sdk.Iterator) (proposalIDs []int64) {
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var proposalID int64
+		keeper.cdc.MustUnmarshalBinary(iterator.Value(), &proposalID)
+		proposalIDs = append(proposalIDs, proposalID)
+	}
+	return proposalIDs
 }
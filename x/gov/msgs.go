@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 // name to idetify transaction types
@@ -14,11 +15,13 @@ var _, _, _ sdk.Msg = MsgSubmitProposal{}, MsgDeposit{}, MsgVote{}
 //-----------------------------------------------------------
 // MsgSubmitProposal
 type MsgSubmitProposal struct {
-	Title          string         `json:"title"`           //  Title of the proposal
-	Description    string         `json:"description"`     //  Description of the proposal
-	ProposalType   ProposalKind   `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Proposer       sdk.AccAddress `json:"proposer"`        //  Address of the proposer
-	InitialDeposit sdk.Coins      `json:"initial_deposit"` //  Initial deposit paid by sender. Must be strictly positive.
+	Title          string         `json:"title"`             //  Title of the proposal
+	Description    string         `json:"description"`       //  Description of the proposal
+	ProposalType   ProposalKind   `json:"proposal_type"`     //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Proposer       sdk.AccAddress `json:"proposer"`          //  Address of the proposer
+	InitialDeposit sdk.Coins      `json:"initial_deposit"`   //  Initial deposit paid by sender. Must be strictly positive.
+	Changes        []ParamChange  `json:"changes,omitempty"` //  Parameter changes, only set for ParameterChange proposals
+	Plan           upgrade.Plan   `json:"plan,omitempty"`    //  Upgrade plan, only set for SoftwareUpgrade proposals
 }
 
 func NewMsgSubmitProposal(title string, description string, proposalType ProposalKind, proposer sdk.AccAddress, initialDeposit sdk.Coins) MsgSubmitProposal {
@@ -31,6 +34,28 @@ func NewMsgSubmitProposal(title string, description string, proposalType Proposa
 	}
 }
 
+func NewMsgSubmitParameterChangeProposal(title string, description string, changes []ParamChange, proposer sdk.AccAddress, initialDeposit sdk.Coins) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   ProposalTypeParameterChange,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+		Changes:        changes,
+	}
+}
+
+func NewMsgSubmitSoftwareUpgradeProposal(title string, description string, plan upgrade.Plan, proposer sdk.AccAddress, initialDeposit sdk.Coins) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   ProposalTypeSoftwareUpgrade,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+		Plan:           plan,
+	}
+}
+
 // Implements Msg.
 func (msg MsgSubmitProposal) Type() string { return MsgType }
 func (msg MsgSubmitProposal) Name() string { return "submit_proposal" }
@@ -55,9 +80,33 @@ func (msg MsgSubmitProposal) ValidateBasic() sdk.Error {
 	if !msg.InitialDeposit.IsNotNegative() {
 		return sdk.ErrInvalidCoins(msg.InitialDeposit.String())
 	}
+	if msg.ProposalType != ProposalTypeParameterChange && len(msg.Changes) != 0 {
+		return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("%s proposals cannot contain parameter changes", msg.ProposalType))
+	}
+	if msg.ProposalType != ProposalTypeSoftwareUpgrade && hasPlan(msg.Plan) {
+		return ErrInvalidUpgradePlan(DefaultCodespace, fmt.Sprintf("%s proposals cannot contain an upgrade plan", msg.ProposalType))
+	}
+
+	switch msg.ProposalType {
+	case ProposalTypeParameterChange:
+		if len(msg.Changes) == 0 {
+			return ErrInvalidParamChange(DefaultCodespace, "ParameterChange proposals must contain at least one change")
+		}
+		for _, change := range msg.Changes {
+			if len(change.Subspace) == 0 || len(change.Key) == 0 || len(change.Value) == 0 {
+				return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("Parameter change '%s' is incomplete", change))
+			}
+		}
+	case ProposalTypeSoftwareUpgrade:
+		return msg.Plan.ValidateBasic()
+	}
 	return nil
 }
 
+func hasPlan(plan upgrade.Plan) bool {
+	return len(plan.Name) != 0 || plan.Height != 0 || !plan.Time.IsZero() || len(plan.Info) != 0
+}
+
 func (msg MsgSubmitProposal) String() string {
 	return fmt.Sprintf("MsgSubmitProposal{%s, %s, %s, %v}", msg.Title, msg.Description, msg.ProposalType, msg.InitialDeposit)
 }
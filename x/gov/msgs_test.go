@@ -2,11 +2,13 @@ package gov
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 var (
@@ -30,8 +32,8 @@ func TestMsgSubmitProposal(t *testing.T) {
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, true},
 		{"", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, false},
 		{"Test Proposal", "", ProposalTypeText, addrs[0], coinsPos, false},
-		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, true},
-		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, true},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, false},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", 0x05, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, sdk.AccAddress{}, coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsZero, true},
@@ -49,6 +51,66 @@ func TestMsgSubmitProposal(t *testing.T) {
 	}
 }
 
+// test ValidateBasic for ParameterChange proposals
+func TestMsgSubmitParameterChangeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	tests := []struct {
+		changes    []ParamChange
+		expectPass bool
+	}{
+		{[]ParamChange{NewParamChange("stake", "MaxValidators", `10`)}, true},
+		{[]ParamChange{NewParamChange("stake", "MaxValidators", `10`), NewParamChange("gov", "votingprocedure", `{}`)}, true},
+		{nil, false},
+		{[]ParamChange{}, false},
+		{[]ParamChange{NewParamChange("", "MaxValidators", `10`)}, false},
+		{[]ParamChange{NewParamChange("stake", "", `10`)}, false},
+		{[]ParamChange{NewParamChange("stake", "MaxValidators", "")}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitParameterChangeProposal("Test Proposal", "the purpose of this proposal is to test", tc.changes, addrs[0], coinsPos)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	// other proposal types cannot carry changes
+	msg := NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos)
+	msg.Changes = []ParamChange{NewParamChange("stake", "MaxValidators", `10`)}
+	require.NotNil(t, msg.ValidateBasic())
+}
+
+// test ValidateBasic for SoftwareUpgrade proposals
+func TestMsgSubmitSoftwareUpgradeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	tests := []struct {
+		plan       upgrade.Plan
+		expectPass bool
+	}{
+		{upgrade.NewHeightPlan("v2", 100, "https://example.com/v2"), true},
+		{upgrade.NewTimePlan("v2", time.Unix(1000, 0), ""), true},
+		{upgrade.Plan{}, false},
+		{upgrade.NewHeightPlan("", 100, ""), false},
+		{upgrade.Plan{Name: "v2", Height: 100, Time: time.Unix(1000, 0)}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitSoftwareUpgradeProposal("Test Proposal", "the purpose of this proposal is to test", tc.plan, addrs[0], coinsPos)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	// other proposal types cannot carry a plan
+	msg := NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos)
+	msg.Plan = upgrade.NewHeightPlan("v2", 100, "")
+	require.NotNil(t, msg.ValidateBasic())
+}
+
 // test ValidateBasic for MsgDeposit
 func TestMsgDeposit(t *testing.T) {
 	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
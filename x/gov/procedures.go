@@ -14,9 +14,10 @@ type DepositProcedure struct {
 
 // Procedure around Tallying votes in governance
 type TallyingProcedure struct {
+	Quorum            sdk.Dec `json:"quorum"`             //  Minimum proportion of bonded voting power that must vote for the result to be valid. Initial value: 0.334
 	Threshold         sdk.Dec `json:"threshold"`          //  Minimum propotion of Yes votes for proposal to pass. Initial value: 0.5
 	Veto              sdk.Dec `json:"veto"`               //  Minimum value of Veto votes to Total votes ratio for proposal to be vetoed. Initial value: 1/3
-	GovernancePenalty sdk.Dec `json:"governance_penalty"` //  Penalty if validator does not vote
+	GovernancePenalty sdk.Dec `json:"governance_penalty"` //  Penalty slashed from bonded validators that did not vote. Initial value: 0.01
 }
 
 // Procedure around Voting in governance
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 //-----------------------------------------------------------
@@ -34,11 +35,17 @@ type Proposal interface {
 	GetSubmitTime() time.Time
 	SetSubmitTime(time.Time)
 
+	GetDepositEndTime() time.Time
+	SetDepositEndTime(time.Time)
+
 	GetTotalDeposit() sdk.Coins
 	SetTotalDeposit(sdk.Coins)
 
 	GetVotingStartTime() time.Time
 	SetVotingStartTime(time.Time)
+
+	GetVotingEndTime() time.Time
+	SetVotingEndTime(time.Time)
 }
 
 // checks if two proposals are equal
@@ -50,8 +57,10 @@ func ProposalEqual(proposalA Proposal, proposalB Proposal) bool {
 		proposalA.GetStatus() == proposalB.GetStatus() &&
 		proposalA.GetTallyResult().Equals(proposalB.GetTallyResult()) &&
 		proposalA.GetSubmitTime().Equal(proposalB.GetSubmitTime()) &&
+		proposalA.GetDepositEndTime().Equal(proposalB.GetDepositEndTime()) &&
 		proposalA.GetTotalDeposit().IsEqual(proposalB.GetTotalDeposit()) &&
-		proposalA.GetVotingStartTime().Equal(proposalB.GetVotingStartTime()) {
+		proposalA.GetVotingStartTime().Equal(proposalB.GetVotingStartTime()) &&
+		proposalA.GetVotingEndTime().Equal(proposalB.GetVotingEndTime()) {
 		return true
 	}
 	return false
@@ -68,10 +77,12 @@ type TextProposal struct {
 	Status      ProposalStatus `json:"proposal_status"` //  Status of the Proposal {Pending, Active, Passed, Rejected}
 	TallyResult TallyResult    `json:"tally_result"`    //  Result of Tallys
 
-	SubmitTime   time.Time `json:"submit_time"`   //  Height of the block where TxGovSubmitProposal was included
-	TotalDeposit sdk.Coins `json:"total_deposit"` //  Current deposit on this proposal. Initial value is set at InitialDeposit
+	SubmitTime     time.Time `json:"submit_time"`      //  Height of the block where TxGovSubmitProposal was included
+	DepositEndTime time.Time `json:"deposit_end_time"` //  Time at which the proposal is dropped if MinDeposit is not reached
+	TotalDeposit   sdk.Coins `json:"total_deposit"`    //  Current deposit on this proposal. Initial value is set at InitialDeposit
 
 	VotingStartTime time.Time `json:"voting_start_time"` //  Height of the block where MinDeposit was reached. -1 if MinDeposit is not reached
+	VotingEndTime   time.Time `json:"voting_end_time"`   //  Time at which the votes of the proposal are tallied
 }
 
 // Implements Proposal Interface
@@ -98,9 +109,62 @@ func (tp TextProposal) GetVotingStartTime() time.Time              { return tp.V
 func (tp *TextProposal) SetVotingStartTime(votingStartTime time.Time) {
 	tp.VotingStartTime = votingStartTime
 }
+func (tp TextProposal) GetDepositEndTime() time.Time { return tp.DepositEndTime }
+func (tp *TextProposal) SetDepositEndTime(depositEndTime time.Time) {
+	tp.DepositEndTime = depositEndTime
+}
+func (tp TextProposal) GetVotingEndTime() time.Time { return tp.VotingEndTime }
+func (tp *TextProposal) SetVotingEndTime(votingEndTime time.Time) {
+	tp.VotingEndTime = votingEndTime
+}
+
+//-----------------------------------------------------------
+// Parameter Change Proposals
+
+// ParamChange defines a single parameter update, the value being the JSON
+// encoding of the new parameter as stored by x/params
+type ParamChange struct {
+	Subspace string `json:"subspace"` //  Name of the params subspace
+	Key      string `json:"key"`      //  Key of the parameter within the subspace
+	Value    string `json:"value"`    //  JSON encoded value of the parameter
+}
+
+func NewParamChange(subspace, key, value string) ParamChange {
+	return ParamChange{subspace, key, value}
+}
+
+func (pc ParamChange) String() string {
+	return fmt.Sprintf("%s/%s: %s", pc.Subspace, pc.Key, pc.Value)
+}
+
+// ParameterChangeProposal is a TextProposal which, once passed, applies its
+// parameter changes to the corresponding params subspaces
+type ParameterChangeProposal struct {
+	TextProposal
+	Changes []ParamChange `json:"changes"` //  Parameter changes applied when the proposal passes
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*ParameterChangeProposal)(nil)
+
+//-----------------------------------------------------------
+// Software Upgrade Proposals
+
+// SoftwareUpgradeProposal is a TextProposal which, once passed, schedules
+// its upgrade plan in the upgrade module
+type SoftwareUpgradeProposal struct {
+	TextProposal
+	Plan upgrade.Plan `json:"plan"` //  Upgrade plan scheduled when the proposal passes
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*SoftwareUpgradeProposal)(nil)
 
 //-----------------------------------------------------------
 // ProposalQueue
+
+// Legacy format of the proposal queues, which were stored as a single list
+// of proposal IDs. Only used by MigrateProposalQueues.
 type ProposalQueue []int64
 
 //-----------------------------------------------------------
@@ -310,6 +374,7 @@ type TallyResult struct {
 	Abstain    sdk.Dec `json:"abstain"`
 	No         sdk.Dec `json:"no"`
 	NoWithVeto sdk.Dec `json:"no_with_veto"`
+	Turnout    sdk.Dec `json:"turnout"` //  Proportion of the bonded voting power which voted
 }
 
 // checks if two proposals are equal
@@ -319,6 +384,7 @@ func EmptyTallyResult() TallyResult {
 		Abstain:    sdk.ZeroDec(),
 		No:         sdk.ZeroDec(),
 		NoWithVeto: sdk.ZeroDec(),
+		Turnout:    sdk.ZeroDec(),
 	}
 }
 
@@ -327,5 +393,6 @@ func (resultA TallyResult) Equals(resultB TallyResult) bool {
 	return (resultA.Yes.Equal(resultB.Yes) &&
 		resultA.Abstain.Equal(resultB.Abstain) &&
 		resultA.No.Equal(resultB.No) &&
-		resultA.NoWithVeto.Equal(resultB.NoWithVeto))
+		resultA.NoWithVeto.Equal(resultB.NoWithVeto) &&
+		resultA.Turnout.Equal(resultB.Turnout))
 }
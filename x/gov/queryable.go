@@ -112,6 +112,7 @@ func queryVote(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Kee
 // Params for query 'custom/gov/deposits'
 type QueryDepositsParams struct {
 	ProposalID int64
+	Page       sdk.PageRequest
 }
 
 // nolint: unparam
@@ -122,12 +123,21 @@ func queryDeposits(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err2.Error()))
 	}
 
+	params.Page, err = params.Page.QuerierPage()
+	if err != nil {
+		return nil, err
+	}
+
 	var deposits []Deposit
 	depositsIterator := keeper.GetDeposits(ctx, params.ProposalID)
-	for ; depositsIterator.Valid(); depositsIterator.Next() {
-		deposit := Deposit{}
-		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), &deposit)
-		deposits = append(deposits, deposit)
+	defer depositsIterator.Close()
+	for i := 0; depositsIterator.Valid() && !params.Page.Done(i); depositsIterator.Next() {
+		if params.Page.Contains(i) {
+			deposit := Deposit{}
+			keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), &deposit)
+			deposits = append(deposits, deposit)
+		}
+		i++
 	}
 
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, deposits)
@@ -140,6 +150,7 @@ func queryDeposits(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 // Params for query 'custom/gov/votes'
 type QueryVotesParams struct {
 	ProposalID int64
+	Page       sdk.PageRequest
 }
 
 // nolint: unparam
@@ -151,12 +162,21 @@ func queryVotes(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Ke
 		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err2.Error()))
 	}
 
+	params.Page, err = params.Page.QuerierPage()
+	if err != nil {
+		return nil, err
+	}
+
 	var votes []Vote
 	votesIterator := keeper.GetVotes(ctx, params.ProposalID)
-	for ; votesIterator.Valid(); votesIterator.Next() {
-		vote := Vote{}
-		keeper.cdc.MustUnmarshalBinary(votesIterator.Value(), &vote)
-		votes = append(votes, vote)
+	defer votesIterator.Close()
+	for i := 0; votesIterator.Valid() && !params.Page.Done(i); votesIterator.Next() {
+		if params.Page.Contains(i) {
+			vote := Vote{}
+			keeper.cdc.MustUnmarshalBinary(votesIterator.Value(), &vote)
+			votes = append(votes, vote)
+		}
+		i++
 	}
 
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, votes)
@@ -172,6 +192,7 @@ type QueryProposalsParams struct {
 	Depositer          sdk.AccAddress
 	ProposalStatus     ProposalStatus
 	NumLatestProposals int64
+	Page               sdk.PageRequest
 }
 
 // nolint: unparam
@@ -182,7 +203,12 @@ func queryProposals(ctx sdk.Context, path []string, req abci.RequestQuery, keepe
 		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err2.Error()))
 	}
 
-	proposals := keeper.GetProposalsFiltered(ctx, params.Voter, params.Depositer, params.ProposalStatus, params.NumLatestProposals)
+	params.Page, err = params.Page.QuerierPage()
+	if err != nil {
+		return nil, err
+	}
+
+	proposals := keeper.GetProposalsFilteredPage(ctx, params.Voter, params.Depositer, params.ProposalStatus, params.NumLatestProposals, params.Page)
 
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, proposals)
 	if err2 != nil {
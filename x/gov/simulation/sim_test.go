@@ -12,7 +12,6 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/mock"
 	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
-	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
@@ -27,9 +26,7 @@ func TestGovWithRandomMessages(t *testing.T) {
 	bankKeeper := bank.NewBaseKeeper(mapper)
 	stakeKey := sdk.NewKVStoreKey("stake")
 	stakeTKey := sdk.NewTransientStoreKey("transient_stake")
-	paramKey := sdk.NewKVStoreKey("params")
-	paramTKey := sdk.NewTransientStoreKey("transient_params")
-	paramKeeper := params.NewKeeper(mapp.Cdc, paramKey, paramTKey)
+	paramKeeper := mapp.ParamsKeeper
 	stakeKeeper := stake.NewKeeper(mapp.Cdc, stakeKey, stakeTKey, bankKeeper, paramKeeper.Subspace(stake.DefaultParamspace), stake.DefaultCodespace)
 	govKey := sdk.NewKVStoreKey("gov")
 	govKeeper := gov.NewKeeper(mapp.Cdc, govKey, paramKeeper, paramKeeper.Subspace(gov.DefaultParamspace), bankKeeper, stakeKeeper, gov.DefaultCodespace)
@@ -39,7 +36,7 @@ func TestGovWithRandomMessages(t *testing.T) {
 		return abci.ResponseEndBlock{}
 	})
 
-	err := mapp.CompleteSetup(stakeKey, stakeTKey, paramKey, paramTKey, govKey)
+	err := mapp.CompleteSetup(stakeKey, stakeTKey, govKey)
 	if err != nil {
 		panic(err)
 	}
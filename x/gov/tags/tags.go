@@ -1,22 +1,26 @@
 // nolint
 package tags
 
-import (
-	sdk "github.com/cosmos/cosmos-sdk/types"
-)
-
+// Types and attribute keys of the events emitted by the gov module. They are
+// indexed as "<type>.<key>" tags, e.g. "vote.proposal-id".
 var (
-	ActionSubmitProposal   = []byte("submit-proposal")
-	ActionDeposit          = []byte("deposit")
-	ActionVote             = []byte("vote")
-	ActionProposalDropped  = []byte("proposal-dropped")
-	ActionProposalPassed   = []byte("proposal-passed")
-	ActionProposalRejected = []byte("proposal-rejected")
+	EventTypeSubmitProposal    = "submit-proposal"
+	EventTypeDeposit           = "deposit"
+	EventTypeVote              = "vote"
+	EventTypeVotingPeriodStart = "voting-period-start"
+	EventTypeInactiveProposal  = "inactive-proposal"
+	EventTypeActiveProposal    = "active-proposal"
+	EventTypePenalizeNonVoter  = "penalize-non-voter"
+
+	ProposalID     = "proposal-id"
+	ProposalResult = "proposal-result"
+	Proposer       = "proposer"
+	Depositer      = "depositer"
+	Voter          = "voter"
+	Option         = "option"
+	Validator      = "validator"
 
-	Action            = sdk.TagAction
-	Proposer          = "proposer"
-	ProposalID        = "proposal-id"
-	VotingPeriodStart = "voting-period-start"
-	Depositer         = "depositer"
-	Voter             = "voter"
+	ProposalResultDropped  = "proposal-dropped"
+	ProposalResultPassed   = "proposal-passed"
+	ProposalResultRejected = "proposal-rejected"
 )
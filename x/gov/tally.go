@@ -1,7 +1,12 @@
 package gov
 
 import (
+	"bytes"
+	"fmt"
+	"sort"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov/tags"
 )
 
 // validatorGovInfo used for tallying
@@ -13,7 +18,10 @@ type validatorGovInfo struct {
 	Vote            VoteOption     // Vote of the validator
 }
 
-func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, tallyResults TallyResult) {
+// tally counts the votes of a proposal, weighted by voting power. It also
+// returns the bonded validators which did not vote themselves, sorted by
+// operator address.
+func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, tallyResults TallyResult, nonVoters []sdk.ValAddress) {
 	results := make(map[VoteOption]sdk.Dec)
 	results[OptionYes] = sdk.ZeroDec()
 	results[OptionAbstain] = sdk.ZeroDec()
@@ -21,9 +29,11 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, tall
 	results[OptionNoWithVeto] = sdk.ZeroDec()
 
 	totalVotingPower := sdk.ZeroDec()
+	totalBondedPower := sdk.ZeroDec()
 	currValidators := make(map[string]validatorGovInfo)
 
 	keeper.vs.IterateValidatorsBonded(ctx, func(index int64, validator sdk.Validator) (stop bool) {
+		totalBondedPower = totalBondedPower.Add(validator.GetPower())
 		currValidators[validator.GetOperator().String()] = validatorGovInfo{
 			Address:         validator.GetOperator(),
 			Power:           validator.GetPower(),
@@ -73,6 +83,7 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, tall
 	// iterate over the validators again to tally their voting power
 	for _, val := range currValidators {
 		if val.Vote == OptionEmpty {
+			nonVoters = append(nonVoters, val.Address)
 			continue
 		}
 
@@ -86,26 +97,67 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, tall
 
 	tallyingProcedure := keeper.GetTallyingProcedure(ctx)
 
+	// map iteration order is random, sort to penalize deterministically
+	sort.Slice(nonVoters, func(i, j int) bool {
+		return bytes.Compare(nonVoters[i], nonVoters[j]) < 0
+	})
+
+	turnout := sdk.ZeroDec()
+	if !totalBondedPower.IsZero() {
+		turnout = totalVotingPower.Quo(totalBondedPower)
+	}
+
 	tallyResults = TallyResult{
 		Yes:        results[OptionYes],
 		Abstain:    results[OptionAbstain],
 		No:         results[OptionNo],
 		NoWithVeto: results[OptionNoWithVeto],
+		Turnout:    turnout,
 	}
 
+	// If there is not enough quorum of votes, proposal fails
+	if turnout.LT(tallyingProcedure.Quorum) {
+		return false, tallyResults, nonVoters
+	}
 	// If no one votes, proposal fails
 	if totalVotingPower.Sub(results[OptionAbstain]).Equal(sdk.ZeroDec()) {
-		return false, tallyResults
+		return false, tallyResults, nonVoters
 	}
 	// If more than 1/3 of voters veto, proposal fails
 	if results[OptionNoWithVeto].Quo(totalVotingPower).GT(tallyingProcedure.Veto) {
-		return false, tallyResults
+		return false, tallyResults, nonVoters
 	}
 	// If more than 1/2 of non-abstaining voters vote Yes, proposal passes
 	if results[OptionYes].Quo(totalVotingPower.Sub(results[OptionAbstain])).GT(tallyingProcedure.Threshold) {
-		return true, tallyResults
+		return true, tallyResults, nonVoters
 	}
 	// If more than 1/2 of non-abstaining voters vote No, proposal fails
 
-	return false, tallyResults
+	return false, tallyResults, nonVoters
+}
+
+// penalizeNonVoters slashes the bonded validators which did not vote on a
+// proposal by the GovernancePenalty, emitting an event for each of them
+func penalizeNonVoters(ctx sdk.Context, keeper Keeper, proposal Proposal, nonVoters []sdk.ValAddress) {
+	penalty := keeper.GetTallyingProcedure(ctx).GovernancePenalty
+	if penalty.IsZero() {
+		return
+	}
+
+	logger := ctx.Logger().With("module", "x/gov")
+	for _, valAddr := range nonVoters {
+		validator := keeper.vs.Validator(ctx, valAddr)
+		if validator == nil || validator.GetStatus() != sdk.Bonded {
+			continue
+		}
+
+		keeper.vs.Slash(ctx, validator.GetConsAddr(), ctx.BlockHeight(), validator.GetPower().RoundInt64(), penalty)
+		ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypePenalizeNonVoter,
+			sdk.NewAttribute(tags.ProposalID, fmt.Sprintf("%d", proposal.GetProposalID())),
+			sdk.NewAttribute(tags.Validator, valAddr.String()),
+		))
+
+		logger.Info(fmt.Sprintf("validator %s did not vote on proposal %d; slashed by %v",
+			valAddr, proposal.GetProposalID(), penalty))
+	}
 }
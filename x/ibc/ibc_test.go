@@ -15,14 +15,22 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 // AccountKeeper(/Keeper) and IBCMapper should use different StoreKey later
 
-func defaultContext(key sdk.StoreKey) sdk.Context {
+func defaultContext(keys ...sdk.StoreKey) sdk.Context {
 	db := dbm.NewMemDB()
 	cms := store.NewCommitMultiStore(db)
-	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	for _, key := range keys {
+		switch key.(type) {
+		case *sdk.TransientStoreKey:
+			cms.MountStoreWithDB(key, sdk.StoreTypeTransient, db)
+		default:
+			cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+		}
+	}
 	cms.LoadLatestVersion()
 	ctx := sdk.NewContext(cms, abci.Header{}, false, log.NewNopLogger())
 	return ctx
@@ -34,7 +42,7 @@ func newAddress() sdk.AccAddress {
 
 func getCoins(ck bank.Keeper, ctx sdk.Context, addr sdk.AccAddress) (sdk.Coins, sdk.Error) {
 	zero := sdk.Coins(nil)
-	coins, _, err := ck.AddCoins(ctx, addr, zero)
+	coins, err := ck.AddCoins(ctx, addr, zero)
 	return coins, err
 }
 
@@ -62,9 +70,12 @@ func TestIBC(t *testing.T) {
 	cdc := makeCodec()
 
 	key := sdk.NewKVStoreKey("ibc")
-	ctx := defaultContext(key)
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
+	ctx := defaultContext(key, keyParams, tkeyParams)
 
-	am := auth.NewAccountKeeper(cdc, key, auth.ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	am := auth.NewAccountKeeper(cdc, key, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
 	ck := bank.NewBaseKeeper(am)
 
 	src := newAddress()
@@ -73,7 +84,7 @@ func TestIBC(t *testing.T) {
 	zero := sdk.Coins(nil)
 	mycoins := sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}
 
-	coins, _, err := ck.AddCoins(ctx, src, mycoins)
+	coins, err := ck.AddCoins(ctx, src, mycoins)
 	require.Nil(t, err)
 	require.Equal(t, mycoins, coins)
 
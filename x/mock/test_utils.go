@@ -74,8 +74,9 @@ func SignCheckDeliver(
 	seq []int64, expSimPass, expPass bool, priv ...crypto.PrivKey,
 ) sdk.Result {
 	tx := GenTx(msgs, accNums, seq, priv...)
-	// Must simulate now as CheckTx doesn't run Msgs anymore
-	res := app.Simulate(tx)
+	// Must simulate now as CheckTx doesn't run Msgs anymore. The tx is
+	// delivered without its bytes below, so it is simulated without them too.
+	res := app.Simulate(nil, tx)
 
 	if expSimPass {
 		require.Equal(t, sdk.ABCICodeOK, res.Code, res.Log)
@@ -215,3 +215,54 @@ func TestSubspace(t *testing.T) {
 		require.Equal(t, kv.param, indirect(kv.ptr), "stored param not equal, tc #%d", i)
 	}
 }
+
+func TestSubspaceUpdate(t *testing.T) {
+	cdc := createTestCodec()
+	key := sdk.NewKVStoreKey("test")
+	tkey := sdk.NewTransientStoreKey("transient_test")
+	ctx := defaultContext(key, tkey)
+	keeper := NewKeeper(cdc, key, tkey)
+
+	table := NewTypeTable(
+		[]byte("int64"), int64(0),
+		[]byte("dec"), sdk.Dec{},
+		[]byte("struct"), s{},
+	)
+	space := keeper.Subspace("test").WithTypeTable(table)
+
+	cases := []struct {
+		key   string
+		value string
+		valid bool
+	}{
+		{"int64", `"ten"`, false},
+		{"int64", `"10"`, true},
+		{"dec", `true`, false},
+		{"dec", `"0.250000000000000000"`, true},
+		{"struct", `[]`, false},
+		{"struct", `{"I":"5"}`, true},
+		{"unregistered", `"10"`, false},
+	}
+
+	for i, tc := range cases {
+		err := space.CheckRaw([]byte(tc.key), []byte(tc.value))
+		require.Equal(t, tc.valid, err == nil, "unexpected CheckRaw result, tc #%d", i)
+		require.False(t, space.Modified(ctx, []byte(tc.key)), "CheckRaw modified the store, tc #%d", i)
+
+		err = space.Update(ctx, []byte(tc.key), []byte(tc.value))
+		require.Equal(t, tc.valid, err == nil, "unexpected Update result, tc #%d", i)
+		require.Equal(t, tc.valid, space.Modified(ctx, []byte(tc.key)), "unexpected Modified result, tc #%d", i)
+	}
+
+	var i int64
+	space.Get(ctx, []byte("int64"), &i)
+	require.Equal(t, int64(10), i)
+
+	var d sdk.Dec
+	space.Get(ctx, []byte("dec"), &d)
+	require.True(t, sdk.NewDecWithPrec(25, 2).Equal(d))
+
+	var st s
+	space.Get(ctx, []byte("struct"), &st)
+	require.Equal(t, s{5}, st)
+}
@@ -1,6 +1,7 @@
 package subspace
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -142,6 +143,39 @@ func (s Subspace) Set(ctx sdk.Context, key []byte, param interface{}) {
 
 }
 
+// CheckRaw decodes a JSON encoded parameter value and checks it against
+// the type registered for the key, without touching the store
+func (s Subspace) CheckRaw(key []byte, value []byte) error {
+	_, err := s.decodeRaw(key, value)
+	return err
+}
+
+// Update sets a JSON encoded parameter value after checking it against
+// the type registered for the key. Unlike Set, it returns an error
+// instead of panicking, as the input comes from outside of the app.
+func (s Subspace) Update(ctx sdk.Context, key []byte, value []byte) error {
+	param, err := s.decodeRaw(key, value)
+	if err != nil {
+		return err
+	}
+	s.Set(ctx, key, param)
+	return nil
+}
+
+func (s Subspace) decodeRaw(key []byte, value []byte) (interface{}, error) {
+	ty, ok := s.table.m[string(key)]
+	if !ok {
+		return nil, fmt.Errorf("parameter %s not registered in subspace %s", key, s.name)
+	}
+
+	ptr := reflect.New(ty)
+	err := s.cdc.UnmarshalJSON(value, ptr.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for parameter %s: %v", key, err)
+	}
+	return ptr.Interface(), nil
+}
+
 // Get to ParamSet
 func (s Subspace) GetParamSet(ctx sdk.Context, ps ParamSet) {
 	for _, pair := range ps.KeyValuePairs() {
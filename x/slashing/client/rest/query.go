@@ -16,6 +16,11 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Co
 		"/slashing/signing_info/{validator}",
 		signingInfoHandlerFn(cliCtx, "slashing", cdc),
 	).Methods("GET")
+
+	r.HandleFunc(
+		"/slashing/signing_infos",
+		signingInfosHandlerFn(cliCtx, cdc),
+	).Methods("GET")
 }
 
 // http request handler to query signing info
@@ -56,3 +61,28 @@ func signingInfoHandlerFn(cliCtx context.CLIContext, storeName string, cdc *code
 		w.Write(output)
 	}
 }
+
+// http request handler to query the signing infos of all validators
+func signingInfosHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, ok := utils.ParsePageRequestOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+
+		params := slashing.QuerySigningInfosParams{Page: page}
+		bz, err := cdc.MarshalJSON(params)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData("custom/slashing/"+slashing.QuerySigningInfos, bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
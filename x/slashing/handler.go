@@ -2,6 +2,7 @@ package slashing
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/slashing/tags"
 )
 
 func NewHandler(k Keeper) sdk.Handler {
@@ -49,9 +50,9 @@ func handleMsgUnjail(ctx sdk.Context, msg MsgUnjail, k Keeper) sdk.Result {
 	// unjail the validator
 	k.validatorSet.Unjail(ctx, consAddr)
 
-	tags := sdk.NewTags("action", []byte("unjail"), "validator", []byte(msg.ValidatorAddr.String()))
+	ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeUnjail,
+		sdk.NewAttribute(tags.Validator, msg.ValidatorAddr.String()),
+	))
 
-	return sdk.Result{
-		Tags: tags,
-	}
+	return sdk.Result{}
 }
@@ -0,0 +1,66 @@
+package slashing
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// query endpoints supported by the slashing Querier
+const (
+	QuerySigningInfos = "signingInfos"
+)
+
+// NewQuerier creates a querier for slashing REST endpoints
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QuerySigningInfos:
+			return querySigningInfos(ctx, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown slashing query endpoint")
+		}
+	}
+}
+
+// Params for query 'custom/slashing/signingInfos'
+type QuerySigningInfosParams struct {
+	Page sdk.PageRequest
+}
+
+// Signing info of a validator along with its consensus address
+type SigningInfo struct {
+	Address              sdk.ConsAddress      `json:"address"`
+	ValidatorSigningInfo ValidatorSigningInfo `json:"signing_info"`
+}
+
+func querySigningInfos(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QuerySigningInfosParams
+	err2 := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err2.Error()))
+	}
+	params.Page, err = params.Page.QuerierPage()
+	if err != nil {
+		return nil, err
+	}
+
+	var signingInfos []SigningInfo
+	i := 0
+	k.iterateValidatorSigningInfos(ctx, func(address sdk.ConsAddress, info ValidatorSigningInfo) (stop bool) {
+		if params.Page.Done(i) {
+			return true
+		}
+		if params.Page.Contains(i) {
+			signingInfos = append(signingInfos, SigningInfo{address, info})
+		}
+		i++
+		return false
+	})
+
+	bz, err2 := codec.MarshalJSONIndent(k.cdc, signingInfos)
+	if err2 != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+	}
+	return bz, nil
+}
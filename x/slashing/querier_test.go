@@ -0,0 +1,53 @@
+package slashing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestQuerySigningInfos(t *testing.T) {
+	ctx, _, _, _, keeper := createTestInput(t, DefaultParams())
+	querier := NewQuerier(keeper)
+
+	for i := 0; i < 3; i++ {
+		info := NewValidatorSigningInfo(int64(i), 0, time.Unix(0, 0), 0)
+		keeper.setValidatorSigningInfo(ctx, sdk.ConsAddress(addrs[i]), info)
+	}
+
+	_, err := querier(ctx, []string{"other"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	querySigningInfos := func(page sdk.PageRequest) []SigningInfo {
+		bz, err := keeper.cdc.MarshalJSON(QuerySigningInfosParams{Page: page})
+		require.Nil(t, err)
+		res, sdkErr := querier(ctx, []string{QuerySigningInfos}, abci.RequestQuery{Data: bz})
+		require.Nil(t, sdkErr)
+		var signingInfos []SigningInfo
+		require.Nil(t, keeper.cdc.UnmarshalJSON(res, &signingInfos))
+		return signingInfos
+	}
+
+	require.Equal(t, 3, len(querySigningInfos(sdk.PageRequest{})))
+	require.Equal(t, 2, len(querySigningInfos(sdk.NewPageRequest(1, 2))))
+	signingInfos := querySigningInfos(sdk.NewPageRequest(2, 2))
+	require.Equal(t, 1, len(signingInfos))
+	info, found := keeper.getValidatorSigningInfo(ctx, signingInfos[0].Address)
+	require.True(t, found)
+	require.Equal(t, info.StartHeight, signingInfos[0].ValidatorSigningInfo.StartHeight)
+	require.Equal(t, 0, len(querySigningInfos(sdk.NewPageRequest(3, 2))))
+
+	bz, err2 := keeper.cdc.MarshalJSON(QuerySigningInfosParams{Page: sdk.NewPageRequest(0, 2)})
+	require.Nil(t, err2)
+	_, err = querier(ctx, []string{QuerySigningInfos}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, err)
+
+	bz, err2 = keeper.cdc.MarshalJSON(QuerySigningInfosParams{Page: sdk.NewPageRequest(1, sdk.MaxPageLimit+1)})
+	require.Nil(t, err2)
+	_, err = querier(ctx, []string{QuerySigningInfos}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, err)
+}
@@ -27,6 +27,22 @@ func (k Keeper) setValidatorSigningInfo(ctx sdk.Context, address sdk.ConsAddress
 	store.Set(GetValidatorSigningInfoKey(address), bz)
 }
 
+// Iterate over the signing infos of all validators, stored by *validator*
+// address (not operator address)
+func (k Keeper) iterateValidatorSigningInfos(ctx sdk.Context, handler func(address sdk.ConsAddress, info ValidatorSigningInfo) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, ValidatorSigningInfoKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		address := sdk.ConsAddress(iter.Key()[len(ValidatorSigningInfoKey):])
+		var info ValidatorSigningInfo
+		k.cdc.MustUnmarshalBinary(iter.Value(), &info)
+		if handler(address, info) {
+			break
+		}
+	}
+}
+
 // Stored by *validator* address (not operator address)
 func (k Keeper) getValidatorMissedBlockBitArray(ctx sdk.Context, address sdk.ConsAddress, index int64) (missed bool) {
 	store := ctx.KVStore(k.storeKey)
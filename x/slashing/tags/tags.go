@@ -0,0 +1,10 @@
+// nolint
+package tags
+
+// Types and attribute keys of the events emitted by the slashing module. They
+// are indexed as "<type>.<key>" tags, e.g. "unjail.validator".
+var (
+	EventTypeUnjail = "unjail"
+
+	Validator = "validator"
+)
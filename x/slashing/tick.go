@@ -1,7 +1,6 @@
 package slashing
 
 import (
-	"encoding/binary"
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -10,12 +9,7 @@ import (
 )
 
 // slashing begin block functionality
-func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, sk Keeper) (tags sdk.Tags) {
-
-	// Tag the height
-	heightBytes := make([]byte, 8)
-	binary.LittleEndian.PutUint64(heightBytes, uint64(req.Header.Height))
-	tags = sdk.NewTags("height", heightBytes)
+func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, sk Keeper) {
 
 	// Iterate over all the validators  which *should* have signed this block
 	// store whether or not they have actually signed it and slash/unbond any
@@ -35,6 +29,4 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, sk Keeper) (tags
 			ctx.Logger().With("module", "x/slashing").Error(fmt.Sprintf("ignored unknown evidence type: %s", evidence.Type))
 		}
 	}
-
-	return
 }
@@ -7,7 +7,6 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/mock"
-	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -21,11 +20,9 @@ func getMockApp(t *testing.T) (*mock.App, Keeper) {
 	keyStake := sdk.NewKVStoreKey("stake")
 
 	tkeyStake := sdk.NewTransientStoreKey("transient_stake")
-	keyParams := sdk.NewKVStoreKey("params")
-	tkeyParams := sdk.NewTransientStoreKey("transient_params")
 
 	bankKeeper := bank.NewBaseKeeper(mApp.AccountKeeper)
-	pk := params.NewKeeper(mApp.Cdc, keyParams, tkeyParams)
+	pk := mApp.ParamsKeeper
 
 	keeper := NewKeeper(mApp.Cdc, keyStake, tkeyStake, bankKeeper, pk.Subspace(DefaultParamspace), mApp.RegisterCodespace(DefaultCodespace))
 
@@ -33,7 +30,7 @@ func getMockApp(t *testing.T) (*mock.App, Keeper) {
 	mApp.SetEndBlocker(getEndBlocker(keeper))
 	mApp.SetInitChainer(getInitChainer(mApp, keeper))
 
-	require.NoError(t, mApp.CompleteSetup(keyStake, tkeyStake, keyParams, tkeyParams))
+	require.NoError(t, mApp.CompleteSetup(keyStake, tkeyStake))
 	return mApp, keeper
 }
 
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/viper"
 	"github.com/tendermint/tendermint/libs/cli"
 
+	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -70,20 +71,25 @@ func GetCmdQueryValidators(storeName string, cdc *codec.Codec) *cobra.Command {
 		Use:   "validators",
 		Short: "Query for all validators",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			key := stake.ValidatorsKey
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			resKVs, err := cliCtx.QuerySubspace(key, storeName)
+			params := stake.QueryValidatorsParams{
+				Page: client.ReadPageRequest(),
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryValidators, bz)
 			if err != nil {
 				return err
 			}
 
-			// parse out the validators
 			var validators []stake.Validator
-			for _, kv := range resKVs {
-				addr := kv.Key[1:]
-				validator := types.MustUnmarshalValidator(cdc, addr, kv.Value)
-				validators = append(validators, validator)
+			err = cdc.UnmarshalJSON(res, &validators)
+			if err != nil {
+				return err
 			}
 
 			switch viper.Get(cli.OutputFlag) {
@@ -111,6 +117,8 @@ func GetCmdQueryValidators(storeName string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 
+	client.PageCommands(cmd)
+
 	return cmd
 }
 
@@ -185,22 +193,28 @@ func GetCmdQueryDelegations(storeName string, cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			key := stake.GetDelegationsKey(delegatorAddr)
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			resKVs, err := cliCtx.QuerySubspace(key, storeName)
+			params := stake.QueryDelegatorParams{
+				DelegatorAddr: delegatorAddr,
+				Page:          client.ReadPageRequest(),
+			}
+			bz, err := cdc.MarshalJSON(params)
 			if err != nil {
 				return err
 			}
 
-			// parse out the validators
-			var delegations []stake.Delegation
-			for _, kv := range resKVs {
-				delegation := types.MustUnmarshalDelegation(cdc, kv.Key, kv.Value)
-				delegations = append(delegations, delegation)
+			res, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryDelegator, bz)
+			if err != nil {
+				return err
 			}
 
-			output, err := codec.MarshalJSONIndent(cdc, delegations)
+			var summary stake.DelegationSummary
+			err = cdc.UnmarshalJSON(res, &summary)
+			if err != nil {
+				return err
+			}
+			output, err := codec.MarshalJSONIndent(cdc, summary.Delegations)
 			if err != nil {
 				return err
 			}
@@ -212,6 +226,8 @@ func GetCmdQueryDelegations(storeName string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 
+	client.PageCommands(cmd)
+
 	return cmd
 }
 
@@ -284,22 +300,28 @@ func GetCmdQueryUnbondingDelegations(storeName string, cdc *codec.Codec) *cobra.
 				return err
 			}
 
-			key := stake.GetUBDsKey(delegatorAddr)
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			resKVs, err := cliCtx.QuerySubspace(key, storeName)
+			params := stake.QueryDelegatorParams{
+				DelegatorAddr: delegatorAddr,
+				Page:          client.ReadPageRequest(),
+			}
+			bz, err := cdc.MarshalJSON(params)
 			if err != nil {
 				return err
 			}
 
-			// parse out the validators
-			var ubds []stake.UnbondingDelegation
-			for _, kv := range resKVs {
-				ubd := types.MustUnmarshalUBD(cdc, kv.Key, kv.Value)
-				ubds = append(ubds, ubd)
+			res, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryDelegator, bz)
+			if err != nil {
+				return err
 			}
 
-			output, err := codec.MarshalJSONIndent(cdc, ubds)
+			var summary stake.DelegationSummary
+			err = cdc.UnmarshalJSON(res, &summary)
+			if err != nil {
+				return err
+			}
+			output, err := codec.MarshalJSONIndent(cdc, summary.UnbondingDelegations)
 			if err != nil {
 				return err
 			}
@@ -311,6 +333,8 @@ func GetCmdQueryUnbondingDelegations(storeName string, cdc *codec.Codec) *cobra.
 		},
 	}
 
+	client.PageCommands(cmd)
+
 	return cmd
 }
 
@@ -388,22 +412,28 @@ func GetCmdQueryRedelegations(storeName string, cdc *codec.Codec) *cobra.Command
 				return err
 			}
 
-			key := stake.GetREDsKey(delegatorAddr)
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			resKVs, err := cliCtx.QuerySubspace(key, storeName)
+			params := stake.QueryDelegatorParams{
+				DelegatorAddr: delegatorAddr,
+				Page:          client.ReadPageRequest(),
+			}
+			bz, err := cdc.MarshalJSON(params)
 			if err != nil {
 				return err
 			}
 
-			// parse out the validators
-			var reds []stake.Redelegation
-			for _, kv := range resKVs {
-				red := types.MustUnmarshalRED(cdc, kv.Key, kv.Value)
-				reds = append(reds, red)
+			res, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryDelegator, bz)
+			if err != nil {
+				return err
 			}
 
-			output, err := codec.MarshalJSONIndent(cdc, reds)
+			var summary stake.DelegationSummary
+			err = cdc.UnmarshalJSON(res, &summary)
+			if err != nil {
+				return err
+			}
+			output, err := codec.MarshalJSONIndent(cdc, summary.Redelegations)
 			if err != nil {
 				return err
 			}
@@ -415,6 +445,8 @@ func GetCmdQueryRedelegations(storeName string, cdc *codec.Codec) *cobra.Command
 		},
 	}
 
+	client.PageCommands(cmd)
+
 	return cmd
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/utils"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
 	"github.com/cosmos/cosmos-sdk/x/stake/tags"
 
 	"github.com/gorilla/mux"
@@ -129,30 +130,28 @@ func delegatorTxsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.Han
 		isUnbondTx := contains(typesQuerySlice, "unbond")
 		isRedTx := contains(typesQuerySlice, "redelegate")
 		var txs = []tx.Info{}
-		var actions []string
+		var eventTypes []string
 
+		// unbondings and redelegations complete in the EndBlocker, so their
+		// complete events are on the block results, not on any tx
 		switch {
 		case isBondTx:
-			actions = append(actions, string(tags.ActionDelegate))
+			eventTypes = append(eventTypes, tags.EventTypeDelegate)
 		case isUnbondTx:
-			actions = append(actions, string(tags.ActionBeginUnbonding))
-			actions = append(actions, string(tags.ActionCompleteUnbonding))
+			eventTypes = append(eventTypes, tags.EventTypeBeginUnbonding)
 		case isRedTx:
-			actions = append(actions, string(tags.ActionBeginRedelegation))
-			actions = append(actions, string(tags.ActionCompleteRedelegation))
+			eventTypes = append(eventTypes, tags.EventTypeBeginRedelegation)
 		case noQuery:
-			actions = append(actions, string(tags.ActionDelegate))
-			actions = append(actions, string(tags.ActionBeginUnbonding))
-			actions = append(actions, string(tags.ActionCompleteUnbonding))
-			actions = append(actions, string(tags.ActionBeginRedelegation))
-			actions = append(actions, string(tags.ActionCompleteRedelegation))
+			eventTypes = append(eventTypes, tags.EventTypeDelegate)
+			eventTypes = append(eventTypes, tags.EventTypeBeginUnbonding)
+			eventTypes = append(eventTypes, tags.EventTypeBeginRedelegation)
 		default:
 			w.WriteHeader(http.StatusNoContent)
 			return
 		}
 
-		for _, action := range actions {
-			foundTxs, errQuery := queryTxs(node, cliCtx, cdc, action, delegatorAddr)
+		for _, eventType := range eventTypes {
+			foundTxs, errQuery := queryTxs(node, cliCtx, cdc, eventType, delegatorAddr)
 			if errQuery != nil {
 				utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			}
@@ -191,7 +190,19 @@ func delegatorValidatorHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) ht
 // HTTP request handler to query list of validators
 func validatorsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		res, err := cliCtx.QueryWithData("custom/stake/validators", nil)
+		page, ok := utils.ParsePageRequestOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+
+		params := stake.QueryValidatorsParams{Page: page}
+		bz, err := cdc.MarshalJSON(params)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData("custom/stake/validators", bz)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
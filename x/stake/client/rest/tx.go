@@ -207,6 +207,7 @@ func delegationsRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx conte
 			Gas:           gas,
 			GasAdjustment: adjustment,
 			SimulateGas:   simulateGas,
+			GasPrices:     baseReq.GasPrices,
 			ChainID:       baseReq.ChainID,
 		}
 
@@ -26,12 +26,13 @@ func contains(stringSlice []string, txType string) bool {
 	return false
 }
 
-// queries staking txs
-func queryTxs(node rpcclient.Client, cliCtx context.CLIContext, cdc *codec.Codec, tag string, delegatorAddr string) ([]tx.Info, error) {
+// queries the staking txs of a delegator which emitted an event of the given
+// type
+func queryTxs(node rpcclient.Client, cliCtx context.CLIContext, cdc *codec.Codec, eventType string, delegatorAddr string) ([]tx.Info, error) {
 	page := 0
 	perPage := 100
 	prove := !cliCtx.TrustNode
-	query := fmt.Sprintf("%s='%s' AND %s='%s'", tags.Action, tag, tags.Delegator, delegatorAddr)
+	query := fmt.Sprintf("%s.%s='%s'", eventType, tags.Delegator, delegatorAddr)
 	res, err := node.TxSearch(query, prove, page, perPage)
 	if err != nil {
 		return nil, err
@@ -93,8 +94,14 @@ func queryDelegator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string
 			return
 		}
 
+		page, ok := utils.ParsePageRequestOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+
 		params := stake.QueryDelegatorParams{
 			DelegatorAddr: delegatorAddr,
+			Page:          page,
 		}
 
 		bz, err := cdc.MarshalJSON(params)
@@ -123,8 +130,14 @@ func queryValidator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string
 			return
 		}
 
+		page, ok := utils.ParsePageRequestOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+
 		params := stake.QueryValidatorParams{
 			ValidatorAddr: validatorAddr,
+			Page:          page,
 		}
 
 		bz, err := cdc.MarshalJSON(params)
@@ -2,6 +2,7 @@ package stake
 
 import (
 	"bytes"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/stake/keeper"
@@ -32,8 +33,6 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 
 // Called every block, update validator set
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) (ValidatorUpdates []abci.ValidatorUpdate) {
-	endBlockerTags := sdk.EmptyTags()
-
 	k.UnbondAllMatureValidatorQueue(ctx)
 
 	matureUnbonds := k.DequeueAllMatureUnbondingQueue(ctx, ctx.BlockHeader().Time)
@@ -42,10 +41,9 @@ func EndBlocker(ctx sdk.Context, k keeper.Keeper) (ValidatorUpdates []abci.Valid
 		if err != nil {
 			continue
 		}
-		endBlockerTags.AppendTags(sdk.NewTags(
-			tags.Action, ActionCompleteUnbonding,
-			tags.Delegator, []byte(dvPair.DelegatorAddr.String()),
-			tags.SrcValidator, []byte(dvPair.ValidatorAddr.String()),
+		ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeCompleteUnbonding,
+			sdk.NewAttribute(tags.Delegator, dvPair.DelegatorAddr.String()),
+			sdk.NewAttribute(tags.SrcValidator, dvPair.ValidatorAddr.String()),
 		))
 	}
 
@@ -55,11 +53,10 @@ func EndBlocker(ctx sdk.Context, k keeper.Keeper) (ValidatorUpdates []abci.Valid
 		if err != nil {
 			continue
 		}
-		endBlockerTags.AppendTags(sdk.NewTags(
-			tags.Action, tags.ActionCompleteRedelegation,
-			tags.Delegator, []byte(dvvTriplet.DelegatorAddr.String()),
-			tags.SrcValidator, []byte(dvvTriplet.ValidatorSrcAddr.String()),
-			tags.DstValidator, []byte(dvvTriplet.ValidatorDstAddr.String()),
+		ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeCompleteRedelegation,
+			sdk.NewAttribute(tags.Delegator, dvvTriplet.DelegatorAddr.String()),
+			sdk.NewAttribute(tags.SrcValidator, dvvTriplet.ValidatorSrcAddr.String()),
+			sdk.NewAttribute(tags.DstValidator, dvvTriplet.ValidatorDstAddr.String()),
 		))
 	}
 
@@ -117,16 +114,13 @@ func handleMsgCreateValidator(ctx sdk.Context, msg types.MsgCreateValidator, k k
 	accAddr := sdk.AccAddress(validator.OperatorAddr)
 	k.OnDelegationCreated(ctx, accAddr, validator.OperatorAddr)
 
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionCreateValidator,
-		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
-		tags.Moniker, []byte(msg.Description.Moniker),
-		tags.Identity, []byte(msg.Description.Identity),
-	)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeCreateValidator,
+		sdk.NewAttribute(tags.DstValidator, msg.ValidatorAddr.String()),
+		sdk.NewAttribute(tags.Moniker, msg.Description.Moniker),
+		sdk.NewAttribute(tags.Identity, msg.Description.Identity),
+	))
 
-	return sdk.Result{
-		Tags: tags,
-	}
+	return sdk.Result{}
 }
 
 func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keeper.Keeper) sdk.Result {
@@ -154,16 +148,13 @@ func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keepe
 
 	k.SetValidator(ctx, validator)
 
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionEditValidator,
-		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
-		tags.Moniker, []byte(description.Moniker),
-		tags.Identity, []byte(description.Identity),
-	)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeEditValidator,
+		sdk.NewAttribute(tags.DstValidator, msg.ValidatorAddr.String()),
+		sdk.NewAttribute(tags.Moniker, description.Moniker),
+		sdk.NewAttribute(tags.Identity, description.Identity),
+	))
 
-	return sdk.Result{
-		Tags: tags,
-	}
+	return sdk.Result{}
 }
 
 func handleMsgDelegate(ctx sdk.Context, msg types.MsgDelegate, k keeper.Keeper) sdk.Result {
@@ -188,15 +179,12 @@ func handleMsgDelegate(ctx sdk.Context, msg types.MsgDelegate, k keeper.Keeper)
 	// call the hook if present
 	k.OnDelegationCreated(ctx, msg.DelegatorAddr, validator.OperatorAddr)
 
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionDelegate,
-		tags.Delegator, []byte(msg.DelegatorAddr.String()),
-		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
-	)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeDelegate,
+		sdk.NewAttribute(tags.Delegator, msg.DelegatorAddr.String()),
+		sdk.NewAttribute(tags.DstValidator, msg.ValidatorAddr.String()),
+	))
 
-	return sdk.Result{
-		Tags: tags,
-	}
+	return sdk.Result{}
 }
 
 func handleMsgBeginUnbonding(ctx sdk.Context, msg types.MsgBeginUnbonding, k keeper.Keeper) sdk.Result {
@@ -207,13 +195,12 @@ func handleMsgBeginUnbonding(ctx sdk.Context, msg types.MsgBeginUnbonding, k kee
 
 	finishTime := types.MsgCdc.MustMarshalBinary(ubd.MinTime)
 
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionBeginUnbonding,
-		tags.Delegator, []byte(msg.DelegatorAddr.String()),
-		tags.SrcValidator, []byte(msg.ValidatorAddr.String()),
-		tags.EndTime, finishTime,
-	)
-	return sdk.Result{Data: finishTime, Tags: tags}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeBeginUnbonding,
+		sdk.NewAttribute(tags.Delegator, msg.DelegatorAddr.String()),
+		sdk.NewAttribute(tags.SrcValidator, msg.ValidatorAddr.String()),
+		sdk.NewAttribute(tags.EndTime, ubd.MinTime.Format(time.RFC3339)),
+	))
+	return sdk.Result{Data: finishTime}
 }
 
 func handleMsgBeginRedelegate(ctx sdk.Context, msg types.MsgBeginRedelegate, k keeper.Keeper) sdk.Result {
@@ -225,12 +212,11 @@ func handleMsgBeginRedelegate(ctx sdk.Context, msg types.MsgBeginRedelegate, k k
 
 	finishTime := types.MsgCdc.MustMarshalBinary(red.MinTime)
 
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionBeginRedelegation,
-		tags.Delegator, []byte(msg.DelegatorAddr.String()),
-		tags.SrcValidator, []byte(msg.ValidatorSrcAddr.String()),
-		tags.DstValidator, []byte(msg.ValidatorDstAddr.String()),
-		tags.EndTime, finishTime,
-	)
-	return sdk.Result{Data: finishTime, Tags: tags}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(tags.EventTypeBeginRedelegation,
+		sdk.NewAttribute(tags.Delegator, msg.DelegatorAddr.String()),
+		sdk.NewAttribute(tags.SrcValidator, msg.ValidatorSrcAddr.String()),
+		sdk.NewAttribute(tags.DstValidator, msg.ValidatorDstAddr.String()),
+		sdk.NewAttribute(tags.EndTime, red.MinTime.Format(time.RFC3339)),
+	))
+	return sdk.Result{Data: finishTime}
 }
@@ -26,13 +26,22 @@ func (k Keeper) GetDelegation(ctx sdk.Context,
 
 // return all delegations used during genesis dump
 func (k Keeper) GetAllDelegations(ctx sdk.Context) (delegations []types.Delegation) {
+	return k.GetDelegationsPage(ctx, sdk.PageRequest{})
+}
+
+// return a page of all delegations, the zero page request returns all of
+// them
+func (k Keeper) GetDelegationsPage(ctx sdk.Context, page sdk.PageRequest) (delegations []types.Delegation) {
 	store := ctx.KVStore(k.storeKey)
 	iterator := sdk.KVStorePrefixIterator(store, DelegationKey)
 	defer iterator.Close()
 
-	for ; iterator.Valid(); iterator.Next() {
-		delegation := types.MustUnmarshalDelegation(k.cdc, iterator.Key(), iterator.Value())
-		delegations = append(delegations, delegation)
+	for i := 0; iterator.Valid() && !page.Done(i); iterator.Next() {
+		if page.Contains(i) {
+			delegation := types.MustUnmarshalDelegation(k.cdc, iterator.Key(), iterator.Value())
+			delegations = append(delegations, delegation)
+		}
+		i++
 	}
 	return delegations
 }
@@ -110,15 +119,26 @@ func (k Keeper) GetUnbondingDelegation(ctx sdk.Context,
 
 // return all unbonding delegations from a particular validator
 func (k Keeper) GetUnbondingDelegationsFromValidator(ctx sdk.Context, valAddr sdk.ValAddress) (ubds []types.UnbondingDelegation) {
+	return k.GetUnbondingDelegationsFromValidatorPage(ctx, valAddr, sdk.PageRequest{})
+}
+
+// return a page of the unbonding delegations from a particular validator, the
+// zero page request returns all of them
+func (k Keeper) GetUnbondingDelegationsFromValidatorPage(ctx sdk.Context, valAddr sdk.ValAddress,
+	page sdk.PageRequest) (ubds []types.UnbondingDelegation) {
+
 	store := ctx.KVStore(k.storeKey)
 	iterator := sdk.KVStorePrefixIterator(store, GetUBDsByValIndexKey(valAddr))
 	defer iterator.Close()
 
-	for ; iterator.Valid(); iterator.Next() {
-		key := GetUBDKeyFromValIndexKey(iterator.Key())
-		value := store.Get(key)
-		ubd := types.MustUnmarshalUBD(k.cdc, key, value)
-		ubds = append(ubds, ubd)
+	for i := 0; iterator.Valid() && !page.Done(i); iterator.Next() {
+		if page.Contains(i) {
+			key := GetUBDKeyFromValIndexKey(iterator.Key())
+			value := store.Get(key)
+			ubd := types.MustUnmarshalUBD(k.cdc, key, value)
+			ubds = append(ubds, ubd)
+		}
+		i++
 	}
 	return ubds
 }
@@ -244,15 +264,26 @@ func (k Keeper) GetRedelegation(ctx sdk.Context,
 
 // return all redelegations from a particular validator
 func (k Keeper) GetRedelegationsFromValidator(ctx sdk.Context, valAddr sdk.ValAddress) (reds []types.Redelegation) {
+	return k.GetRedelegationsFromValidatorPage(ctx, valAddr, sdk.PageRequest{})
+}
+
+// return a page of the redelegations from a particular validator, the zero
+// page request returns all of them
+func (k Keeper) GetRedelegationsFromValidatorPage(ctx sdk.Context, valAddr sdk.ValAddress,
+	page sdk.PageRequest) (reds []types.Redelegation) {
+
 	store := ctx.KVStore(k.storeKey)
 	iterator := sdk.KVStorePrefixIterator(store, GetREDsFromValSrcIndexKey(valAddr))
 	defer iterator.Close()
 
-	for ; iterator.Valid(); iterator.Next() {
-		key := GetREDKeyFromValSrcIndexKey(iterator.Key())
-		value := store.Get(key)
-		red := types.MustUnmarshalRED(k.cdc, key, value)
-		reds = append(reds, red)
+	for i := 0; iterator.Valid() && !page.Done(i); iterator.Next() {
+		if page.Contains(i) {
+			key := GetREDKeyFromValSrcIndexKey(iterator.Key())
+			value := store.Get(key)
+			red := types.MustUnmarshalRED(k.cdc, key, value)
+			reds = append(reds, red)
+		}
+		i++
 	}
 	return reds
 }
@@ -361,7 +392,7 @@ func (k Keeper) Delegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt sdk.Co
 
 	if subtractAccount {
 		// Account new shares, save
-		_, _, err = k.bankKeeper.SubtractCoins(ctx, delegation.DelegatorAddr, sdk.Coins{bondAmt})
+		err = k.bankKeeper.DelegateCoins(ctx, delegation.DelegatorAddr, sdk.Coins{bondAmt})
 		if err != nil {
 			return
 		}
@@ -484,7 +515,7 @@ func (k Keeper) BeginUnbonding(ctx sdk.Context,
 
 	// no need to create the ubd object just complete now
 	if completeNow {
-		_, _, err := k.bankKeeper.AddCoins(ctx, delAddr, sdk.Coins{balance})
+		err := k.bankKeeper.UndelegateCoins(ctx, delAddr, sdk.Coins{balance})
 		if err != nil {
 			return types.UnbondingDelegation{}, err
 		}
@@ -514,7 +545,7 @@ func (k Keeper) CompleteUnbonding(ctx sdk.Context, delAddr sdk.AccAddress, valAd
 		return types.ErrNoUnbondingDelegation(k.Codespace())
 	}
 
-	_, _, err := k.bankKeeper.AddCoins(ctx, ubd.DelegatorAddr, sdk.Coins{ubd.Balance})
+	err := k.bankKeeper.UndelegateCoins(ctx, ubd.DelegatorAddr, sdk.Coins{ubd.Balance})
 	if err != nil {
 		return err
 	}
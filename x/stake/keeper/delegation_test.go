@@ -90,6 +90,10 @@ func TestDelegation(t *testing.T) {
 	require.True(t, bond2to1.Equal(allBonds[3]))
 	require.True(t, bond2to2.Equal(allBonds[4]))
 	require.True(t, bond2to3.Equal(allBonds[5]))
+	allBonds = keeper.GetDelegationsPage(ctx, sdk.NewPageRequest(2, 4))
+	require.Equal(t, 2, len(allBonds))
+	require.True(t, bond2to2.Equal(allBonds[0]))
+	require.True(t, bond2to3.Equal(allBonds[1]))
 
 	resVals := keeper.GetDelegatorValidators(ctx, addrDels[0], 3)
 	require.Equal(t, 3, len(resVals))
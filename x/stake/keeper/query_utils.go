@@ -52,15 +52,24 @@ func (k Keeper) GetDelegatorValidator(ctx sdk.Context, delegatorAddr sdk.AccAddr
 func (k Keeper) GetAllDelegatorDelegations(ctx sdk.Context, delegator sdk.AccAddress) (
 	delegations []types.Delegation) {
 
+	return k.GetDelegatorDelegationsPage(ctx, delegator, sdk.PageRequest{})
+}
+
+// return a page of the delegations for a delegator, the zero page request
+// returns all of them
+func (k Keeper) GetDelegatorDelegationsPage(ctx sdk.Context, delegator sdk.AccAddress,
+	page sdk.PageRequest) (delegations []types.Delegation) {
+
 	store := ctx.KVStore(k.storeKey)
 	delegatorPrefixKey := GetDelegationsKey(delegator)
 	iterator := sdk.KVStorePrefixIterator(store, delegatorPrefixKey) //smallest to largest
 	defer iterator.Close()
 
-	i := 0
-	for ; iterator.Valid(); iterator.Next() {
-		delegation := types.MustUnmarshalDelegation(k.cdc, iterator.Key(), iterator.Value())
-		delegations = append(delegations, delegation)
+	for i := 0; iterator.Valid() && !page.Done(i); iterator.Next() {
+		if page.Contains(i) {
+			delegation := types.MustUnmarshalDelegation(k.cdc, iterator.Key(), iterator.Value())
+			delegations = append(delegations, delegation)
+		}
 		i++
 	}
 	return delegations
@@ -70,15 +79,24 @@ func (k Keeper) GetAllDelegatorDelegations(ctx sdk.Context, delegator sdk.AccAdd
 func (k Keeper) GetAllUnbondingDelegations(ctx sdk.Context, delegator sdk.AccAddress) (
 	unbondingDelegations []types.UnbondingDelegation) {
 
+	return k.GetDelegatorUnbondingDelegationsPage(ctx, delegator, sdk.PageRequest{})
+}
+
+// return a page of the unbonding-delegations for a delegator, the zero page
+// request returns all of them
+func (k Keeper) GetDelegatorUnbondingDelegationsPage(ctx sdk.Context, delegator sdk.AccAddress,
+	page sdk.PageRequest) (unbondingDelegations []types.UnbondingDelegation) {
+
 	store := ctx.KVStore(k.storeKey)
 	delegatorPrefixKey := GetUBDsKey(delegator)
 	iterator := sdk.KVStorePrefixIterator(store, delegatorPrefixKey) //smallest to largest
 	defer iterator.Close()
 
-	i := 0
-	for ; iterator.Valid(); iterator.Next() {
-		unbondingDelegation := types.MustUnmarshalUBD(k.cdc, iterator.Key(), iterator.Value())
-		unbondingDelegations = append(unbondingDelegations, unbondingDelegation)
+	for i := 0; iterator.Valid() && !page.Done(i); iterator.Next() {
+		if page.Contains(i) {
+			unbondingDelegation := types.MustUnmarshalUBD(k.cdc, iterator.Key(), iterator.Value())
+			unbondingDelegations = append(unbondingDelegations, unbondingDelegation)
+		}
 		i++
 	}
 	return unbondingDelegations
@@ -86,15 +104,24 @@ func (k Keeper) GetAllUnbondingDelegations(ctx sdk.Context, delegator sdk.AccAdd
 
 // return all redelegations for a delegator
 func (k Keeper) GetAllRedelegations(ctx sdk.Context, delegator sdk.AccAddress) (redelegations []types.Redelegation) {
+	return k.GetDelegatorRedelegationsPage(ctx, delegator, sdk.PageRequest{})
+}
+
+// return a page of the redelegations for a delegator, the zero page request
+// returns all of them
+func (k Keeper) GetDelegatorRedelegationsPage(ctx sdk.Context, delegator sdk.AccAddress,
+	page sdk.PageRequest) (redelegations []types.Redelegation) {
+
 	store := ctx.KVStore(k.storeKey)
 	delegatorPrefixKey := GetREDsKey(delegator)
 	iterator := sdk.KVStorePrefixIterator(store, delegatorPrefixKey) //smallest to largest
 	defer iterator.Close()
 
-	i := 0
-	for ; iterator.Valid(); iterator.Next() {
-		redelegation := types.MustUnmarshalRED(k.cdc, iterator.Key(), iterator.Value())
-		redelegations = append(redelegations, redelegation)
+	for i := 0; iterator.Valid() && !page.Done(i); iterator.Next() {
+		if page.Contains(i) {
+			redelegation := types.MustUnmarshalRED(k.cdc, iterator.Key(), iterator.Value())
+			redelegations = append(redelegations, redelegation)
+		}
 		i++
 	}
 	return redelegations
@@ -204,14 +204,23 @@ func (k Keeper) RemoveValidator(ctx sdk.Context, address sdk.ValAddress) {
 
 // get the set of all validators with no limits, used during genesis dump
 func (k Keeper) GetAllValidators(ctx sdk.Context) (validators []types.Validator) {
+	return k.GetValidatorsPage(ctx, sdk.PageRequest{})
+}
+
+// get a page of the set of all validators, the zero page request returns
+// all of them
+func (k Keeper) GetValidatorsPage(ctx sdk.Context, page sdk.PageRequest) (validators []types.Validator) {
 	store := ctx.KVStore(k.storeKey)
 	iterator := sdk.KVStorePrefixIterator(store, ValidatorsKey)
 	defer iterator.Close()
 
-	for ; iterator.Valid(); iterator.Next() {
-		addr := iterator.Key()[1:]
-		validator := types.MustUnmarshalValidator(k.cdc, addr, iterator.Value())
-		validators = append(validators, validator)
+	for i := 0; iterator.Valid() && !page.Done(i); iterator.Next() {
+		if page.Contains(i) {
+			addr := iterator.Key()[1:]
+			validator := types.MustUnmarshalValidator(k.cdc, addr, iterator.Value())
+			validators = append(validators, validator)
+		}
+		i++
 	}
 	return validators
 }
@@ -28,7 +28,7 @@ func NewQuerier(k keep.Keeper, cdc *codec.Codec) sdk.Querier {
 	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
 		switch path[0] {
 		case QueryValidators:
-			return queryValidators(ctx, cdc, k)
+			return queryValidators(ctx, cdc, req, k)
 		case QueryValidator:
 			return queryValidator(ctx, cdc, req, k)
 		case QueryValidatorUnbondingDelegations:
@@ -55,19 +55,32 @@ func NewQuerier(k keep.Keeper, cdc *codec.Codec) sdk.Querier {
 	}
 }
 
+// defines the params for the following queries:
+// - 'custom/stake/validators'
+type QueryValidatorsParams struct {
+	Page sdk.PageRequest
+}
+
 // defines the params for the following queries:
 // - 'custom/stake/delegator'
 // - 'custom/stake/delegatorValidators'
+//
+// The page only applies to 'custom/stake/delegator', it pages each of the
+// delegations, unbonding-delegations and redelegations of the delegator.
 type QueryDelegatorParams struct {
 	DelegatorAddr sdk.AccAddress
+	Page          sdk.PageRequest
 }
 
 // defines the params for the following queries:
 // - 'custom/stake/validator'
 // - 'custom/stake/validatorUnbondingDelegations'
 // - 'custom/stake/validatorRedelegations'
+//
+// The page doesn't apply to 'custom/stake/validator'.
 type QueryValidatorParams struct {
 	ValidatorAddr sdk.ValAddress
+	Page          sdk.PageRequest
 }
 
 // defines the params for the following queries:
@@ -79,12 +92,29 @@ type QueryBondsParams struct {
 	ValidatorAddr sdk.ValAddress
 }
 
-func queryValidators(ctx sdk.Context, cdc *codec.Codec, k keep.Keeper) (res []byte, err sdk.Error) {
-	stakeParams := k.GetParams(ctx)
-	validators := k.GetValidators(ctx, stakeParams.MaxValidators)
+func queryValidators(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k keep.Keeper) (res []byte, err sdk.Error) {
+	// requests without params ask for the first MaxValidators validators
+	if len(req.Data) == 0 {
+		stakeParams := k.GetParams(ctx)
+		return marshalValidators(cdc, k.GetValidators(ctx, stakeParams.MaxValidators))
+	}
 
-	res, errRes := codec.MarshalJSONIndent(cdc, validators)
+	var params QueryValidatorsParams
+	errRes := cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", errRes.Error()))
+	}
+	params.Page, err = params.Page.QuerierPage()
 	if err != nil {
+		return nil, err
+	}
+
+	return marshalValidators(cdc, k.GetValidatorsPage(ctx, params.Page))
+}
+
+func marshalValidators(cdc *codec.Codec, validators []types.Validator) (res []byte, err sdk.Error) {
+	res, errRes := codec.MarshalJSONIndent(cdc, validators)
+	if errRes != nil {
 		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
 	}
 	return res, nil
@@ -118,7 +148,12 @@ func queryValidatorUnbondingDelegations(ctx sdk.Context, cdc *codec.Codec, req a
 		return []byte{}, sdk.ErrUnknownAddress("")
 	}
 
-	unbonds := k.GetUnbondingDelegationsFromValidator(ctx, params.ValidatorAddr)
+	params.Page, err = params.Page.QuerierPage()
+	if err != nil {
+		return nil, err
+	}
+
+	unbonds := k.GetUnbondingDelegationsFromValidatorPage(ctx, params.ValidatorAddr, params.Page)
 
 	res, errRes = codec.MarshalJSONIndent(cdc, unbonds)
 	if errRes != nil {
@@ -135,7 +170,12 @@ func queryValidatorRedelegations(ctx sdk.Context, cdc *codec.Codec, req abci.Req
 		return []byte{}, sdk.ErrUnknownAddress("")
 	}
 
-	redelegations := k.GetRedelegationsFromValidator(ctx, params.ValidatorAddr)
+	params.Page, err = params.Page.QuerierPage()
+	if err != nil {
+		return nil, err
+	}
+
+	redelegations := k.GetRedelegationsFromValidatorPage(ctx, params.ValidatorAddr, params.Page)
 
 	res, errRes = codec.MarshalJSONIndent(cdc, redelegations)
 	if errRes != nil {
@@ -150,9 +190,14 @@ func queryDelegator(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k
 	if errRes != nil {
 		return []byte{}, sdk.ErrUnknownAddress("")
 	}
-	delegations := k.GetAllDelegatorDelegations(ctx, params.DelegatorAddr)
-	unbondingDelegations := k.GetAllUnbondingDelegations(ctx, params.DelegatorAddr)
-	redelegations := k.GetAllRedelegations(ctx, params.DelegatorAddr)
+	params.Page, err = params.Page.QuerierPage()
+	if err != nil {
+		return nil, err
+	}
+
+	delegations := k.GetDelegatorDelegationsPage(ctx, params.DelegatorAddr, params.Page)
+	unbondingDelegations := k.GetDelegatorUnbondingDelegationsPage(ctx, params.DelegatorAddr, params.Page)
+	redelegations := k.GetDelegatorRedelegationsPage(ctx, params.DelegatorAddr, params.Page)
 
 	summary := types.DelegationSummary{
 		Delegations:          delegations,
@@ -130,7 +130,7 @@ func TestQueryValidators(t *testing.T) {
 	// Query Validators
 	queriedValidators := keeper.GetValidators(ctx, params.MaxValidators)
 
-	res, err := queryValidators(ctx, cdc, keeper)
+	res, err := queryValidators(ctx, cdc, abci.RequestQuery{}, keeper)
 	require.Nil(t, err)
 
 	var validatorsResp []types.Validator
@@ -140,9 +140,22 @@ func TestQueryValidators(t *testing.T) {
 	require.Equal(t, len(queriedValidators), len(validatorsResp))
 	require.ElementsMatch(t, queriedValidators, validatorsResp)
 
+	// Query a page of validators
+	bz, errRes := cdc.MarshalJSON(QueryValidatorsParams{Page: sdk.NewPageRequest(2, 1)})
+	require.Nil(t, errRes)
+
+	res, err = queryValidators(ctx, cdc, abci.RequestQuery{Data: bz}, keeper)
+	require.Nil(t, err)
+
+	validatorsResp = nil
+	errRes = cdc.UnmarshalJSON(res, &validatorsResp)
+	require.Nil(t, errRes)
+	require.Equal(t, 1, len(validatorsResp))
+	require.Equal(t, keeper.GetAllValidators(ctx)[1], validatorsResp[0])
+
 	// Query each validator
 	queryParams := newTestValidatorQuery(addrVal1)
-	bz, errRes := cdc.MarshalJSON(queryParams)
+	bz, errRes = cdc.MarshalJSON(queryParams)
 	require.Nil(t, errRes)
 
 	query := abci.RequestQuery{
@@ -295,6 +308,21 @@ func TestQueryDelegation(t *testing.T) {
 
 	require.Equal(t, unbond, summary.UnbondingDelegations[0])
 
+	// Query the second page of the delegator summary
+	bz, errRes = cdc.MarshalJSON(QueryDelegatorParams{DelegatorAddr: addrAcc2, Page: sdk.NewPageRequest(2, 1)})
+	require.Nil(t, errRes)
+	query.Data = bz
+
+	res, err = queryDelegator(ctx, cdc, query, keeper)
+	require.Nil(t, err)
+
+	summary = types.DelegationSummary{}
+	errRes = cdc.UnmarshalJSON(res, &summary)
+	require.Nil(t, errRes)
+
+	require.Empty(t, summary.Delegations)
+	require.Empty(t, summary.UnbondingDelegations)
+
 	// error unknown request
 	query.Data = bz[:len(bz)-1]
 
@@ -9,25 +9,26 @@ import (
 )
 
 type (
-	Keeper               = keeper.Keeper
-	Validator            = types.Validator
-	Description          = types.Description
-	Commission           = types.Commission
-	Delegation           = types.Delegation
-	DelegationSummary    = types.DelegationSummary
-	UnbondingDelegation  = types.UnbondingDelegation
-	Redelegation         = types.Redelegation
-	Params               = types.Params
-	Pool                 = types.Pool
-	MsgCreateValidator   = types.MsgCreateValidator
-	MsgEditValidator     = types.MsgEditValidator
-	MsgDelegate          = types.MsgDelegate
-	MsgBeginUnbonding    = types.MsgBeginUnbonding
-	MsgBeginRedelegate   = types.MsgBeginRedelegate
-	GenesisState         = types.GenesisState
-	QueryDelegatorParams = querier.QueryDelegatorParams
-	QueryValidatorParams = querier.QueryValidatorParams
-	QueryBondsParams     = querier.QueryBondsParams
+	Keeper                = keeper.Keeper
+	Validator             = types.Validator
+	Description           = types.Description
+	Commission            = types.Commission
+	Delegation            = types.Delegation
+	DelegationSummary     = types.DelegationSummary
+	UnbondingDelegation   = types.UnbondingDelegation
+	Redelegation          = types.Redelegation
+	Params                = types.Params
+	Pool                  = types.Pool
+	MsgCreateValidator    = types.MsgCreateValidator
+	MsgEditValidator      = types.MsgEditValidator
+	MsgDelegate           = types.MsgDelegate
+	MsgBeginUnbonding     = types.MsgBeginUnbonding
+	MsgBeginRedelegate    = types.MsgBeginRedelegate
+	GenesisState          = types.GenesisState
+	QueryDelegatorParams  = querier.QueryDelegatorParams
+	QueryValidatorParams  = querier.QueryValidatorParams
+	QueryValidatorsParams = querier.QueryValidatorsParams
+	QueryBondsParams      = querier.QueryBondsParams
 )
 
 var (
@@ -141,18 +142,18 @@ var (
 )
 
 var (
-	ActionCreateValidator      = tags.ActionCreateValidator
-	ActionEditValidator        = tags.ActionEditValidator
-	ActionDelegate             = tags.ActionDelegate
-	ActionBeginUnbonding       = tags.ActionBeginUnbonding
-	ActionCompleteUnbonding    = tags.ActionCompleteUnbonding
-	ActionBeginRedelegation    = tags.ActionBeginRedelegation
-	ActionCompleteRedelegation = tags.ActionCompleteRedelegation
-
-	TagAction       = tags.Action
+	EventTypeCreateValidator      = tags.EventTypeCreateValidator
+	EventTypeEditValidator        = tags.EventTypeEditValidator
+	EventTypeDelegate             = tags.EventTypeDelegate
+	EventTypeBeginUnbonding       = tags.EventTypeBeginUnbonding
+	EventTypeCompleteUnbonding    = tags.EventTypeCompleteUnbonding
+	EventTypeBeginRedelegation    = tags.EventTypeBeginRedelegation
+	EventTypeCompleteRedelegation = tags.EventTypeCompleteRedelegation
+
 	TagSrcValidator = tags.SrcValidator
 	TagDstValidator = tags.DstValidator
 	TagDelegator    = tags.Delegator
 	TagMoniker      = tags.Moniker
 	TagIdentity     = tags.Identity
+	TagEndTime      = tags.EndTime
 )
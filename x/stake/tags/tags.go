@@ -1,23 +1,20 @@
 // nolint
 package tags
 
-import (
-	sdk "github.com/cosmos/cosmos-sdk/types"
-)
-
+// Types and attribute keys of the events emitted by the stake module. They
+// are indexed as "<type>.<key>" tags, e.g. "delegate.delegator".
 var (
-	ActionCreateValidator      = []byte("create-validator")
-	ActionEditValidator        = []byte("edit-validator")
-	ActionDelegate             = []byte("delegate")
-	ActionBeginUnbonding       = []byte("begin-unbonding")
-	ActionCompleteUnbonding    = []byte("complete-unbonding")
-	ActionBeginRedelegation    = []byte("begin-redelegation")
-	ActionCompleteRedelegation = []byte("complete-redelegation")
+	EventTypeCreateValidator      = "create-validator"
+	EventTypeEditValidator        = "edit-validator"
+	EventTypeDelegate             = "delegate"
+	EventTypeBeginUnbonding       = "begin-unbonding"
+	EventTypeCompleteUnbonding    = "complete-unbonding"
+	EventTypeBeginRedelegation    = "begin-redelegation"
+	EventTypeCompleteRedelegation = "complete-redelegation"
 
-	Action       = sdk.TagAction
-	SrcValidator = sdk.TagSrcValidator
-	DstValidator = sdk.TagDstValidator
-	Delegator    = sdk.TagDelegator
+	SrcValidator = "source-validator"
+	DstValidator = "destination-validator"
+	Delegator    = "delegator"
 	Moniker      = "moniker"
 	Identity     = "identity"
 	EndTime      = "end-time"
@@ -0,0 +1,41 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BeginBlocker applies the scheduled upgrade once it is due. If the running
+// binary has no handler registered for it, the chain halts: the node panics
+// with a message telling the operator which upgrade to install, and must be
+// restarted with a binary implementing it.
+func BeginBlocker(ctx sdk.Context, k Keeper) {
+	plan, found := k.GetUpgradePlan(ctx)
+	if !found {
+		return
+	}
+
+	handler, hasHandler := k.upgradeHandlers[plan.Name]
+	if !plan.ShouldExecute(ctx) {
+		// a binary that already knows this upgrade must not process the
+		// blocks preceding it, as it may already run the new state machine
+		if hasHandler {
+			msg := fmt.Sprintf("BINARY UPDATED BEFORE TRIGGER! UPGRADE \"%s\" - in binary but not executed on chain", plan.Name)
+			ctx.Logger().Error(msg)
+			panic(msg)
+		}
+		return
+	}
+
+	if !hasHandler {
+		msg := fmt.Sprintf("UPGRADE \"%s\" NEEDED at %s, halting at height %d: %s", plan.Name, plan.DueAt(), ctx.BlockHeight(), plan.Info)
+		ctx.Logger().Error(msg)
+		panic(msg)
+	}
+
+	ctx.Logger().Info(fmt.Sprintf("applying upgrade \"%s\" at %s", plan.Name, plan.DueAt()))
+	handler(ctx, plan)
+	k.ClearUpgradePlan(ctx)
+	k.setDone(ctx, plan.Name)
+}
@@ -0,0 +1,111 @@
+package upgrade
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	db := dbm.NewMemDB()
+	key := sdk.NewKVStoreKey("upgrade")
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	header := abci.Header{Height: 10, Time: time.Unix(1000, 0)}
+	ctx := sdk.NewContext(ms, header, false, log.NewNopLogger())
+	return ctx, NewKeeper(codec.New(), key)
+}
+
+func TestPlanValidateBasic(t *testing.T) {
+	tests := []struct {
+		plan       Plan
+		expectPass bool
+	}{
+		{NewHeightPlan("v2", 100, "info"), true},
+		{NewTimePlan("v2", time.Unix(2000, 0), ""), true},
+		{NewHeightPlan("", 100, "info"), false},
+		{NewHeightPlan("v2", 0, "info"), false},
+		{NewHeightPlan("v2", -1, "info"), false},
+		{Plan{Name: "v2", Height: 100, Time: time.Unix(2000, 0)}, false},
+	}
+
+	for i, tc := range tests {
+		if tc.expectPass {
+			require.Nil(t, tc.plan.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, tc.plan.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+func TestScheduleUpgrade(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	// plans in the past are refused
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewHeightPlan("v2", 10, "")))
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewTimePlan("v2", time.Unix(1000, 0), "")))
+
+	plan := NewHeightPlan("v2", 11, "info")
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, plan))
+	stored, found := keeper.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, plan, stored)
+
+	// a new plan overrides the previous one
+	plan = NewHeightPlan("v3", 20, "")
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, plan))
+	stored, found = keeper.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, plan, stored)
+
+	keeper.ClearUpgradePlan(ctx)
+	_, found = keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+}
+
+func TestBeginBlockerHaltsWithoutHandler(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, NewHeightPlan("v2", 11, "")))
+
+	require.NotPanics(t, func() { BeginBlocker(ctx, keeper) })
+
+	ctx = ctx.WithBlockHeight(11)
+	require.Panics(t, func() { BeginBlocker(ctx, keeper) })
+}
+
+func TestBeginBlockerRunsHandler(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, NewTimePlan("v2", time.Unix(2000, 0), "")))
+
+	called := false
+	keeper.SetUpgradeHandler("v2", func(ctx sdk.Context, plan Plan) { called = true })
+
+	// the binary was switched before the upgrade was due
+	require.Panics(t, func() { BeginBlocker(ctx, keeper) })
+	require.False(t, called)
+
+	ctx = ctx.WithBlockTime(time.Unix(2000, 0)).WithBlockHeight(12)
+	require.NotPanics(t, func() { BeginBlocker(ctx, keeper) })
+	require.True(t, called)
+
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+	require.Equal(t, int64(12), keeper.GetDoneHeight(ctx, "v2"))
+
+	// an applied upgrade cannot be scheduled again
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewHeightPlan("v2", 20, "")))
+}
@@ -0,0 +1,19 @@
+/*
+Package upgrade coordinates software upgrades of a running chain.
+
+An upgrade Plan, usually scheduled by a passed governance proposal, names an
+upgrade and the height or time at which it happens. When the plan is due,
+BeginBlocker looks for an UpgradeHandler registered under the same name:
+
+ - if the running binary has none, the node halts with a message naming the
+   upgrade, so that operators can switch to the new binary
+ - if the handler is registered, it runs in the block to migrate the state
+   and the chain carries on with the new binary
+
+Binaries implementing an upgrade register its handler with
+Keeper.SetUpgradeHandler before starting the node. If the upgrade adds,
+renames or deletes stores, they also pass sdk.StoreUpgrades to
+baseapp.SetStoreUpgrades, whose Height is the height the chain halted at for
+the upgrade: the plan height, or the one logged when halting for a time plan.
+*/
+package upgrade
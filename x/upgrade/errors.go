@@ -0,0 +1,21 @@
+//nolint
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	DefaultCodespace sdk.CodespaceType = 11
+
+	CodeInvalidPlan sdk.CodeType = 1
+)
+
+//----------------------------------------
+// Error constructors
+
+func ErrInvalidPlan(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPlan, fmt.Sprintf("Invalid upgrade plan: %s", msg))
+}
@@ -0,0 +1,82 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all upgrade state that must be provided at genesis
+type GenesisState struct {
+	Plan *Plan         `json:"plan"` // scheduled upgrade plan, if any
+	Done []DoneUpgrade `json:"done"` // upgrades already applied
+}
+
+// DoneUpgrade is an applied upgrade and the height it was applied at
+type DoneUpgrade struct {
+	Name   string `json:"name"`
+	Height int64  `json:"height"`
+}
+
+func NewGenesisState(plan *Plan, done []DoneUpgrade) GenesisState {
+	return GenesisState{
+		Plan: plan,
+		Done: done,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// new upgrade genesis
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	if data.Plan != nil {
+		keeper.setUpgradePlan(ctx, *data.Plan)
+	}
+	for _, done := range data.Done {
+		keeper.setDoneHeight(ctx, done.Name, done.Height)
+	}
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper. The
+// GenesisState will contain the scheduled plan and the applied upgrades
+func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	var plan *Plan
+	if p, found := keeper.GetUpgradePlan(ctx); found {
+		plan = &p
+	}
+
+	var done []DoneUpgrade
+	keeper.IterateDoneUpgrades(ctx, func(name string, height int64) (stop bool) {
+		done = append(done, DoneUpgrade{Name: name, Height: height})
+		return false
+	})
+	return NewGenesisState(plan, done)
+}
+
+// ValidateGenesis checks that the plan is valid and that each applied upgrade
+// is named once with a positive height
+func ValidateGenesis(data GenesisState) error {
+	if data.Plan != nil {
+		err := data.Plan.ValidateBasic()
+		if err != nil {
+			return err
+		}
+	}
+	names := make(map[string]bool, len(data.Done))
+	for _, done := range data.Done {
+		if len(done.Name) == 0 {
+			return fmt.Errorf("applied upgrade name cannot be empty")
+		}
+		if names[done.Name] {
+			return fmt.Errorf("duplicate applied upgrade %s", done.Name)
+		}
+		if done.Height <= 0 {
+			return fmt.Errorf("applied upgrade %s must have a positive height, is %d", done.Name, done.Height)
+		}
+		names[done.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitExportGenesis(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+
+	// an empty genesis exports no plan and no applied upgrades
+	InitGenesis(ctx, keeper, DefaultGenesisState())
+	require.Equal(t, DefaultGenesisState(), ExportGenesis(ctx, keeper))
+
+	plan := NewHeightPlan("v3", 20, "info")
+	data := NewGenesisState(&plan, []DoneUpgrade{{"v1", 3}, {"v2", 7}})
+	require.Nil(t, ValidateGenesis(data))
+
+	InitGenesis(ctx, keeper, data)
+	require.Equal(t, int64(3), keeper.GetDoneHeight(ctx, "v1"))
+	require.Equal(t, int64(7), keeper.GetDoneHeight(ctx, "v2"))
+	require.Equal(t, data, ExportGenesis(ctx, keeper))
+}
+
+func TestValidateGenesis(t *testing.T) {
+	invalidPlan := NewHeightPlan("", 20, "")
+	tests := []struct {
+		data       GenesisState
+		expectPass bool
+	}{
+		{DefaultGenesisState(), true},
+		{NewGenesisState(&invalidPlan, nil), false},
+		{NewGenesisState(nil, []DoneUpgrade{{"", 3}}), false},
+		{NewGenesisState(nil, []DoneUpgrade{{"v1", 0}}), false},
+		{NewGenesisState(nil, []DoneUpgrade{{"v1", 3}, {"v1", 7}}), false},
+	}
+
+	for i, tc := range tests {
+		if tc.expectPass {
+			require.Nil(t, ValidateGenesis(tc.data), "test: %v", i)
+		} else {
+			require.NotNil(t, ValidateGenesis(tc.data), "test: %v", i)
+		}
+	}
+}
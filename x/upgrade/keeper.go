@@ -0,0 +1,124 @@
+package upgrade
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// key prefix bytes
+var (
+	PlanKey = []byte{0x00} // key for the currently scheduled plan
+	DoneKey = []byte{0x01} // prefix for the heights at which upgrades were applied
+)
+
+// get the key under which the height of a done upgrade is stored
+func GetDoneKey(name string) []byte {
+	return append(DoneKey, []byte(name)...)
+}
+
+// UpgradeHandler migrates the state of the application when the upgrade of
+// the same name is triggered
+type UpgradeHandler func(ctx sdk.Context, plan Plan)
+
+// Keeper of the upgrade store
+type Keeper struct {
+	storeKey        sdk.StoreKey
+	cdc             *codec.Codec
+	upgradeHandlers map[string]UpgradeHandler
+}
+
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey) Keeper {
+	return Keeper{
+		storeKey:        key,
+		cdc:             cdc,
+		upgradeHandlers: make(map[string]UpgradeHandler),
+	}
+}
+
+// SetUpgradeHandler registers the handler run when the upgrade of the given
+// name is triggered. Binaries register the handlers of the upgrades they
+// implement before starting.
+func (k Keeper) SetUpgradeHandler(name string, handler UpgradeHandler) {
+	k.upgradeHandlers[name] = handler
+}
+
+// ScheduleUpgrade sets the upgrade plan, overwriting any previously
+// scheduled one
+func (k Keeper) ScheduleUpgrade(ctx sdk.Context, plan Plan) sdk.Error {
+	err := plan.ValidateBasic()
+	if err != nil {
+		return err
+	}
+	if plan.ShouldExecute(ctx) {
+		return ErrInvalidPlan(DefaultCodespace, fmt.Sprintf("upgrade cannot be scheduled in the past (%s)", plan.DueAt()))
+	}
+	if k.GetDoneHeight(ctx, plan.Name) != 0 {
+		return ErrInvalidPlan(DefaultCodespace, fmt.Sprintf("upgrade with name %s has already been applied", plan.Name))
+	}
+
+	k.setUpgradePlan(ctx, plan)
+	return nil
+}
+
+func (k Keeper) setUpgradePlan(ctx sdk.Context, plan Plan) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(PlanKey, k.cdc.MustMarshalBinary(plan))
+}
+
+// GetUpgradePlan returns the currently scheduled plan, if any
+func (k Keeper) GetUpgradePlan(ctx sdk.Context) (plan Plan, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(PlanKey)
+	if bz == nil {
+		return plan, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &plan)
+	return plan, true
+}
+
+// ClearUpgradePlan removes the currently scheduled plan, if any
+func (k Keeper) ClearUpgradePlan(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(PlanKey)
+}
+
+// GetDoneHeight returns the height at which the upgrade of the given name
+// was applied, 0 if it never was
+func (k Keeper) GetDoneHeight(ctx sdk.Context, name string) int64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetDoneKey(name))
+	if bz == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+// IterateDoneUpgrades iterates over the applied upgrades by name, with the
+// height at which they were applied
+func (k Keeper) IterateDoneUpgrades(ctx sdk.Context, fn func(name string, height int64) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, DoneKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		name := string(iterator.Key()[len(DoneKey):])
+		height := int64(binary.BigEndian.Uint64(iterator.Value()))
+		if fn(name, height) {
+			break
+		}
+	}
+}
+
+func (k Keeper) setDone(ctx sdk.Context, name string) {
+	k.setDoneHeight(ctx, name, ctx.BlockHeight())
+}
+
+func (k Keeper) setDoneHeight(ctx sdk.Context, name string, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	store.Set(GetDoneKey(name), bz)
+}
@@ -0,0 +1,67 @@
+package upgrade
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Plan specifies a software upgrade. The upgrade is triggered either at the
+// given block height or by the first block whose time is past the given
+// time, exactly one of both must be set.
+type Plan struct {
+	Name   string    `json:"name"`   // name of the upgrade, matched against the registered upgrade handlers
+	Time   time.Time `json:"time"`   // time after which the upgrade is triggered
+	Height int64     `json:"height"` // height at which the upgrade is triggered
+	Info   string    `json:"info"`   // any application specific information, e.g. where to fetch the new binary
+}
+
+func NewHeightPlan(name string, height int64, info string) Plan {
+	return Plan{Name: name, Height: height, Info: info}
+}
+
+func NewTimePlan(name string, t time.Time, info string) Plan {
+	return Plan{Name: name, Time: t, Info: info}
+}
+
+// ValidateBasic performs stateless checks on the plan
+func (p Plan) ValidateBasic() sdk.Error {
+	if len(strings.TrimSpace(p.Name)) == 0 {
+		return ErrInvalidPlan(DefaultCodespace, "name cannot be empty")
+	}
+	if p.Height < 0 {
+		return ErrInvalidPlan(DefaultCodespace, "height cannot be negative")
+	}
+	if p.Time.IsZero() == (p.Height == 0) {
+		return ErrInvalidPlan(DefaultCodespace, "exactly one of time and height must be set")
+	}
+	return nil
+}
+
+// ShouldExecute returns true if the plan is due at the block of the context
+func (p Plan) ShouldExecute(ctx sdk.Context) bool {
+	if !p.Time.IsZero() {
+		return !ctx.BlockHeader().Time.Before(p.Time)
+	}
+	if p.Height > 0 {
+		return ctx.BlockHeight() >= p.Height
+	}
+	return false
+}
+
+// DueAt returns a human readable description of when the plan is due
+func (p Plan) DueAt() string {
+	if !p.Time.IsZero() {
+		return fmt.Sprintf("time: %s", p.Time.UTC().Format(time.RFC3339))
+	}
+	return fmt.Sprintf("height: %d", p.Height)
+}
+
+func (p Plan) String() string {
+	return fmt.Sprintf(`Upgrade Plan
+  Name: %s
+  %s
+  Info: %s`, p.Name, p.DueAt(), p.Info)
+}